@@ -4,17 +4,32 @@ import (
 	"database/sql"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nerijusro/scootinAboot/config"
+	"github.com/nerijusro/scootinAboot/services/apikey"
 	"github.com/nerijusro/scootinAboot/services/auth"
 	"github.com/nerijusro/scootinAboot/services/client"
+	"github.com/nerijusro/scootinAboot/services/idempotency"
+	"github.com/nerijusro/scootinAboot/services/maintenance"
+	"github.com/nerijusro/scootinAboot/services/operator"
+	"github.com/nerijusro/scootinAboot/services/parking"
+	"github.com/nerijusro/scootinAboot/services/pricing"
+	"github.com/nerijusro/scootinAboot/services/reservation"
 	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/services/telemetry"
 	"github.com/nerijusro/scootinAboot/services/trip"
+	"github.com/nerijusro/scootinAboot/services/zone"
 	"github.com/nerijusro/scootinAboot/types/interfaces"
 	"github.com/nerijusro/scootinAboot/utils"
 )
 
+const (
+	clientTokenAudience = "client"
+	adminTokenAudience  = "admin"
+)
+
 type APIServer struct {
 	address *utils.ServerAddress
 	db      *sql.DB
@@ -29,10 +44,15 @@ func (s *APIServer) Run(wg *sync.WaitGroup) error {
 
 	serviceLocator := buildServiceLocator(s.db)
 	authService := serviceLocator.AuthMiddlewares["authMiddleware"]
-	routerGroups := enableAuthenticationAndGetRouterGroups(ginEngine, authService)
+	idempotency := serviceLocator.IdempotencyMiddlewares["idempotencyMiddleware"]
+	routes := utils.NewRoutes(ginEngine, authService, serviceLocator.RateLimiters, idempotency)
 
 	for _, handler := range serviceLocator.EndpointHandlers {
-		handler.RegisterEndpoints(routerGroups)
+		handler.RegisterEndpoints(routes)
+	}
+
+	for _, job := range serviceLocator.BackgroundJobs {
+		go job.Run()
 	}
 
 	wg.Add(1)
@@ -47,45 +67,157 @@ func (s *APIServer) Run(wg *sync.WaitGroup) error {
 }
 
 func buildServiceLocator(db *sql.DB) *utils.ServiceLocator {
-	authService := utils.NewAuthService(config.Envs.StaticAdminApiKey, config.Envs.StaticUserApiKey)
+	clientTokens := utils.NewTokenService(
+		config.Envs.ClientTokenSecret,
+		time.Duration(config.Envs.ClientTokenTtl)*time.Minute,
+		clientTokenAudience)
+	adminTokens := utils.NewTokenService(
+		config.Envs.AdminTokenSecret,
+		time.Duration(config.Envs.AdminTokenTtl)*time.Minute,
+		adminTokenAudience)
+
+	repositories := buildRepositories(db)
+
+	apiKeysRepository := apikey.NewCachingRepository(
+		repositories.apiKeys,
+		time.Duration(config.Envs.ApiKeyCacheTtl)*time.Second)
+	apiKeysValidator := apikey.NewApiKeyValidator()
+	apiKeyHandler := apikey.NewApiKeyHandler(apiKeysRepository, apiKeysValidator)
+
+	authService := utils.NewAuthService(
+		config.Envs.StaticAdminApiKey,
+		config.Envs.StaticUserApiKey,
+		config.Envs.AuthBootstrapMode,
+		clientTokens,
+		adminTokens,
+		apiKeysRepository,
+		repositories.deviceSecrets)
 
 	authHandler := auth.NewAuthorizationHandler(authService)
 
-	clientsRepository := client.NewRepository(db)
-	clientHandler := client.NewClientsHandler(clientsRepository)
+	idempotencyService := idempotency.NewIdempotencyService(
+		repositories.idempotency,
+		time.Duration(config.Envs.IdempotencyKeyTtl)*time.Hour)
 
-	scootersRepository := scooter.NewRepository(db)
-	scootersRequestValidator := scooter.NewScooterValidator()
-	scootersHandler := scooter.NewScooterHandler(scootersRepository, scootersRequestValidator)
+	clientsRepository := repositories.clients
+	clientsValidator := client.NewClientValidator()
+	clientHandler := client.NewClientsHandler(clientsRepository, clientsValidator, clientTokens, time.Duration(config.Envs.ClientSessionMaxAge)*time.Hour)
+
+	operatorsRepository := repositories.operators
+	operatorsValidator := operator.NewOperatorValidator()
+	operatorHandler := operator.NewOperatorHandler(operatorsRepository, operatorsValidator, adminTokens)
 
-	tripsRepository := trip.NewRepository(db)
+	scootersRepository := repositories.scooters
+	scootersRequestValidator := scooter.NewScooterValidator()
+	scootersHandler := scooter.NewScooterHandler(
+		scootersRepository,
+		repositories.deviceSecrets,
+		scootersRequestValidator,
+		config.Envs.LowBatteryThreshold)
+
+	ticketsValidator := maintenance.NewMaintenanceTicketValidator()
+	maintenanceHandler := maintenance.NewMaintenanceHandler(
+		repositories.tickets,
+		scootersRepository,
+		ticketsValidator,
+		config.Envs.LowBatteryThreshold)
+
+	telemetryValidator := telemetry.NewTelemetryValidator()
+	telemetryHandler := telemetry.NewTelemetryHandler(
+		repositories.telemetry,
+		scootersRepository,
+		telemetryValidator,
+		config.Envs.LowBatteryThreshold)
+
+	tripsRepository := repositories.trips
 	tripsValidator := trip.NewTripValidator()
-	tripHandler := trip.NewTripHandler(tripsValidator, tripsRepository, scootersRepository, clientsRepository)
+	fareCalculator := pricing.NewFareCalculator(
+		config.Envs.PricingCurrency,
+		config.Envs.PricingUnlockFee,
+		config.Envs.PricingPerMinuteRate,
+		config.Envs.PricingPausedRate,
+		config.Envs.PricingPerKmRate,
+		config.Envs.PricingMinimumFare)
+	reservationsRepository := repositories.reservations
+	reservationsValidator := reservation.NewReservationValidator()
+	reservationHandler := reservation.NewReservationHandler(
+		reservationsValidator,
+		reservationsRepository,
+		scootersRepository,
+		clientsRepository,
+		time.Duration(config.Envs.ReservationWindow)*time.Minute)
+	reservationExpirer := reservation.NewReservationExpirer(
+		reservationsRepository,
+		scootersRepository,
+		time.Duration(config.Envs.ReservationExpirerInterval)*time.Second)
+
+	zonesRepository := repositories.zones
+	zonesValidator := zone.NewZoneValidator()
+	zoneHandler := zone.NewZoneHandler(zonesRepository, zonesValidator)
+	stationsRepository := repositories.stations
+	stationsValidator := parking.NewParkingStationValidator()
+	parkingStationHandler := parking.NewParkingStationHandler(stationsRepository, stationsValidator)
+	geofence := zone.NewGeofence(zonesRepository, stationsRepository, config.Envs.SlowZoneSpeedLimit)
+
+	tripHandler := trip.NewTripHandler(
+		tripsValidator,
+		tripsRepository,
+		scootersRepository,
+		clientsRepository,
+		reservationsRepository,
+		geofence,
+		fareCalculator,
+		config.Envs.LowBatteryThreshold)
+	tripSweeper := trip.NewTripSweeper(
+		tripsRepository,
+		scootersRepository,
+		clientsRepository,
+		fareCalculator,
+		config.Envs.LowBatteryThreshold,
+		time.Duration(config.Envs.TripIdleTimeout)*time.Minute,
+		time.Duration(config.Envs.TripSweeperInterval)*time.Second)
 
 	serviceLocator := &utils.ServiceLocator{
-		EndpointHandlers: make(map[string]interfaces.EndpointHandler),
-		AuthMiddlewares:  make(map[string]interfaces.AuthService),
+		EndpointHandlers:       make(map[string]interfaces.EndpointHandler),
+		AuthMiddlewares:        make(map[string]interfaces.AuthService),
+		RateLimiters:           make(map[string]interfaces.RateLimiter),
+		IdempotencyMiddlewares: make(map[string]interfaces.IdempotencyService),
+		BackgroundJobs:         make(map[string]interfaces.BackgroundJob),
 	}
 
 	serviceLocator.RegisterAuthMiddleware("authMiddleware", authService)
-
-	serviceLocator.RegisterEndpointHandler("authHandler", authHandler)
+	serviceLocator.RegisterIdempotencyMiddleware("idempotencyMiddleware", idempotencyService)
+	registerRateLimiter(serviceLocator, utils.PublicRoutes, config.Envs.PublicRateLimit, config.Envs.PublicRateLimitBurst)
+	registerRateLimiter(serviceLocator, utils.ClientRoutes, config.Envs.ClientRateLimit, config.Envs.ClientRateLimitBurst)
+	registerRateLimiter(serviceLocator, utils.AdminRoutes, config.Envs.AdminRateLimit, config.Envs.AdminRateLimitBurst)
+	registerRateLimiter(serviceLocator, utils.DeviceRoutes, config.Envs.DeviceRateLimit, config.Envs.DeviceRateLimitBurst)
+
+	// Static keys are only handed out while bootstrapping, otherwise anyone could ask for them
+	if config.Envs.AuthBootstrapMode {
+		serviceLocator.RegisterEndpointHandler("authHandler", authHandler)
+	}
+	serviceLocator.RegisterEndpointHandler("operatorHandler", operatorHandler)
+	serviceLocator.RegisterEndpointHandler("apiKeyHandler", apiKeyHandler)
 	serviceLocator.RegisterEndpointHandler("clientHandler", clientHandler)
 	serviceLocator.RegisterEndpointHandler("scootersHandler", scootersHandler)
+	serviceLocator.RegisterEndpointHandler("maintenanceHandler", maintenanceHandler)
+	serviceLocator.RegisterEndpointHandler("reservationHandler", reservationHandler)
+	serviceLocator.RegisterEndpointHandler("zoneHandler", zoneHandler)
+	serviceLocator.RegisterEndpointHandler("parkingStationHandler", parkingStationHandler)
 	serviceLocator.RegisterEndpointHandler("tripHandler", tripHandler)
+	serviceLocator.RegisterEndpointHandler("telemetryHandler", telemetryHandler)
+
+	serviceLocator.RegisterBackgroundJob("reservationExpirer", reservationExpirer)
+	serviceLocator.RegisterBackgroundJob("tripSweeper", tripSweeper)
 
 	return serviceLocator
 }
 
-func enableAuthenticationAndGetRouterGroups(e *gin.Engine, authService interfaces.AuthService) map[string]*gin.RouterGroup {
-	adminAuthorized := e.Group("/admin", authService.AuthenticateAdmin)
-	userAuthorized := e.Group("/client", authService.AuthenticateClient)
-
-	routerGroups := map[string]*gin.RouterGroup{
-		"root":   &e.RouterGroup,
-		"admin":  adminAuthorized,
-		"client": userAuthorized,
+// registerRateLimiter leaves the route group unlimited when its rate is not positive.
+func registerRateLimiter(serviceLocator *utils.ServiceLocator, routeGroup string, ratePerMinute int, burst int) {
+	if ratePerMinute <= 0 {
+		return
 	}
 
-	return routerGroups
+	serviceLocator.RegisterRateLimiter(routeGroup, utils.NewRateLimiter(ratePerMinute, max(burst, 1)))
 }
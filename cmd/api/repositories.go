@@ -0,0 +1,73 @@
+package api
+
+import (
+	"database/sql"
+
+	"github.com/nerijusro/scootinAboot/config"
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/services/apikey"
+	"github.com/nerijusro/scootinAboot/services/client"
+	"github.com/nerijusro/scootinAboot/services/idempotency"
+	"github.com/nerijusro/scootinAboot/services/maintenance"
+	"github.com/nerijusro/scootinAboot/services/operator"
+	"github.com/nerijusro/scootinAboot/services/parking"
+	"github.com/nerijusro/scootinAboot/services/reservation"
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/services/telemetry"
+	"github.com/nerijusro/scootinAboot/services/trip"
+	"github.com/nerijusro/scootinAboot/services/zone"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+)
+
+type repositories struct {
+	clients       interfaces.ClientRepository
+	scooters      interfaces.ScooterRepository
+	deviceSecrets interfaces.DeviceSecretRepository
+	telemetry     interfaces.TelemetryRepository
+	tickets       interfaces.MaintenanceTicketRepository
+	trips         interfaces.TripRepository
+	reservations  interfaces.ReservationRepository
+	zones         interfaces.ZoneRepository
+	stations      interfaces.ParkingStationRepository
+	operators     interfaces.OperatorRepository
+	apiKeys       interfaces.ApiKeyRepository
+	idempotency   interfaces.IdempotencyRepository
+}
+
+func buildRepositories(sqlDb *sql.DB) *repositories {
+	if config.Envs.StorageType == enums.InMemoryStorage {
+		storage := db.NewInMemoryStorage()
+		scooters := scooter.NewInMemoryRepository(storage)
+		return &repositories{
+			clients:       client.NewInMemoryRepository(storage),
+			scooters:      scooters,
+			deviceSecrets: scooters,
+			telemetry:     telemetry.NewInMemoryRepository(storage),
+			tickets:       maintenance.NewInMemoryRepository(storage),
+			trips:         trip.NewInMemoryRepository(storage),
+			reservations:  reservation.NewInMemoryRepository(storage),
+			zones:         zone.NewInMemoryRepository(storage),
+			stations:      parking.NewInMemoryRepository(storage),
+			operators:     operator.NewInMemoryRepository(storage),
+			apiKeys:       apikey.NewInMemoryRepository(storage),
+			idempotency:   idempotency.NewInMemoryRepository(storage),
+		}
+	}
+
+	scooters := scooter.NewRepository(sqlDb)
+	return &repositories{
+		clients:       client.NewRepository(sqlDb),
+		scooters:      scooters,
+		deviceSecrets: scooters,
+		telemetry:     telemetry.NewRepository(sqlDb),
+		tickets:       maintenance.NewRepository(sqlDb),
+		trips:         trip.NewRepository(sqlDb),
+		reservations:  reservation.NewRepository(sqlDb),
+		zones:         zone.NewRepository(sqlDb),
+		stations:      parking.NewRepository(sqlDb),
+		operators:     operator.NewRepository(sqlDb),
+		apiKeys:       apikey.NewRepository(sqlDb),
+		idempotency:   idempotency.NewRepository(sqlDb),
+	}
+}
@@ -54,10 +54,10 @@ func (c *MobileClientDummy) Run(wg *sync.WaitGroup) {
 	go c.simulateClient(wg, *clientStaticApiKey, clientThree)
 }
 
-func (c *MobileClientDummy) simulateClient(wg *sync.WaitGroup, staticApiKey string, client *types.MobileClient) error {
+func (c *MobileClientDummy) simulateClient(wg *sync.WaitGroup, staticApiKey string, client *types.CreateUserResponse) error {
 	defer wg.Done()
 	for i := 0; i > -1; i++ {
-		err := c.simulateTrip(staticApiKey, client.ID.String())
+		err := c.simulateTrip(staticApiKey, client.Token)
 		if err != nil {
 			break
 		}
@@ -68,7 +68,7 @@ func (c *MobileClientDummy) simulateClient(wg *sync.WaitGroup, staticApiKey stri
 	return nil
 }
 
-func (c *MobileClientDummy) simulateTrip(staticApiKey string, clientID string) error {
+func (c *MobileClientDummy) simulateTrip(staticApiKey string, clientToken string) error {
 	scootersResponse, err := c.getScootersInArea(staticApiKey, 24.0, 26.0, 53.0, 55.0)
 	if err != nil {
 		log.Fatal(err)
@@ -76,7 +76,7 @@ func (c *MobileClientDummy) simulateTrip(staticApiKey string, clientID string) e
 	}
 
 	scooter := scootersResponse.Scooters[rand.Intn(len(scootersResponse.Scooters))]
-	startTripResponse, err := c.startTrip(staticApiKey, clientID, scooter.ID)
+	startTripResponse, err := c.startTrip(staticApiKey, clientToken, scooter.ID)
 	if err != nil {
 		return err
 	}
@@ -90,7 +90,7 @@ func (c *MobileClientDummy) simulateTrip(staticApiKey string, clientID string) e
 		newLocation = c.getNewRandomLocation()
 		err = c.updateTrip(
 			staticApiKey,
-			clientID,
+			clientToken,
 			startTripResponse.TripID,
 			types.TripUpdateRequest{
 				Location:    newLocation,
@@ -109,7 +109,7 @@ func (c *MobileClientDummy) simulateTrip(staticApiKey string, clientID string) e
 	newLocation = c.getNewRandomLocation()
 	err = c.updateTrip(
 		staticApiKey,
-		clientID,
+		clientToken,
 		startTripResponse.TripID,
 		types.TripUpdateRequest{
 			Location:    newLocation,
@@ -126,7 +126,7 @@ func (c *MobileClientDummy) simulateTrip(staticApiKey string, clientID string) e
 	return nil
 }
 
-func (c *MobileClientDummy) updateTrip(staticApiKey string, clientID string, tripID uuid.UUID, updatedTrip types.TripUpdateRequest) error {
+func (c *MobileClientDummy) updateTrip(staticApiKey string, clientToken string, tripID uuid.UUID, updatedTrip types.TripUpdateRequest) error {
 	requestBody := updatedTrip
 
 	marshalledRequestBody, _ := json.Marshal(requestBody)
@@ -136,7 +136,7 @@ func (c *MobileClientDummy) updateTrip(staticApiKey string, clientID string, tri
 	}
 
 	request.Header.Set("x-api-key", staticApiKey)
-	request.Header.Set("client-id", clientID)
+	request.Header.Set("Authorization", "Bearer "+clientToken)
 	resp, err := http.DefaultClient.Do(request)
 	if err != nil {
 		log.Println("Error updating trip", err)
@@ -151,7 +151,7 @@ func (c *MobileClientDummy) updateTrip(staticApiKey string, clientID string, tri
 	return nil
 }
 
-func (c *MobileClientDummy) startTrip(staticApiKey string, clientID string, scooterID uuid.UUID) (*types.TripEvent, error) {
+func (c *MobileClientDummy) startTrip(staticApiKey string, clientToken string, scooterID uuid.UUID) (*types.TripEvent, error) {
 	requestBody := types.StartTripRequest{
 		ScooterID: scooterID,
 		CreatedAt: time.Now(),
@@ -164,7 +164,7 @@ func (c *MobileClientDummy) startTrip(staticApiKey string, clientID string, scoo
 	}
 
 	request.Header.Set("x-api-key", staticApiKey)
-	request.Header.Set("client-id", clientID)
+	request.Header.Set("Authorization", "Bearer "+clientToken)
 	resp, err := http.DefaultClient.Do(request)
 	if err != nil {
 		log.Println("Error creating trip", err)
@@ -231,7 +231,7 @@ func (c *MobileClientDummy) createScooters(staticApiKey string) error {
 			Longitude: 25.279651,
 			Latitude:  54.687157,
 		},
-		IsAvailable: true,
+		Status: enums.AvailableScooter,
 	}
 
 	marshalledRequestBody, _ := json.Marshal(requestBody)
@@ -257,9 +257,12 @@ func (c *MobileClientDummy) createScooters(staticApiKey string) error {
 	return nil
 }
 
-func (c *MobileClientDummy) spawnUser(staticApiKey string, fullName string) *types.MobileClient {
+func (c *MobileClientDummy) spawnUser(staticApiKey string, fullName string) *types.CreateUserResponse {
+	// Every run spawns new riders, so their emails must not collide with the ones of previous runs
 	requestBody := types.CreateUserRequest{
 		FullName: fullName,
+		Email:    "rider-" + uuid.NewString() + "@example.com",
+		Password: "dummy-password",
 	}
 
 	marshalledRequestBody, _ := json.Marshal(requestBody)
@@ -281,7 +284,7 @@ func (c *MobileClientDummy) spawnUser(staticApiKey string, fullName string) *typ
 		return nil
 	}
 
-	var userResponse types.MobileClient
+	var userResponse types.CreateUserResponse
 	if err := json.NewDecoder(resp.Body).Decode(&userResponse); err != nil {
 		log.Fatal(err)
 	}
@@ -11,11 +11,19 @@ import (
 	"github.com/nerijusro/scootinAboot/cmd/child"
 	"github.com/nerijusro/scootinAboot/config"
 	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types/enums"
 	"github.com/nerijusro/scootinAboot/utils"
 )
 
 func main() {
-	db := createAndInitializeMySqlStorage()
+	if err := config.Envs.ValidateTokenSecrets(); err != nil {
+		log.Fatal(err)
+	}
+
+	var db *sql.DB
+	if config.Envs.StorageType != enums.InMemoryStorage {
+		db = createAndInitializeMySqlStorage()
+	}
 
 	serverAddress := utils.NewServerAddress(config.Envs.Protocol, config.Envs.PublicHost, config.Envs.Port)
 	server := api.NewAPIServer(serverAddress, db)
@@ -26,7 +34,11 @@ func main() {
 		log.Println("Error starting Gin server:", err)
 	}
 
-	go mobileClientDummy.Run(&wg)
+	// The dummy relies on static keys, which are only served in bootstrap mode
+	if config.Envs.AuthBootstrapMode {
+		go mobileClientDummy.Run(&wg)
+	}
+
 	wg.Wait()
 }
 
@@ -0,0 +1,7 @@
+-- Events published before sequences were enforced may repeat a sequence, they are renumbered in their original order
+-- so that the unique (trip_id, sequence) constraint can be added.
+UPDATE events e JOIN (
+  SELECT id, ROW_NUMBER() OVER (PARTITION BY trip_id ORDER BY sequence, created_at, id) AS renumbered_sequence FROM events
+) renumbered ON renumbered.id = e.id
+SET e.sequence = renumbered.renumbered_sequence
+WHERE e.sequence <> renumbered.renumbered_sequence;
\ No newline at end of file
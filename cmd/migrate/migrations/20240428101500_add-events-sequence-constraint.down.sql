@@ -0,0 +1 @@
+ALTER TABLE events ADD INDEX `events_trip_id` (`trip_id`), DROP INDEX unique_trip_sequence;
\ No newline at end of file
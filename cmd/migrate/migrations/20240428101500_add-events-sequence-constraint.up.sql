@@ -0,0 +1 @@
+ALTER TABLE events ADD CONSTRAINT unique_trip_sequence UNIQUE (`trip_id`, `sequence`);
\ No newline at end of file
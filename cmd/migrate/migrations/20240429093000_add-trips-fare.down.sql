@@ -0,0 +1 @@
+ALTER TABLE trips DROP COLUMN `fare`;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE trips ADD COLUMN `fare` INT NULL;
\ No newline at end of file
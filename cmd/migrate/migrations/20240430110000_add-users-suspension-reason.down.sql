@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN `suspension_reason`;
\ No newline at end of file
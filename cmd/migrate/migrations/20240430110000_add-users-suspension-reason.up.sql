@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN `suspension_reason` VARCHAR(255) NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE scooters DROP INDEX `scooters_latitude_id`, DROP INDEX `scooters_longitude_id`;
\ No newline at end of file
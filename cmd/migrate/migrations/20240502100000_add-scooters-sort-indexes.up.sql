@@ -0,0 +1 @@
+ALTER TABLE scooters ADD INDEX `scooters_latitude_id` (`latitude`, `id`), ADD INDEX `scooters_longitude_id` (`longitude`, `id`);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS operators;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS operators (
+  `id` BINARY(16) NOT NULL PRIMARY KEY,
+  `username` VARCHAR(64) NOT NULL UNIQUE,
+  `password_hash` VARCHAR(255) NOT NULL,
+  `opt_lock_version` INT NOT NULL DEFAULT 0
+);
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+  `id` BINARY(16) NOT NULL PRIMARY KEY,
+  `name` VARCHAR(255) NOT NULL,
+  `scope` VARCHAR(16) NOT NULL,
+  `key_hash` CHAR(64) NOT NULL UNIQUE,
+  `expires_at` TIMESTAMP NULL,
+  `is_revoked` BOOLEAN NOT NULL DEFAULT FALSE,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
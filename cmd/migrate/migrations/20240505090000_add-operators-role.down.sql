@@ -0,0 +1 @@
+ALTER TABLE operators DROP COLUMN `role`;
\ No newline at end of file
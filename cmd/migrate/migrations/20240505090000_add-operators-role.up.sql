@@ -0,0 +1 @@
+ALTER TABLE operators ADD COLUMN `role` VARCHAR(16) NOT NULL DEFAULT 'admin' AFTER `username`;
\ No newline at end of file
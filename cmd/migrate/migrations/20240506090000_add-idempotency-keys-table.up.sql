@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  `caller` VARCHAR(255) NOT NULL,
+  `idempotency_key` VARCHAR(255) NOT NULL,
+  `request_hash` CHAR(64) NOT NULL,
+  `status_code` INT NULL,
+  `response_body` MEDIUMBLOB NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`caller`, `idempotency_key`),
+  INDEX `idempotency_keys_created_at` (`created_at`)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS reservations;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS reservations (
+  `id` BINARY(16) NOT NULL PRIMARY KEY,
+  `user_id` BINARY(16) NOT NULL,
+  `scooter_id` BINARY(16) NOT NULL,
+  `status` VARCHAR(16) NOT NULL,
+  `expires_at` TIMESTAMP NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `active_user_id` BINARY(16) AS (IF(`status` = 'active', `user_id`, NULL)) STORED UNIQUE,
+  INDEX `reservations_status_expires_at` (`status`, `expires_at`),
+  FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+  FOREIGN KEY (`scooter_id`) REFERENCES scooters(`id`)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS reservation_events;
\ No newline at end of file
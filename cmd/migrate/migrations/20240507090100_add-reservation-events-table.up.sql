@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS reservation_events (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `reservation_id` BINARY(16) NOT NULL,
+  `event_type` VARCHAR(255) NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (`reservation_id`) REFERENCES reservations(`id`)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE events DROP COLUMN `actor`, DROP COLUMN `reason`;
\ No newline at end of file
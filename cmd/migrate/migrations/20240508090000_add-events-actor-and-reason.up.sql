@@ -0,0 +1 @@
+ALTER TABLE events ADD COLUMN `actor` VARCHAR(255) NULL, ADD COLUMN `reason` VARCHAR(1024) NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS zones;
\ No newline at end of file
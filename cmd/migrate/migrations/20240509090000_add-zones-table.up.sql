@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS zones (
+  `id` BINARY(16) NOT NULL PRIMARY KEY,
+  `name` VARCHAR(255) NOT NULL,
+  `zone_type` VARCHAR(16) NOT NULL,
+  `geometry` JSON NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS zone_violations;
\ No newline at end of file
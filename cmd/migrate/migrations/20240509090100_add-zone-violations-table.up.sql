@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS zone_violations (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `trip_id` BINARY(16) NOT NULL,
+  `sequence` INT NOT NULL,
+  `zone_id` BINARY(16) NULL,
+  `violation_type` VARCHAR(32) NOT NULL,
+  `latitude` FLOAT NOT NULL,
+  `longitude` FLOAT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL,
+  FOREIGN KEY (`trip_id`) REFERENCES trips(`id`)
+);
\ No newline at end of file
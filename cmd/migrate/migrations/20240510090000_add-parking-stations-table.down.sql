@@ -0,0 +1 @@
+DROP TABLE IF EXISTS parking_stations;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS parking_stations (
+  `id` BINARY(16) NOT NULL PRIMARY KEY,
+  `name` VARCHAR(255) NOT NULL,
+  `latitude` FLOAT NOT NULL,
+  `longitude` FLOAT NOT NULL,
+  `radius_m` FLOAT NULL,
+  `geometry` JSON NULL,
+  `capacity` INT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE scooters DROP COLUMN `battery_level`, DROP COLUMN `odometer_m`, DROP COLUMN `firmware_version`, DROP COLUMN `last_seen_at`;
\ No newline at end of file
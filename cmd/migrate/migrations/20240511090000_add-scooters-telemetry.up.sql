@@ -0,0 +1 @@
+ALTER TABLE scooters ADD COLUMN `battery_level` TINYINT UNSIGNED NOT NULL DEFAULT 100, ADD COLUMN `odometer_m` FLOAT NOT NULL DEFAULT 0, ADD COLUMN `firmware_version` VARCHAR(32) NOT NULL DEFAULT '', ADD COLUMN `last_seen_at` TIMESTAMP NULL;
\ No newline at end of file
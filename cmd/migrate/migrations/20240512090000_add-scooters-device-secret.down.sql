@@ -0,0 +1 @@
+ALTER TABLE scooters DROP COLUMN `device_secret_hash`;
\ No newline at end of file
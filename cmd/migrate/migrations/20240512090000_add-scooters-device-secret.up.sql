@@ -0,0 +1 @@
+ALTER TABLE scooters ADD COLUMN `device_secret_hash` CHAR(64) NULL;
\ No newline at end of file
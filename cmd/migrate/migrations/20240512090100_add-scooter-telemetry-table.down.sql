@@ -0,0 +1 @@
+DROP TABLE IF EXISTS scooter_telemetry;
\ No newline at end of file
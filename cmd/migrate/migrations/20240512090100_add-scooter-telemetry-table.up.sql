@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS scooter_telemetry (
+  `id` BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `scooter_id` BINARY(16) NOT NULL,
+  `latitude` FLOAT NOT NULL,
+  `longitude` FLOAT NOT NULL,
+  `battery_level` TINYINT UNSIGNED NULL,
+  `is_locked` BOOLEAN NULL,
+  `recorded_at` TIMESTAMP(3) NOT NULL,
+  `received_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (`scooter_id`, `recorded_at`),
+  FOREIGN KEY (`scooter_id`) REFERENCES scooters(`id`)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS maintenance_tickets;
\ No newline at end of file
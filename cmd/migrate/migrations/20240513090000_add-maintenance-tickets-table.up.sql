@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS maintenance_tickets (
+  `id` BINARY(16) NOT NULL PRIMARY KEY,
+  `scooter_id` BINARY(16) NOT NULL,
+  `status` VARCHAR(16) NOT NULL,
+  `description` VARCHAR(1024) NOT NULL,
+  `opened_by` VARCHAR(255) NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `resolution` VARCHAR(1024) NULL,
+  `resolved_by` VARCHAR(255) NULL,
+  `resolved_at` TIMESTAMP NULL,
+  INDEX `maintenance_tickets_scooter_id_status` (`scooter_id`, `status`),
+  FOREIGN KEY (`scooter_id`) REFERENCES scooters(`id`)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE scooters DROP INDEX `scooters_status`, DROP COLUMN `status`;
\ No newline at end of file
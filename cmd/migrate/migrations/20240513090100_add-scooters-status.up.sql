@@ -0,0 +1 @@
+ALTER TABLE scooters ADD COLUMN `status` VARCHAR(16) NOT NULL DEFAULT 'available', ADD INDEX `scooters_status` (`status`);
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE scooters SET is_available = (`status` = 'available'), is_retired = (`status` = 'retired');
\ No newline at end of file
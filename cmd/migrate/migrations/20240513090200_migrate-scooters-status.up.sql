@@ -0,0 +1,8 @@
+UPDATE scooters SET `status` = CASE
+  WHEN is_retired = true THEN 'retired'
+  WHEN id IN (SELECT scooter_id FROM trips WHERE is_finished = false) THEN 'in_trip'
+  WHEN id IN (SELECT scooter_id FROM reservations WHERE status = 'active') THEN 'reserved'
+  WHEN is_available = true THEN 'available'
+  WHEN battery_level < 15 THEN 'charging'
+  ELSE 'maintenance'
+END;
\ No newline at end of file
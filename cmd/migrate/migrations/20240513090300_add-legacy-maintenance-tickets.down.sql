@@ -0,0 +1 @@
+DELETE FROM maintenance_tickets WHERE opened_by = 'migration';
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO maintenance_tickets (id, scooter_id, status, description, opened_by)
+SELECT UUID_TO_BIN(UUID(), false), id, 'open', 'Taken out of service before maintenance tickets were introduced', 'migration' FROM scooters WHERE `status` = 'maintenance';
\ No newline at end of file
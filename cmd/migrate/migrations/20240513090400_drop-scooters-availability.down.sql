@@ -0,0 +1 @@
+ALTER TABLE scooters ADD COLUMN `is_available` BOOLEAN NOT NULL DEFAULT FALSE, ADD COLUMN `is_retired` BOOLEAN NOT NULL DEFAULT FALSE;
\ No newline at end of file
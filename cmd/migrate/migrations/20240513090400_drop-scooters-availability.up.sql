@@ -0,0 +1 @@
+ALTER TABLE scooters DROP COLUMN `is_available`, DROP COLUMN `is_retired`;
\ No newline at end of file
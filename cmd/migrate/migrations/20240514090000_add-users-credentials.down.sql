@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN `email`, DROP COLUMN `password_hash`;
\ No newline at end of file
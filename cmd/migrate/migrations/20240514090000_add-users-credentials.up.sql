@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN `email` VARCHAR(255) NULL UNIQUE, ADD COLUMN `password_hash` VARCHAR(255) NULL;
\ No newline at end of file
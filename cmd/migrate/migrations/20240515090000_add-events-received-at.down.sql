@@ -0,0 +1 @@
+ALTER TABLE events DROP COLUMN `received_at`;
\ No newline at end of file
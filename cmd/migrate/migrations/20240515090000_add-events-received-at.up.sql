@@ -0,0 +1 @@
+ALTER TABLE events ADD COLUMN `received_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
\ No newline at end of file
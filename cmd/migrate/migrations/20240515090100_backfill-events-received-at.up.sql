@@ -0,0 +1 @@
+UPDATE events SET `received_at` = `created_at`;
\ No newline at end of file
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	mysqlCnfg "github.com/go-sql-driver/mysql"
+	"github.com/nerijusro/scootinAboot/config"
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/services/projection"
+)
+
+func main() {
+	db, err := db.NewMySqlStorage(mysqlCnfg.Config{
+		User:                 config.Envs.DBUser,
+		Passwd:               config.Envs.DBPassword,
+		Addr:                 config.Envs.DBAddress,
+		DBName:               config.Envs.DBName,
+		Net:                  config.Envs.Net,
+		AllowNativePasswords: config.Envs.AllowNativePasswords,
+		ParseTime:            config.Envs.ParseTime,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	service := projection.NewProjectionService(projection.NewRepository(db, config.Envs.LowBatteryThreshold))
+
+	cmd := os.Args[(len(os.Args) - 1)]
+	if cmd == "rebuild" {
+		projected, err := service.Rebuild()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Printf("Rebuilt %d trips, %d scooters and %d users from events", len(projected.Trips), len(projected.Scooters), len(projected.Users))
+	}
+
+	if cmd == "check" {
+		drift, err := service.CheckConsistency()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, d := range drift {
+			log.Printf("Drift in %s %s: %s is %s, but events project %s", d.Entity, d.ID.String(), d.Field, d.Stored, d.Projected)
+		}
+
+		if len(drift) > 0 {
+			log.Fatalf("Found %d inconsistencies between projections and stored rows", len(drift))
+		}
+
+		log.Println("Projections are consistent with stored rows")
+	}
+}
@@ -3,43 +3,123 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 
 	"github.com/joho/godotenv"
+	"github.com/nerijusro/scootinAboot/types/enums"
 )
 
 type Config struct {
-	Protocol             string
-	PublicHost           string
-	Port                 string
-	DBUser               string
-	DBPassword           string
-	DBAddress            string
-	DBName               string
-	Net                  string
-	AllowNativePasswords bool
-	ParseTime            bool
-	StaticUserApiKey     string
-	StaticAdminApiKey    string
+	Protocol                   string
+	StorageType                enums.StorageType
+	PublicHost                 string
+	Port                       string
+	DBUser                     string
+	DBPassword                 string
+	DBAddress                  string
+	DBName                     string
+	Net                        string
+	AllowNativePasswords       bool
+	ParseTime                  bool
+	StaticUserApiKey           string
+	StaticAdminApiKey          string
+	AuthBootstrapMode          bool
+	ClientTokenSecret          string
+	ClientTokenTtl             int
+	ClientSessionMaxAge        int
+	AdminTokenSecret           string
+	AdminTokenTtl              int
+	ApiKeyCacheTtl             int
+	IdempotencyKeyTtl          int
+	ReservationWindow          int
+	ReservationExpirerInterval int
+	TripIdleTimeout            int
+	TripSweeperInterval        int
+	SlowZoneSpeedLimit         int
+	LowBatteryThreshold        int
+	PublicRateLimit            int
+	PublicRateLimitBurst       int
+	ClientRateLimit            int
+	ClientRateLimitBurst       int
+	AdminRateLimit             int
+	AdminRateLimitBurst        int
+	DeviceRateLimit            int
+	DeviceRateLimitBurst       int
+	PricingCurrency            string
+	PricingUnlockFee           int
+	PricingPerMinuteRate       int
+	PricingPausedRate          int
+	PricingPerKmRate           int
+	PricingMinimumFare         int
 }
 
 var Envs = initConfig()
 
+// sampleTokenSecrets are published in .env, tokens signed with them can be forged by anyone.
+var sampleTokenSecrets = []string{"my_client_token_secret", "my_admin_token_secret"}
+
 func initConfig() Config {
 	godotenv.Load()
 	return Config{
-		Protocol:             getEnv("PROTOCOL", "http"),
-		PublicHost:           getEnv("PUBLIC_HOST", "localhost"),
-		Port:                 getEnv("PORT", "8080"),
-		DBUser:               getEnv("DB_USER", "root"),
-		DBPassword:           getEnv("DB_PASSWORD", "root"),
-		DBAddress:            fmt.Sprintf("%s:%s", getEnv("DB_HOST", "localhost"), getEnv("DB_PORT", "3306")),
-		DBName:               getEnv("DB_NAME", "scootin_aboot"),
-		Net:                  getEnv("DB_NET", "tcp"),
-		AllowNativePasswords: getEnv("DB_ALLOW_NATIVE_PASSWORDS", "true") == "true",
-		ParseTime:            getEnv("DB_PARSE_TIME", "true") == "true",
-		StaticUserApiKey:     getEnv("STATIC_USER_API_KEY", "my_static_user_api_key"),
-		StaticAdminApiKey:    getEnv("STATIC_ADMIN_API_KEY", "my_static_admin_api_key"),
+		Protocol:                   getEnv("PROTOCOL", "http"),
+		StorageType:                enums.StorageType(getEnv("STORAGE_TYPE", string(enums.MySqlStorage))),
+		PublicHost:                 getEnv("PUBLIC_HOST", "localhost"),
+		Port:                       getEnv("PORT", "8080"),
+		DBUser:                     getEnv("DB_USER", "root"),
+		DBPassword:                 getEnv("DB_PASSWORD", "root"),
+		DBAddress:                  fmt.Sprintf("%s:%s", getEnv("DB_HOST", "localhost"), getEnv("DB_PORT", "3306")),
+		DBName:                     getEnv("DB_NAME", "scootin_aboot"),
+		Net:                        getEnv("DB_NET", "tcp"),
+		AllowNativePasswords:       getEnv("DB_ALLOW_NATIVE_PASSWORDS", "true") == "true",
+		ParseTime:                  getEnv("DB_PARSE_TIME", "true") == "true",
+		StaticUserApiKey:           getEnv("STATIC_USER_API_KEY", "my_static_user_api_key"),
+		StaticAdminApiKey:          getEnv("STATIC_ADMIN_API_KEY", "my_static_admin_api_key"),
+		AuthBootstrapMode:          getEnv("AUTH_BOOTSTRAP_MODE", "false") == "true",
+		ClientTokenSecret:          getEnv("CLIENT_TOKEN_SECRET", ""),
+		ClientTokenTtl:             getEnvAsInt("CLIENT_TOKEN_TTL_MINUTES", 24*60),
+		ClientSessionMaxAge:        getEnvAsInt("CLIENT_SESSION_MAX_AGE_HOURS", 30*24),
+		AdminTokenSecret:           getEnv("ADMIN_TOKEN_SECRET", ""),
+		AdminTokenTtl:              getEnvAsInt("ADMIN_TOKEN_TTL_MINUTES", 15),
+		ApiKeyCacheTtl:             getEnvAsInt("API_KEY_CACHE_TTL_SECONDS", 30),
+		IdempotencyKeyTtl:          getEnvAsInt("IDEMPOTENCY_KEY_TTL_HOURS", 24),
+		ReservationWindow:          getEnvAsInt("RESERVATION_WINDOW_MINUTES", 10),
+		ReservationExpirerInterval: getEnvAsInt("RESERVATION_EXPIRER_INTERVAL_SECONDS", 30),
+		TripIdleTimeout:            getEnvAsInt("TRIP_IDLE_TIMEOUT_MINUTES", 60),
+		TripSweeperInterval:        getEnvAsInt("TRIP_SWEEPER_INTERVAL_SECONDS", 60),
+		SlowZoneSpeedLimit:         getEnvAsInt("SLOW_ZONE_SPEED_LIMIT_KMH", 15),
+		LowBatteryThreshold:        getEnvAsInt("LOW_BATTERY_THRESHOLD_PERCENT", 15),
+		PublicRateLimit:            getEnvAsInt("PUBLIC_RATE_LIMIT_PER_MINUTE", 30),
+		PublicRateLimitBurst:       getEnvAsInt("PUBLIC_RATE_LIMIT_BURST", 10),
+		ClientRateLimit:            getEnvAsInt("CLIENT_RATE_LIMIT_PER_MINUTE", 120),
+		ClientRateLimitBurst:       getEnvAsInt("CLIENT_RATE_LIMIT_BURST", 30),
+		AdminRateLimit:             getEnvAsInt("ADMIN_RATE_LIMIT_PER_MINUTE", 300),
+		AdminRateLimitBurst:        getEnvAsInt("ADMIN_RATE_LIMIT_BURST", 60),
+		DeviceRateLimit:            getEnvAsInt("DEVICE_RATE_LIMIT_PER_MINUTE", 60),
+		DeviceRateLimitBurst:       getEnvAsInt("DEVICE_RATE_LIMIT_BURST", 10),
+		PricingCurrency:            getEnv("PRICING_CURRENCY", "EUR"),
+		PricingUnlockFee:           getEnvAsInt("PRICING_UNLOCK_FEE", 100),
+		PricingPerMinuteRate:       getEnvAsInt("PRICING_PER_MINUTE_RATE", 25),
+		PricingPausedRate:          getEnvAsInt("PRICING_PAUSED_PER_MINUTE_RATE", 10),
+		PricingPerKmRate:           getEnvAsInt("PRICING_PER_KM_RATE", 10),
+		PricingMinimumFare:         getEnvAsInt("PRICING_MINIMUM_FARE", 150),
+	}
+}
+
+// ValidateTokenSecrets refuses secrets that are missing, as well as the sample ones outside bootstrap mode.
+func (c Config) ValidateTokenSecrets() error {
+	secrets := map[string]string{"CLIENT_TOKEN_SECRET": c.ClientTokenSecret, "ADMIN_TOKEN_SECRET": c.AdminTokenSecret}
+	for name, secret := range secrets {
+		if secret == "" {
+			return fmt.Errorf("%s must be set", name)
+		}
+
+		if !c.AuthBootstrapMode && slices.Contains(sampleTokenSecrets, secret) {
+			return fmt.Errorf("%s must be changed from its sample value outside bootstrap mode", name)
+		}
 	}
+
+	return nil
 }
 
 func getEnv(key, fallback string) string {
@@ -48,3 +128,12 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvAsInt(key string, fallback int) int {
+	if value, ok := os.LookupEnv(key); ok {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return fallback
+}
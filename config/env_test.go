@@ -0,0 +1,37 @@
+package config
+
+import "testing"
+
+func TestConfig(t *testing.T) {
+	t.Run("When validating token secrets while they are set returns nil", func(t *testing.T) {
+		config := Config{ClientTokenSecret: "client-secret", AdminTokenSecret: "admin-secret"}
+
+		if err := config.ValidateTokenSecrets(); err != nil {
+			t.Errorf("expected no error, got %s", err.Error())
+		}
+	})
+
+	t.Run("When validating token secrets while one is missing returns error", func(t *testing.T) {
+		config := Config{ClientTokenSecret: "client-secret", AuthBootstrapMode: true}
+
+		if err := config.ValidateTokenSecrets(); err == nil {
+			t.Errorf("expected an error, got nil")
+		}
+	})
+
+	t.Run("When validating token secrets while sample values are used outside bootstrap mode returns error", func(t *testing.T) {
+		config := Config{ClientTokenSecret: "my_client_token_secret", AdminTokenSecret: "admin-secret"}
+
+		if err := config.ValidateTokenSecrets(); err == nil {
+			t.Errorf("expected an error, got nil")
+		}
+	})
+
+	t.Run("When validating token secrets while sample values are used in bootstrap mode returns nil", func(t *testing.T) {
+		config := Config{ClientTokenSecret: "my_client_token_secret", AdminTokenSecret: "my_admin_token_secret", AuthBootstrapMode: true}
+
+		if err := config.ValidateTokenSecrets(); err != nil {
+			t.Errorf("expected no error, got %s", err.Error())
+		}
+	})
+}
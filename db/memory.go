@@ -0,0 +1,53 @@
+package db
+
+import (
+	"sync"
+
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type VersionedRecord[T any] struct {
+	Value          T
+	OptLockVersion int
+}
+
+// InMemoryStorage mimics the MySql schema for local development and integration tests.
+// Repositories sharing the same storage must hold its lock for the whole "transaction".
+type InMemoryStorage struct {
+	sync.RWMutex
+	Scooters           map[string]*VersionedRecord[types.Scooter]
+	Users              map[string]*VersionedRecord[types.MobileClient]
+	Trips              map[string]*types.Trip
+	Events             []types.TripEvent
+	Operators          map[string]*types.Operator
+	ApiKeys            map[string]*types.ApiKey
+	IdempotencyRecords map[string]*types.IdempotencyRecord
+	Reservations       map[string]*types.Reservation
+	ReservationEvents  []types.ReservationEvent
+	Zones              map[string]*types.Zone
+	ZoneViolations     []types.ZoneViolation
+	ParkingStations    map[string]*types.ParkingStation
+	DeviceSecrets      map[string]string
+	Telemetry          []types.TelemetryPing
+	MaintenanceTickets map[string]*types.MaintenanceTicket
+}
+
+func NewInMemoryStorage() *InMemoryStorage {
+	return &InMemoryStorage{
+		Scooters:           make(map[string]*VersionedRecord[types.Scooter]),
+		Users:              make(map[string]*VersionedRecord[types.MobileClient]),
+		Trips:              make(map[string]*types.Trip),
+		Events:             make([]types.TripEvent, 0),
+		Operators:          make(map[string]*types.Operator),
+		ApiKeys:            make(map[string]*types.ApiKey),
+		IdempotencyRecords: make(map[string]*types.IdempotencyRecord),
+		Reservations:       make(map[string]*types.Reservation),
+		ReservationEvents:  make([]types.ReservationEvent, 0),
+		Zones:              make(map[string]*types.Zone),
+		ZoneViolations:     make([]types.ZoneViolation, 0),
+		ParkingStations:    make(map[string]*types.ParkingStation),
+		DeviceSecrets:      make(map[string]string),
+		Telemetry:          make([]types.TelemetryPing, 0),
+		MaintenanceTickets: make(map[string]*types.MaintenanceTicket),
+	}
+}
@@ -2,7 +2,11 @@ module github.com/nerijusro/scootinAboot
 
 go 1.22.2
 
-require github.com/go-sql-driver/mysql v1.8.1
+require (
+	github.com/go-playground/validator/v10 v10.14.0
+	github.com/go-sql-driver/mysql v1.8.1
+	golang.org/x/crypto v0.20.0
+)
 
 require (
 	github.com/bytedance/sonic v1.9.1 // indirect
@@ -11,7 +15,6 @@ require (
 	github.com/gin-contrib/sse v0.1.0 // indirect
 	github.com/go-playground/locales v0.14.1 // indirect
 	github.com/go-playground/universal-translator v0.18.1 // indirect
-	github.com/go-playground/validator/v10 v10.14.0 // indirect
 	github.com/goccy/go-json v0.10.2 // indirect
 	github.com/hashicorp/errwrap v1.1.0 // indirect
 	github.com/hashicorp/go-cleanhttp v0.5.2 // indirect
@@ -27,7 +30,6 @@ require (
 	github.com/ugorji/go/codec v1.2.11 // indirect
 	go.uber.org/atomic v1.7.0 // indirect
 	golang.org/x/arch v0.3.0 // indirect
-	golang.org/x/crypto v0.20.0 // indirect
 	golang.org/x/net v0.21.0 // indirect
 	golang.org/x/sys v0.17.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
@@ -0,0 +1,74 @@
+package apikey
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+)
+
+type cacheEntry struct {
+	apiKey   types.ApiKey
+	cachedAt time.Time
+}
+
+// CachingApiKeyRepository keeps keys looked up by hash in memory, so that authenticating a request
+// does not hit the database every time. Revocations made through it apply immediately,
+// while revocations made by other instances apply once the entry expires.
+type CachingApiKeyRepository struct {
+	interfaces.ApiKeyRepository
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	now     func() time.Time
+}
+
+func NewCachingRepository(repository interfaces.ApiKeyRepository, ttl time.Duration) *CachingApiKeyRepository {
+	return &CachingApiKeyRepository{
+		ApiKeyRepository: repository,
+		ttl:              ttl,
+		entries:          make(map[string]cacheEntry),
+		now:              time.Now,
+	}
+}
+
+func (r *CachingApiKeyRepository) GetApiKeyByHash(keyHash string) (*types.ApiKey, error) {
+	r.mu.Lock()
+	entry, ok := r.entries[keyHash]
+	r.mu.Unlock()
+
+	if ok && r.now().Sub(entry.cachedAt) < r.ttl {
+		apiKey := entry.apiKey
+		return &apiKey, nil
+	}
+
+	// Unknown keys are not cached, otherwise random keys could be used to fill up the memory
+	apiKey, err := r.ApiKeyRepository.GetApiKeyByHash(keyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.entries[keyHash] = cacheEntry{apiKey: *apiKey, cachedAt: r.now()}
+	r.mu.Unlock()
+
+	return apiKey, nil
+}
+
+func (r *CachingApiKeyRepository) RevokeApiKey(id string) error {
+	if err := r.ApiKeyRepository.RevokeApiKey(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for keyHash, entry := range r.entries {
+		if entry.apiKey.ID.String() == id {
+			delete(r.entries, keyHash)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestCachingApiKeyRepository(t *testing.T) {
+	t.Run("When getting api key by hash while it was revoked through the cache returns revoked key", func(t *testing.T) {
+		storage := db.NewInMemoryStorage()
+		repository := NewCachingRepository(NewInMemoryRepository(storage), time.Minute)
+
+		apiKey := types.ApiKey{ID: uuid.New(), Name: "partner app", Scope: enums.ClientScope, KeyHash: "hash"}
+		if err := repository.CreateApiKey(apiKey); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := repository.GetApiKeyByHash("hash"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := repository.RevokeApiKey(apiKey.ID.String()); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := repository.GetApiKeyByHash("hash")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !result.IsRevoked {
+			t.Errorf("expected api key to be revoked")
+		}
+	})
+
+	t.Run("When getting api key by hash while cached entry expired returns fresh key", func(t *testing.T) {
+		storage := db.NewInMemoryStorage()
+		repository := NewCachingRepository(NewInMemoryRepository(storage), time.Minute)
+		now := time.Now()
+		repository.now = func() time.Time { return now }
+
+		apiKey := types.ApiKey{ID: uuid.New(), Name: "partner app", Scope: enums.ClientScope, KeyHash: "hash"}
+		if err := repository.CreateApiKey(apiKey); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := repository.GetApiKeyByHash("hash"); err != nil {
+			t.Fatal(err)
+		}
+
+		// Revoked by another instance, bypassing this cache
+		storage.ApiKeys[apiKey.ID.String()].IsRevoked = true
+
+		result, _ := repository.GetApiKeyByHash("hash")
+		if result.IsRevoked {
+			t.Errorf("expected cached api key to be returned")
+		}
+
+		now = now.Add(2 * time.Minute)
+		result, _ = repository.GetApiKeyByHash("hash")
+		if !result.IsRevoked {
+			t.Errorf("expected api key to be reloaded after the cache entry expired")
+		}
+	})
+}
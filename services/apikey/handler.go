@@ -0,0 +1,102 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+type ApiKeyHandler struct {
+	repository interfaces.ApiKeyRepository
+	validator  interfaces.ApiKeyValidator
+}
+
+func NewApiKeyHandler(repository interfaces.ApiKeyRepository, validator interfaces.ApiKeyValidator) *ApiKeyHandler {
+	return &ApiKeyHandler{repository: repository, validator: validator}
+}
+
+func (h *ApiKeyHandler) RegisterEndpoints(routes interfaces.Routes) {
+	routes.Admin(enums.ManageApiKeys).POST("/api-keys", h.createApiKey)
+	routes.Admin(enums.ManageApiKeys).GET("/api-keys", h.getApiKeys)
+	routes.Admin(enums.ManageApiKeys).DELETE("/api-keys/:id", h.revokeApiKey)
+}
+
+func (h *ApiKeyHandler) createApiKey(c *gin.Context) {
+	var request types.CreateApiKeyRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateCreateApiKeyRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	key, err := utils.GenerateApiKey()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error generating api key"})
+		return
+	}
+
+	apiKey := types.ApiKey{
+		ID:        uuid.New(),
+		Name:      request.Name,
+		Scope:     request.Scope,
+		KeyHash:   utils.HashApiKey(key),
+		ExpiresAt: request.ExpiresAt,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if err := h.repository.CreateApiKey(apiKey); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	// Only the hash is stored, so this is the one and only time the key can be seen
+	c.JSON(http.StatusCreated, types.CreateApiKeyResponse{ApiKey: apiKey, Key: key})
+}
+
+func (h *ApiKeyHandler) getApiKeys(c *gin.Context) {
+	apiKeys, err := h.repository.GetApiKeys()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.GetApiKeysResponse{ApiKeys: apiKeys})
+}
+
+func (h *ApiKeyHandler) revokeApiKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	apiKey, err := h.repository.GetApiKeyById(id.String())
+	if errors.Is(err, ErrApiKeyNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	if err := h.repository.RevokeApiKey(id.String()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	apiKey.IsRevoked = true
+	c.JSON(http.StatusOK, apiKey)
+}
@@ -0,0 +1,157 @@
+package apikey
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+func TestApiKeyHandler(t *testing.T) {
+	repository := &mockApiKeyRepository{}
+	validator := &mockApiKeyValidator{}
+	handler := NewApiKeyHandler(repository, validator)
+
+	t.Run("When creating api key while given valid request returns key once and stores its hash", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CreateApiKeyRequest{Name: "partner app", Scope: enums.ClientScope})
+		request, err := http.NewRequest(http.MethodPost, "/admin/api-keys", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/api-keys", handler.createApiKey)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+
+		var response types.CreateApiKeyResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Key == "" {
+			t.Errorf("expected plaintext key to be returned")
+		}
+
+		if repository.created.KeyHash != utils.HashApiKey(response.Key) {
+			t.Errorf("expected stored hash to match the returned key")
+		}
+	})
+
+	t.Run("When creating api key while request is invalid returns bad request", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CreateApiKeyRequest{Name: "partner app", Scope: "superuser"})
+		request, err := http.NewRequest(http.MethodPost, "/admin/api-keys", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/api-keys", handler.createApiKey)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When revoking api key while key exists returns revoked key", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodDelete, "/admin/api-keys/"+apiKeyId.String(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.DELETE("/admin/api-keys/:id", handler.revokeApiKey)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.ApiKey
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if !response.IsRevoked {
+			t.Errorf("expected api key to be revoked")
+		}
+	})
+
+	t.Run("When revoking api key while key does not exist returns not found", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodDelete, "/admin/api-keys/"+uuid.New().String(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.DELETE("/admin/api-keys/:id", handler.revokeApiKey)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+}
+
+var apiKeyId = uuid.MustParse("5d0c2f4a-7e1b-4c39-9a52-3f6e8b1d2222")
+
+type mockApiKeyRepository struct {
+	created types.ApiKey
+}
+
+func (m *mockApiKeyRepository) CreateApiKey(apiKey types.ApiKey) error {
+	m.created = apiKey
+	return nil
+}
+
+// GetApiKeys implements interfaces.ApiKeyRepository.
+func (m *mockApiKeyRepository) GetApiKeys() ([]*types.ApiKey, error) {
+	panic("unimplemented")
+}
+
+func (m *mockApiKeyRepository) GetApiKeyById(id string) (*types.ApiKey, error) {
+	if id != apiKeyId.String() {
+		return nil, ErrApiKeyNotFound
+	}
+
+	return &types.ApiKey{ID: apiKeyId, Name: "partner app", Scope: enums.ClientScope}, nil
+}
+
+// GetApiKeyByHash implements interfaces.ApiKeyRepository.
+func (m *mockApiKeyRepository) GetApiKeyByHash(keyHash string) (*types.ApiKey, error) {
+	panic("unimplemented")
+}
+
+func (m *mockApiKeyRepository) RevokeApiKey(id string) error {
+	return nil
+}
+
+type mockApiKeyValidator struct{}
+
+func (m *mockApiKeyValidator) ValidateCreateApiKeyRequest(request *types.CreateApiKeyRequest) error {
+	if request.Scope != enums.AdminScope && request.Scope != enums.ClientScope {
+		return errors.New("invalid scope")
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package apikey
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type InMemoryApiKeyRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryApiKeyRepository {
+	return &InMemoryApiKeyRepository{storage: storage}
+}
+
+func (r *InMemoryApiKeyRepository) CreateApiKey(apiKey types.ApiKey) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	for _, existing := range r.storage.ApiKeys {
+		if existing.ID == apiKey.ID || existing.KeyHash == apiKey.KeyHash {
+			return fmt.Errorf("api key already exists")
+		}
+	}
+
+	r.storage.ApiKeys[apiKey.ID.String()] = &apiKey
+	return nil
+}
+
+func (r *InMemoryApiKeyRepository) GetApiKeys() ([]*types.ApiKey, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	apiKeys := make([]*types.ApiKey, 0, len(r.storage.ApiKeys))
+	for _, apiKey := range r.storage.ApiKeys {
+		result := *apiKey
+		apiKeys = append(apiKeys, &result)
+	}
+
+	sort.Slice(apiKeys, func(i, j int) bool {
+		if !apiKeys[i].CreatedAt.Equal(apiKeys[j].CreatedAt) {
+			return apiKeys[i].CreatedAt.Before(apiKeys[j].CreatedAt)
+		}
+		return apiKeys[i].ID.String() < apiKeys[j].ID.String()
+	})
+
+	return apiKeys, nil
+}
+
+func (r *InMemoryApiKeyRepository) GetApiKeyById(id string) (*types.ApiKey, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	apiKey, ok := r.storage.ApiKeys[id]
+	if !ok {
+		return nil, ErrApiKeyNotFound
+	}
+
+	result := *apiKey
+	return &result, nil
+}
+
+func (r *InMemoryApiKeyRepository) GetApiKeyByHash(keyHash string) (*types.ApiKey, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	for _, apiKey := range r.storage.ApiKeys {
+		if apiKey.KeyHash == keyHash {
+			result := *apiKey
+			return &result, nil
+		}
+	}
+
+	return nil, ErrApiKeyNotFound
+}
+
+func (r *InMemoryApiKeyRepository) RevokeApiKey(id string) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if apiKey, ok := r.storage.ApiKeys[id]; ok {
+		apiKey.IsRevoked = true
+	}
+
+	return nil
+}
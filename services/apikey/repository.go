@@ -0,0 +1,104 @@
+package apikey
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type ApiKeyRepository struct {
+	db *sql.DB
+}
+
+var ErrApiKeyNotFound = errors.New("api key not found")
+
+const apiKeyColumns = "id, name, scope, key_hash, expires_at, is_revoked, created_at"
+
+func NewRepository(db *sql.DB) *ApiKeyRepository {
+	return &ApiKeyRepository{db: db}
+}
+
+func (r *ApiKeyRepository) CreateApiKey(apiKey types.ApiKey) error {
+	_, err := r.db.Exec("INSERT INTO api_keys (id, name, scope, key_hash, expires_at, created_at) VALUES (UUID_TO_BIN(?, false), ?, ?, ?, ?, ?)",
+		apiKey.ID.String(), apiKey.Name, apiKey.Scope, apiKey.KeyHash, apiKey.ExpiresAt, apiKey.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ApiKeyRepository) GetApiKeys() ([]*types.ApiKey, error) {
+	rows, err := r.db.Query("SELECT " + apiKeyColumns + " FROM api_keys ORDER BY created_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiKeys := make([]*types.ApiKey, 0)
+	for rows.Next() {
+		apiKey, err := scanRowIntoApiKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	return apiKeys, rows.Err()
+}
+
+func (r *ApiKeyRepository) GetApiKeyById(id string) (*types.ApiKey, error) {
+	return r.getApiKey("id = UUID_TO_BIN(?, false)", id)
+}
+
+func (r *ApiKeyRepository) GetApiKeyByHash(keyHash string) (*types.ApiKey, error) {
+	return r.getApiKey("key_hash = ?", keyHash)
+}
+
+func (r *ApiKeyRepository) RevokeApiKey(id string) error {
+	_, err := r.db.Exec("UPDATE api_keys SET is_revoked = true WHERE id = UUID_TO_BIN(?, false)", id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ApiKeyRepository) getApiKey(condition string, value string) (*types.ApiKey, error) {
+	rows, err := r.db.Query("SELECT "+apiKeyColumns+" FROM api_keys WHERE "+condition, value)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apiKey *types.ApiKey
+	for rows.Next() {
+		apiKey, err = scanRowIntoApiKey(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if apiKey == nil {
+		return nil, ErrApiKeyNotFound
+	}
+
+	return apiKey, nil
+}
+
+func scanRowIntoApiKey(row *sql.Rows) (*types.ApiKey, error) {
+	var apiKey types.ApiKey
+	var expiresAt sql.NullTime
+
+	if err := row.Scan(&apiKey.ID, &apiKey.Name, &apiKey.Scope, &apiKey.KeyHash, &expiresAt, &apiKey.IsRevoked, &apiKey.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		apiKey.ExpiresAt = &expiresAt.Time
+	}
+
+	return &apiKey, nil
+}
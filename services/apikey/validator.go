@@ -0,0 +1,38 @@
+package apikey
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+type ApiKeyValidator struct{}
+
+var Validator = validator.New()
+
+func NewApiKeyValidator() *ApiKeyValidator {
+	return &ApiKeyValidator{}
+}
+
+func (v *ApiKeyValidator) ValidateCreateApiKeyRequest(request *types.CreateApiKeyRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	if len(request.Name) > 255 {
+		return errors.New("name must not be longer than 255 characters")
+	}
+
+	if request.Scope != enums.AdminScope && request.Scope != enums.ClientScope {
+		return errors.New("invalid scope")
+	}
+
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		return errors.New("expires_at must be in the future")
+	}
+
+	return nil
+}
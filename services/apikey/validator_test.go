@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestApiKeyValidator(t *testing.T) {
+	validator := NewApiKeyValidator()
+
+	t.Run("When validating create api key request while given valid request returns nil", func(t *testing.T) {
+		expiresAt := time.Now().Add(24 * time.Hour)
+		request := types.CreateApiKeyRequest{Name: "partner app", Scope: enums.AdminScope, ExpiresAt: &expiresAt}
+
+		result := validator.ValidateCreateApiKeyRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create api key request while scope is unknown returns error", func(t *testing.T) {
+		request := types.CreateApiKeyRequest{Name: "partner app", Scope: "superuser"}
+
+		result := validator.ValidateCreateApiKeyRequest(&request)
+		if result == nil {
+			t.Errorf("expected result to be an error, got nil")
+		}
+	})
+
+	t.Run("When validating create api key request while expiry is in the past returns error", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+		request := types.CreateApiKeyRequest{Name: "partner app", Scope: enums.ClientScope, ExpiresAt: &expiresAt}
+
+		result := validator.ValidateCreateApiKeyRequest(&request)
+		if result == nil {
+			t.Errorf("expected result to be an error, got nil")
+		}
+	})
+}
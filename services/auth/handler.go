@@ -16,8 +16,8 @@ func NewAuthorizationHandler(authProvider interfaces.AuthProvider) *Authorizatio
 	return &AuthorizationHandler{authProvider: authProvider}
 }
 
-func (h *AuthorizationHandler) RegisterEndpoints(routerGroups map[string]*gin.RouterGroup) {
-	rootGroup := routerGroups["root"]
+func (h *AuthorizationHandler) RegisterEndpoints(routes interfaces.Routes) {
+	rootGroup := routes.Public()
 	rootGroup.GET("/client/auth", h.authorizeUser)
 	rootGroup.GET("/admin/auth", h.authorizeAdmin)
 }
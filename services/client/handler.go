@@ -1,25 +1,49 @@
 package client
 
 import (
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
 	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"github.com/nerijusro/scootinAboot/utils"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type ClientHandler struct {
-	repository interfaces.ClientRepository
+	repository    interfaces.ClientRepository
+	validator     interfaces.ClientValidator
+	tokens        interfaces.TokenService
+	maxSessionAge time.Duration
 }
 
-func NewClientsHandler(repository interfaces.ClientRepository) *ClientHandler {
-	return &ClientHandler{repository: repository}
+const defaultUsersPageSize = 20
+
+// dummyPasswordHash is compared against when the email is unknown,
+// so that response times do not reveal which emails are registered.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+func NewClientsHandler(repository interfaces.ClientRepository, validator interfaces.ClientValidator, tokens interfaces.TokenService, maxSessionAge time.Duration) *ClientHandler {
+	return &ClientHandler{repository: repository, validator: validator, tokens: tokens, maxSessionAge: maxSessionAge}
 }
 
-func (h *ClientHandler) RegisterEndpoints(routerGroups map[string]*gin.RouterGroup) {
-	userAuthorized := routerGroups["client"]
-	userAuthorized.POST("/users", h.createUser)
+func (h *ClientHandler) RegisterEndpoints(routes interfaces.Routes) {
+	routes.Admin(enums.ReadUsers).GET("/users", h.getUsers)
+	routes.Admin(enums.ReadUsers).GET("/users/:id", h.getUser)
+	routes.Admin(enums.WriteUsers).PATCH("/users/:id", h.updateUser)
+	routes.Admin(enums.WriteUsers).PUT("/users/:id/credentials", h.setUserCredentials)
+
+	routes.IdempotentClient().POST("/users", h.createUser)
+
+	userAuthorized := routes.Client()
+	userAuthorized.POST("/users/login", h.login)
+	userAuthorized.POST("/users/token", h.refreshToken)
+	userAuthorized.PUT("/users/credentials", h.setCredentials)
 }
 
 func (h *ClientHandler) createUser(c *gin.Context) {
@@ -29,17 +53,285 @@ func (h *ClientHandler) createUser(c *gin.Context) {
 		return
 	}
 
+	if err := h.validator.ValidateCreateUserRequest(&userRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(userRequest.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error hashing password"})
+		return
+	}
+
+	email := normalizeEmail(userRequest.Email)
 	user := types.MobileClient{
 		ID:                 uuid.New(),
 		FullName:           userRequest.FullName,
+		Email:              &email,
+		PasswordHash:       string(passwordHash),
 		IsEligibleToTravel: true,
 	}
 
-	err := h.repository.CreateUser(user)
+	err = h.repository.CreateUser(user)
+	if errors.Is(err, ErrEmailTaken) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	token, err := h.tokens.IssueToken(types.TokenClaims{Subject: user.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error issuing token"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.CreateUserResponse{MobileClient: user, AuthToken: *token})
+}
+
+// login starts a new session for the rider, riders whose token expired or who lost it log in again.
+func (h *ClientHandler) login(c *gin.Context) {
+	var request types.UserCredentialsRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateLoginRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	user, err := h.repository.GetUserByEmail(normalizeEmail(request.Email))
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting user"})
+		return
+	}
+
+	passwordHash := dummyPasswordHash
+	if user != nil {
+		passwordHash = []byte(user.PasswordHash)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(passwordHash, []byte(request.Password)); err != nil || user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "invalid email or password"})
+		return
+	}
+
+	token, err := h.tokens.IssueToken(types.TokenClaims{Subject: user.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error issuing token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// refreshToken exchanges a valid rider token for a new one within the same session.
+// Sessions are bounded by maxSessionAge, so that a leaked token can not be renewed forever.
+func (h *ClientHandler) refreshToken(c *gin.Context) {
+	clientId := c.GetString(utils.ClientIdKey)
+	if clientId == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "missing client token"})
+		return
+	}
+
+	authenticatedAt := c.GetTime(utils.AuthenticatedAtKey)
+	if time.Since(authenticatedAt) > h.maxSessionAge {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "session has expired, log in again"})
+		return
+	}
+
+	user, _, err := h.repository.GetUserById(clientId)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	token, err := h.tokens.IssueToken(types.TokenClaims{Subject: user.ID, AuthenticatedAt: authenticatedAt})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error issuing token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// setCredentials lets riders created before they could log in, who still hold a valid token, choose their email and password once.
+func (h *ClientHandler) setCredentials(c *gin.Context) {
+	clientId := c.GetString(utils.ClientIdKey)
+	if clientId == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "missing client token"})
+		return
+	}
+
+	h.saveCredentials(c, clientId)
+}
+
+// setUserCredentials lets support set up the email and password of a rider created before they could log in,
+// whose token has already expired, so that the rider can log in again.
+func (h *ClientHandler) setUserCredentials(c *gin.Context) {
+	id := c.Param("id")
+	idInUUID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	h.saveCredentials(c, idInUUID.String())
+}
+
+// saveCredentials sets the email and password of a rider who has none yet, credentials are never overwritten.
+func (h *ClientHandler) saveCredentials(c *gin.Context, clientId string) {
+	var request types.UserCredentialsRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateUserCredentialsRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	user, _, err := h.repository.GetUserById(clientId)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error hashing password"})
+		return
+	}
+
+	email := normalizeEmail(request.Email)
+	user.Email = &email
+	user.PasswordHash = string(passwordHash)
+
+	err = h.repository.SetUserCredentials(*user)
+	if errors.Is(err, ErrEmailTaken) || errors.Is(err, ErrCredentialsAlreadySet) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, user)
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *ClientHandler) getUsers(c *gin.Context) {
+	var queryParameters types.GetUsersQueryParameters
+	if err := c.BindQuery(&queryParameters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateGetUsersQueryParameters(&queryParameters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	if queryParameters.Limit == 0 {
+		queryParameters.Limit = defaultUsersPageSize
+	}
+
+	users, err := h.repository.GetUsers(queryParameters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	response := types.GetUsersResponse{
+		Users:  users,
+		Limit:  queryParameters.Limit,
+		Offset: queryParameters.Offset,
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ClientHandler) getUser(c *gin.Context) {
+	id := c.Param("id")
+	idInUUID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	user, _, err := h.repository.GetUserById(idInUUID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *ClientHandler) updateUser(c *gin.Context) {
+	id := c.Param("id")
+	idInUUID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	var request types.UpdateUserRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateUpdateUserRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	user, optLockVersion, err := h.repository.GetUserById(idInUUID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	// Eligibility of a rider in a trip is owned by the trip, it is released once the trip ends
+	isInActiveTrip, err := h.repository.IsInActiveTrip(user.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error checking active trips"})
+		return
+	}
+
+	if isInActiveTrip {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": "user is in an active trip"})
+		return
+	}
+
+	user.IsEligibleToTravel = *request.IsEligibleToTravel
+	user.SuspensionReason = nil
+	if !user.IsEligibleToTravel {
+		user.SuspensionReason = &request.Reason
+	}
+
+	err = h.repository.UpdateUserEligibility(*user, optLockVersion)
+	if errors.Is(err, ErrConcurrentUpdate) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
 }
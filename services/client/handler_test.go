@@ -7,18 +7,27 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/db"
 	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/utils"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestClientHandler(t *testing.T) {
 	repository := &mockClientRepository{}
-	handler := NewClientsHandler(repository)
+	validator := &mockClientValidator{}
+	tokens := &mockTokenService{}
+	handler := NewClientsHandler(repository, validator, tokens, 30*24*time.Hour)
 
 	t.Run("When creating user while everything is valid returns ok", func(t *testing.T) {
 		requestBody := types.CreateUserRequest{
 			FullName: "John Doe",
+			Email:    "John.Doe@example.com",
+			Password: "correct-password",
 		}
 
 		marshalledRequestBody, _ := json.Marshal(requestBody)
@@ -37,7 +46,7 @@ func TestClientHandler(t *testing.T) {
 			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
 		}
 
-		var response types.MobileClient
+		var response types.CreateUserResponse
 		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
 			t.Fatal(err)
 		}
@@ -45,6 +54,61 @@ func TestClientHandler(t *testing.T) {
 		if response.FullName != requestBody.FullName {
 			t.Errorf("expected latitude to be %s, got %s", requestBody.FullName, response.FullName)
 		}
+
+		if response.Token != "token-"+response.ID.String() {
+			t.Errorf("expected token to be issued for %s, got %s", response.ID.String(), response.Token)
+		}
+
+		if response.Email == nil || *response.Email != "john.doe@example.com" {
+			t.Errorf("expected email to be john.doe@example.com, got %v", response.Email)
+		}
+	})
+
+	t.Run("When creating user while email is missing returns bad request", func(t *testing.T) {
+		requestBody := types.CreateUserRequest{
+			FullName: "John Doe",
+			Password: "correct-password",
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/client/users", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/client/users", handler.createUser)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When creating user while email is taken returns conflict", func(t *testing.T) {
+		requestBody := types.CreateUserRequest{
+			FullName: "John Doe",
+			Email:    "taken@example.com",
+			Password: "correct-password",
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/client/users", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/client/users", handler.createUser)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
 	})
 
 	t.Run("When creating user while invalid request body returns bad request", func(t *testing.T) {
@@ -69,6 +133,8 @@ func TestClientHandler(t *testing.T) {
 	t.Run("When creating user while repository returns error returns internal server error", func(t *testing.T) {
 		requestBody := types.CreateUserRequest{
 			FullName: "Not John Doe",
+			Email:    "not.john.doe@example.com",
+			Password: "correct-password",
 		}
 
 		marshalledRequestBody, _ := json.Marshal(requestBody)
@@ -87,18 +153,514 @@ func TestClientHandler(t *testing.T) {
 			t.Errorf("expected status code %d but got %d", http.StatusInternalServerError, responseRecoreder.Code)
 		}
 	})
+
+	t.Run("When refreshing token while client is authenticated returns new token", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/client/users/token", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/client/users/token", func(c *gin.Context) {
+			c.Set(utils.ClientIdKey, "bec6a2fb-896f-473e-1111-a4208d033498")
+			c.Set(utils.AuthenticatedAtKey, time.Now().Add(-time.Hour))
+		}, handler.refreshToken)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.AuthToken
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Token != "token-bec6a2fb-896f-473e-1111-a4208d033498" {
+			t.Errorf("expected token to be token-bec6a2fb-896f-473e-1111-a4208d033498, got %s", response.Token)
+		}
+	})
+
+	t.Run("When refreshing token while client token is missing returns unauthorized", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/client/users/token", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/client/users/token", handler.refreshToken)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When refreshing token while user no longer exists returns not found", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/client/users/token", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/client/users/token", func(c *gin.Context) {
+			c.Set(utils.ClientIdKey, "bec6a2fb-896f-473e-2222-a4208d033498")
+			c.Set(utils.AuthenticatedAtKey, time.Now().Add(-time.Hour))
+		}, handler.refreshToken)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When logging in while credentials are valid returns ok", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.UserCredentialsRequest{Email: "John.Doe@example.com", Password: "correct-password"})
+		request, err := http.NewRequest(http.MethodPost, "/client/users/login", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/client/users/login", handler.login)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When logging in while password is wrong returns unauthorized", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.UserCredentialsRequest{Email: "john.doe@example.com", Password: "wrong-password"})
+		request, err := http.NewRequest(http.MethodPost, "/client/users/login", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/client/users/login", handler.login)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When logging in while email is not registered returns unauthorized", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.UserCredentialsRequest{Email: "nobody@example.com", Password: "correct-password"})
+		request, err := http.NewRequest(http.MethodPost, "/client/users/login", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/client/users/login", handler.login)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When refreshing token while session is older than its max age returns unauthorized", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/client/users/token", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/client/users/token", func(c *gin.Context) {
+			c.Set(utils.ClientIdKey, "bec6a2fb-896f-473e-1111-a4208d033498")
+			c.Set(utils.AuthenticatedAtKey, time.Now().Add(-31*24*time.Hour))
+		}, handler.refreshToken)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When setting credentials while user has none returns ok", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.UserCredentialsRequest{Email: "jane.doe@example.com", Password: "correct-password"})
+		request, err := http.NewRequest(http.MethodPut, "/client/users/credentials", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PUT("/client/users/credentials", func(c *gin.Context) {
+			c.Set(utils.ClientIdKey, "bec6a2fb-896f-473e-1111-a4208d033498")
+		}, handler.setCredentials)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When setting credentials while user already has them returns conflict", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.UserCredentialsRequest{Email: "jane.doe@example.com", Password: "correct-password"})
+		request, err := http.NewRequest(http.MethodPut, "/client/users/credentials", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PUT("/client/users/credentials", func(c *gin.Context) {
+			c.Set(utils.ClientIdKey, "bec6a2fb-896f-473e-5555-a4208d033498")
+		}, handler.setCredentials)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When setting credentials as admin while user has none returns ok", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.UserCredentialsRequest{Email: "jane.doe@example.com", Password: "correct-password"})
+		request, err := http.NewRequest(http.MethodPut, "/admin/users/bec6a2fb-896f-473e-1111-a4208d033498/credentials", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PUT("/admin/users/:id/credentials", handler.setUserCredentials)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.MobileClient
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Email == nil || *response.Email != "jane.doe@example.com" {
+			t.Errorf("expected email to be jane.doe@example.com, got %v", response.Email)
+		}
+	})
+
+	t.Run("When setting credentials as admin while user already has them returns conflict", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.UserCredentialsRequest{Email: "jane.doe@example.com", Password: "correct-password"})
+		request, err := http.NewRequest(http.MethodPut, "/admin/users/bec6a2fb-896f-473e-5555-a4208d033498/credentials", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PUT("/admin/users/:id/credentials", handler.setUserCredentials)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When setting credentials as admin while rider has no password lets the rider log in", func(t *testing.T) {
+		storage := db.NewInMemoryStorage()
+		rider := types.MobileClient{ID: uuid.New(), FullName: "Jane Doe", IsEligibleToTravel: true}
+		storage.Users[rider.ID.String()] = &db.VersionedRecord[types.MobileClient]{Value: rider}
+		handler := NewClientsHandler(NewInMemoryRepository(storage), validator, tokens, 30*24*time.Hour)
+
+		router := gin.Default()
+		router.PUT("/admin/users/:id/credentials", handler.setUserCredentials)
+		router.POST("/client/users/login", handler.login)
+
+		marshalledRequestBody, _ := json.Marshal(types.UserCredentialsRequest{Email: "jane.doe@example.com", Password: "correct-password"})
+		request, err := http.NewRequest(http.MethodPut, "/admin/users/"+rider.ID.String()+"/credentials", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Fatalf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		request, err = http.NewRequest(http.MethodPost, "/client/users/login", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		responseRecoreder = httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting users while everything is valid returns ok", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/admin/users?full_name=john&limit=10", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/admin/users", handler.getUsers)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.GetUsersResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(response.Users) != 1 || response.Users[0].FullName != "John Doe" {
+			t.Errorf("expected a single user named John Doe, got %v", response.Users)
+		}
+
+		if response.Limit != 10 {
+			t.Errorf("expected limit to be 10, got %d", response.Limit)
+		}
+	})
+
+	t.Run("When getting users while limit is not valid returns bad request", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/admin/users?limit=1000", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/admin/users", handler.getUsers)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting user by id while user is not existant returns not found", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/admin/users/bec6a2fb-896f-473e-2222-a4208d033498", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/admin/users/:id", handler.getUser)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When suspending user while everything is valid returns suspended user", func(t *testing.T) {
+		isEligibleToTravel := false
+		requestBody := types.UpdateUserRequest{IsEligibleToTravel: &isEligibleToTravel, Reason: "unpaid fares"}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPatch, "/admin/users/bec6a2fb-896f-473e-1111-a4208d033498", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/users/:id", handler.updateUser)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.MobileClient
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.IsEligibleToTravel {
+			t.Errorf("expected user not to be eligible to travel")
+		}
+
+		if response.SuspensionReason == nil || *response.SuspensionReason != "unpaid fares" {
+			t.Errorf("expected suspension reason to be: unpaid fares, got %v", response.SuspensionReason)
+		}
+	})
+
+	t.Run("When suspending user while user is in an active trip returns conflict", func(t *testing.T) {
+		isEligibleToTravel := false
+		requestBody := types.UpdateUserRequest{IsEligibleToTravel: &isEligibleToTravel, Reason: "unpaid fares"}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPatch, "/admin/users/bec6a2fb-896f-473e-3333-a4208d033498", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/users/:id", handler.updateUser)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When reinstating user while user was updated concurrently returns conflict", func(t *testing.T) {
+		isEligibleToTravel := true
+		requestBody := types.UpdateUserRequest{IsEligibleToTravel: &isEligibleToTravel}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPatch, "/admin/users/bec6a2fb-896f-473e-4444-a4208d033498", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/users/:id", handler.updateUser)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
 }
 
 type mockClientRepository struct{}
 
+var mockPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+
 func (m *mockClientRepository) CreateUser(client types.MobileClient) error {
 	if client.FullName != "John Doe" {
 		return errors.New("internal server error")
 	}
 
+	if *client.Email == "taken@example.com" {
+		return ErrEmailTaken
+	}
+
+	return nil
+}
+
+func (m *mockClientRepository) GetUserByEmail(email string) (*types.MobileClient, error) {
+	if email != "john.doe@example.com" {
+		return nil, ErrUserNotFound
+	}
+
+	return &types.MobileClient{ID: uuid.New(), FullName: "John Doe", Email: &email, PasswordHash: string(mockPasswordHash), IsEligibleToTravel: true}, nil
+}
+
+func (m *mockClientRepository) SetUserCredentials(client types.MobileClient) error {
+	if client.ID.String() == "bec6a2fb-896f-473e-5555-a4208d033498" {
+		return ErrCredentialsAlreadySet
+	}
+
 	return nil
 }
 
 func (m *mockClientRepository) GetUserById(id string) (*types.MobileClient, *int, error) {
-	return &types.MobileClient{}, nil, nil
+	if id == "bec6a2fb-896f-473e-2222-a4208d033498" {
+		return nil, nil, errors.New("user not found")
+	}
+
+	return &types.MobileClient{ID: uuid.MustParse(id), FullName: "John Doe", IsEligibleToTravel: true}, new(int), nil
+}
+
+func (m *mockClientRepository) GetUsers(queryParams types.GetUsersQueryParameters) ([]*types.MobileClient, error) {
+	return []*types.MobileClient{{ID: uuid.New(), FullName: "John Doe", IsEligibleToTravel: true}}, nil
+}
+
+func (m *mockClientRepository) IsInActiveTrip(id string) (bool, error) {
+	return id == "bec6a2fb-896f-473e-3333-a4208d033498", nil
+}
+
+func (m *mockClientRepository) UpdateUserEligibility(client types.MobileClient, optLockVersion *int) error {
+	if client.ID.String() == "bec6a2fb-896f-473e-4444-a4208d033498" {
+		return ErrConcurrentUpdate
+	}
+
+	return nil
+}
+
+type mockClientValidator struct{}
+
+func (m *mockClientValidator) ValidateCreateUserRequest(request *types.CreateUserRequest) error {
+	if request.Email == "" || request.Password == "" {
+		return errors.New("email and password are required")
+	}
+
+	return nil
+}
+
+func (m *mockClientValidator) ValidateLoginRequest(request *types.UserCredentialsRequest) error {
+	if request.Email == "" || request.Password == "" {
+		return errors.New("email and password are required")
+	}
+
+	return nil
+}
+
+func (m *mockClientValidator) ValidateUserCredentialsRequest(request *types.UserCredentialsRequest) error {
+	if request.Email == "" || request.Password == "" {
+		return errors.New("email and password are required")
+	}
+
+	return nil
+}
+
+func (m *mockClientValidator) ValidateGetUsersQueryParameters(queryParams *types.GetUsersQueryParameters) error {
+	if queryParams.Limit > 100 {
+		return errors.New("invalid limit")
+	}
+
+	return nil
+}
+
+func (m *mockClientValidator) ValidateUpdateUserRequest(request *types.UpdateUserRequest) error {
+	if request.IsEligibleToTravel == nil {
+		return errors.New("is_eligible_to_travel is required")
+	}
+
+	return nil
+}
+
+type mockTokenService struct{}
+
+func (m *mockTokenService) IssueToken(claims types.TokenClaims) (*types.AuthToken, error) {
+	return &types.AuthToken{Token: "token-" + claims.Subject.String(), ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+// ParseToken implements interfaces.TokenService.
+func (m *mockTokenService) ParseToken(token string) (*types.TokenClaims, error) {
+	panic("unimplemented")
 }
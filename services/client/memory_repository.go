@@ -0,0 +1,151 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type InMemoryClientRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryClientRepository {
+	return &InMemoryClientRepository{storage: storage}
+}
+
+func (r *InMemoryClientRepository) CreateUser(client types.MobileClient) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if _, ok := r.storage.Users[client.ID.String()]; ok {
+		return fmt.Errorf("user with id %s already exists", client.ID.String())
+	}
+
+	if client.Email != nil && r.findUserByEmail(*client.Email) != nil {
+		return ErrEmailTaken
+	}
+
+	// Mirrors the column default of the users table
+	client.IsEligibleToTravel = true
+	r.storage.Users[client.ID.String()] = &db.VersionedRecord[types.MobileClient]{Value: client}
+	return nil
+}
+
+func (r *InMemoryClientRepository) GetUserById(id string) (*types.MobileClient, *int, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	record, ok := r.storage.Users[id]
+	if !ok {
+		return nil, nil, fmt.Errorf("user with id %s not found", id)
+	}
+
+	client := record.Value
+	optLockVersion := record.OptLockVersion
+	return &client, &optLockVersion, nil
+}
+
+func (r *InMemoryClientRepository) GetUserByEmail(email string) (*types.MobileClient, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	record := r.findUserByEmail(email)
+	if record == nil {
+		return nil, ErrUserNotFound
+	}
+
+	client := record.Value
+	return &client, nil
+}
+
+func (r *InMemoryClientRepository) SetUserCredentials(client types.MobileClient) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	record, ok := r.storage.Users[client.ID.String()]
+	if !ok || record.Value.Email != nil {
+		return ErrCredentialsAlreadySet
+	}
+
+	if r.findUserByEmail(*client.Email) != nil {
+		return ErrEmailTaken
+	}
+
+	record.Value.Email = client.Email
+	record.Value.PasswordHash = client.PasswordHash
+	return nil
+}
+
+func (r *InMemoryClientRepository) GetUsers(queryParams types.GetUsersQueryParameters) ([]*types.MobileClient, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	// MySql compares names using a case insensitive collation
+	fullName := strings.ToLower(queryParams.FullName)
+	clients := make([]*types.MobileClient, 0)
+	for _, record := range r.storage.Users {
+		if !strings.Contains(strings.ToLower(record.Value.FullName), fullName) {
+			continue
+		}
+
+		client := record.Value
+		clients = append(clients, &client)
+	}
+
+	sort.Slice(clients, func(i, j int) bool {
+		if clients[i].FullName != clients[j].FullName {
+			return clients[i].FullName < clients[j].FullName
+		}
+		return clients[i].ID.String() < clients[j].ID.String()
+	})
+
+	if queryParams.Offset >= len(clients) {
+		return make([]*types.MobileClient, 0), nil
+	}
+
+	end := min(queryParams.Offset+queryParams.Limit, len(clients))
+	return clients[queryParams.Offset:end], nil
+}
+
+func (r *InMemoryClientRepository) IsInActiveTrip(id string) (bool, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	for _, trip := range r.storage.Trips {
+		if trip.ClientId.String() == id && !trip.IsFinished {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *InMemoryClientRepository) UpdateUserEligibility(client types.MobileClient, optLockVersion *int) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	record, ok := r.storage.Users[client.ID.String()]
+	if !ok || record.OptLockVersion != *optLockVersion {
+		return ErrConcurrentUpdate
+	}
+
+	record.Value.IsEligibleToTravel = client.IsEligibleToTravel
+	record.Value.SuspensionReason = client.SuspensionReason
+	record.OptLockVersion++
+	return nil
+}
+
+// findUserByEmail must be called while holding the storage lock. MySql compares emails using a case insensitive collation.
+func (r *InMemoryClientRepository) findUserByEmail(email string) *db.VersionedRecord[types.MobileClient] {
+	for _, record := range r.storage.Users {
+		if record.Value.Email != nil && strings.EqualFold(*record.Value.Email, email) {
+			return record
+		}
+	}
+
+	return nil
+}
@@ -2,7 +2,10 @@ package client
 
 import (
 	"database/sql"
+	"errors"
+	"strings"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/nerijusro/scootinAboot/types"
 )
 
@@ -10,29 +13,139 @@ type ClientRepository struct {
 	db *sql.DB
 }
 
+var (
+	ErrConcurrentUpdate      = errors.New("user was updated by another transaction")
+	ErrEmailTaken            = errors.New("user with the same email already exists")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrCredentialsAlreadySet = errors.New("user already has credentials")
+)
+
+const mySqlDuplicateEntryErrorNumber = 1062
+
 func NewRepository(db *sql.DB) *ClientRepository {
 	return &ClientRepository{db: db}
 }
 
 func (r *ClientRepository) CreateUser(client types.MobileClient) error {
-	_, err := r.db.Exec("INSERT INTO users (id, full_name) VALUES (UUID_TO_BIN(?, false), ?)",
-		client.ID.String(), client.FullName)
-	if err != nil {
-		return err
+	_, err := r.db.Exec("INSERT INTO users (id, full_name, email, password_hash) VALUES (UUID_TO_BIN(?, false), ?, ?, ?)",
+		client.ID.String(), client.FullName, client.Email, client.PasswordHash)
+
+	var mySqlErr *mysql.MySQLError
+	if errors.As(err, &mySqlErr) && mySqlErr.Number == mySqlDuplicateEntryErrorNumber {
+		return ErrEmailTaken
 	}
 
-	return nil
+	return err
 }
 
 func (r *ClientRepository) GetUserById(id string) (*types.MobileClient, *int, error) {
-	row := r.db.QueryRow("SELECT * FROM users WHERE id = UUID_TO_BIN(?, false)", id)
+	row := r.db.QueryRow("SELECT id, full_name, email, is_eligible_to_travel, suspension_reason, opt_lock_version FROM users WHERE id = UUID_TO_BIN(?, false)", id)
 
 	var client types.MobileClient
 	var optLockVersion int
-	err := row.Scan(&client.ID, &client.FullName, &client.IsEligibleToTravel, &optLockVersion)
+	err := row.Scan(&client.ID, &client.FullName, &client.Email, &client.IsEligibleToTravel, &client.SuspensionReason, &optLockVersion)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	return &client, &optLockVersion, nil
 }
+
+func (r *ClientRepository) GetUserByEmail(email string) (*types.MobileClient, error) {
+	row := r.db.QueryRow("SELECT id, full_name, email, password_hash, is_eligible_to_travel, suspension_reason FROM users WHERE email = ?", email)
+
+	var client types.MobileClient
+	err := row.Scan(&client.ID, &client.FullName, &client.Email, &client.PasswordHash, &client.IsEligibleToTravel, &client.SuspensionReason)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
+
+// SetUserCredentials only sets credentials of users created before riders could log in,
+// so that a leaked token can not be used to take the account over.
+func (r *ClientRepository) SetUserCredentials(client types.MobileClient) error {
+	result, err := r.db.Exec("UPDATE users SET email = ?, password_hash = ? WHERE id = UUID_TO_BIN(?, false) AND email IS NULL",
+		client.Email, client.PasswordHash, client.ID.String())
+
+	var mySqlErr *mysql.MySQLError
+	if errors.As(err, &mySqlErr) && mySqlErr.Number == mySqlDuplicateEntryErrorNumber {
+		return ErrEmailTaken
+	}
+
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrCredentialsAlreadySet
+	}
+
+	return nil
+}
+
+func (r *ClientRepository) GetUsers(queryParams types.GetUsersQueryParameters) ([]*types.MobileClient, error) {
+	rows, err := r.db.Query("SELECT id, full_name, email, is_eligible_to_travel, suspension_reason FROM users WHERE full_name LIKE ? ORDER BY full_name, id LIMIT ? OFFSET ?",
+		"%"+escapeLikePattern(queryParams.FullName)+"%", queryParams.Limit, queryParams.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clients := make([]*types.MobileClient, 0)
+	for rows.Next() {
+		var client types.MobileClient
+		if err := rows.Scan(&client.ID, &client.FullName, &client.Email, &client.IsEligibleToTravel, &client.SuspensionReason); err != nil {
+			return nil, err
+		}
+
+		clients = append(clients, &client)
+	}
+
+	return clients, nil
+}
+
+func (r *ClientRepository) IsInActiveTrip(id string) (bool, error) {
+	row := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM trips WHERE user_id = UUID_TO_BIN(?, false) AND is_finished = false)", id)
+
+	var isInActiveTrip bool
+	if err := row.Scan(&isInActiveTrip); err != nil {
+		return false, err
+	}
+
+	return isInActiveTrip, nil
+}
+
+func (r *ClientRepository) UpdateUserEligibility(client types.MobileClient, optLockVersion *int) error {
+	result, err := r.db.Exec("UPDATE users SET is_eligible_to_travel = ?, suspension_reason = ?, opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ?",
+		client.IsEligibleToTravel, client.SuspensionReason, *optLockVersion, client.ID.String(), *optLockVersion)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrConcurrentUpdate
+	}
+
+	return nil
+}
+
+func escapeLikePattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
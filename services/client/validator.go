@@ -0,0 +1,88 @@
+package client
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type ClientValidator struct{}
+
+var Validator = validator.New()
+
+const (
+	maxUsersPageSize  = 100
+	maxEmailLength    = 255
+	minPasswordLength = 8
+	// bcrypt ignores everything past 72 bytes, longer passwords would only give a false sense of security
+	maxPasswordLength = 72
+)
+
+func NewClientValidator() *ClientValidator {
+	return &ClientValidator{}
+}
+
+func (v *ClientValidator) ValidateCreateUserRequest(request *types.CreateUserRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	return validateCredentials(request.Email, request.Password)
+}
+
+func (v *ClientValidator) ValidateLoginRequest(request *types.UserCredentialsRequest) error {
+	return Validator.Struct(request)
+}
+
+func (v *ClientValidator) ValidateUserCredentialsRequest(request *types.UserCredentialsRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	return validateCredentials(request.Email, request.Password)
+}
+
+func (v *ClientValidator) ValidateGetUsersQueryParameters(queryParams *types.GetUsersQueryParameters) error {
+	if queryParams.Limit < 0 || queryParams.Limit > maxUsersPageSize {
+		return errors.New("invalid limit")
+	}
+
+	if queryParams.Offset < 0 {
+		return errors.New("invalid offset")
+	}
+
+	return nil
+}
+
+func (v *ClientValidator) ValidateUpdateUserRequest(request *types.UpdateUserRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	if !*request.IsEligibleToTravel && request.Reason == "" {
+		return errors.New("reason is required when suspending a user")
+	}
+
+	if len(request.Reason) > 255 {
+		return errors.New("reason must not be longer than 255 characters")
+	}
+
+	return nil
+}
+
+func validateCredentials(email string, password string) error {
+	if len(email) > maxEmailLength {
+		return errors.New("email must not be longer than 255 characters")
+	}
+
+	if len(password) < minPasswordLength {
+		return errors.New("password must be at least 8 characters long")
+	}
+
+	if len(password) > maxPasswordLength {
+		return errors.New("password must not be longer than 72 bytes")
+	}
+
+	return nil
+}
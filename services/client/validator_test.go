@@ -0,0 +1,113 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+func TestClientValidator(t *testing.T) {
+	validator := NewClientValidator()
+
+	t.Run("When validating create user request while given valid credentials returns nil", func(t *testing.T) {
+		request := types.CreateUserRequest{FullName: "John Doe", Email: "john.doe@example.com", Password: "correct-password"}
+
+		result := validator.ValidateCreateUserRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create user request while given malformed email returns error", func(t *testing.T) {
+		request := types.CreateUserRequest{FullName: "John Doe", Email: "john.doe", Password: "correct-password"}
+
+		result := validator.ValidateCreateUserRequest(&request)
+		if result == nil {
+			t.Errorf("expected result to be an error, got nil")
+		}
+	})
+
+	t.Run("When validating create user request while given short password returns error", func(t *testing.T) {
+		request := types.CreateUserRequest{FullName: "John Doe", Email: "john.doe@example.com", Password: "short"}
+
+		result := validator.ValidateCreateUserRequest(&request)
+		if result == nil || result.Error() != "password must be at least 8 characters long" {
+			t.Errorf("expected result to be: password must be at least 8 characters long, got %v", result)
+		}
+	})
+
+	t.Run("When validating user credentials request while password is too long returns error", func(t *testing.T) {
+		request := types.UserCredentialsRequest{Email: "john.doe@example.com", Password: strings.Repeat("a", 73)}
+
+		result := validator.ValidateUserCredentialsRequest(&request)
+		if result == nil || result.Error() != "password must not be longer than 72 bytes" {
+			t.Errorf("expected result to be: password must not be longer than 72 bytes, got %v", result)
+		}
+	})
+
+	t.Run("When validating login request while password is missing returns error", func(t *testing.T) {
+		request := types.UserCredentialsRequest{Email: "john.doe@example.com"}
+
+		result := validator.ValidateLoginRequest(&request)
+		if result == nil {
+			t.Errorf("expected result to be an error, got nil")
+		}
+	})
+
+	t.Run("When validating get users query while given valid params returns nil", func(t *testing.T) {
+		queryParams := types.GetUsersQueryParameters{FullName: "John", Limit: 50, Offset: 100}
+
+		result := validator.ValidateGetUsersQueryParameters(&queryParams)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get users query while given invalid limit returns error", func(t *testing.T) {
+		queryParams := types.GetUsersQueryParameters{Limit: 101}
+
+		result := validator.ValidateGetUsersQueryParameters(&queryParams)
+		if result.Error() != "invalid limit" {
+			t.Errorf("expected result to be invalid limit, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get users query while given negative offset returns error", func(t *testing.T) {
+		queryParams := types.GetUsersQueryParameters{Offset: -1}
+
+		result := validator.ValidateGetUsersQueryParameters(&queryParams)
+		if result.Error() != "invalid offset" {
+			t.Errorf("expected result to be invalid offset, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating update user request while reinstating without reason returns nil", func(t *testing.T) {
+		isEligibleToTravel := true
+		request := types.UpdateUserRequest{IsEligibleToTravel: &isEligibleToTravel}
+
+		result := validator.ValidateUpdateUserRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating update user request while suspending without reason returns error", func(t *testing.T) {
+		isEligibleToTravel := false
+		request := types.UpdateUserRequest{IsEligibleToTravel: &isEligibleToTravel}
+
+		result := validator.ValidateUpdateUserRequest(&request)
+		if result.Error() != "reason is required when suspending a user" {
+			t.Errorf("expected result to be: reason is required when suspending a user, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating update user request while eligibility is missing returns error", func(t *testing.T) {
+		request := types.UpdateUserRequest{Reason: "unpaid fares"}
+
+		result := validator.ValidateUpdateUserRequest(&request)
+		if result.Error() != "Key: 'UpdateUserRequest.IsEligibleToTravel' Error:Field validation for 'IsEligibleToTravel' failed on the 'required' tag" {
+			t.Errorf("expected required tag validation error, got %s", result.Error())
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package idempotency
+
+import (
+	"time"
+
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type InMemoryIdempotencyRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryIdempotencyRepository {
+	return &InMemoryIdempotencyRepository{storage: storage}
+}
+
+func (r *InMemoryIdempotencyRepository) CreateIdempotencyRecord(record types.IdempotencyRecord) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if _, ok := r.storage.IdempotencyRecords[recordKey(record.Caller, record.Key)]; ok {
+		return ErrIdempotencyKeyExists
+	}
+
+	record.StatusCode = 0
+	record.ResponseBody = nil
+	r.storage.IdempotencyRecords[recordKey(record.Caller, record.Key)] = &record
+	return nil
+}
+
+func (r *InMemoryIdempotencyRepository) GetIdempotencyRecord(caller string, key string) (*types.IdempotencyRecord, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	record, ok := r.storage.IdempotencyRecords[recordKey(caller, key)]
+	if !ok {
+		return nil, ErrIdempotencyRecordNotFound
+	}
+
+	result := *record
+	return &result, nil
+}
+
+func (r *InMemoryIdempotencyRepository) CompleteIdempotencyRecord(record types.IdempotencyRecord) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if existing, ok := r.storage.IdempotencyRecords[recordKey(record.Caller, record.Key)]; ok {
+		existing.StatusCode = record.StatusCode
+		existing.ResponseBody = record.ResponseBody
+	}
+
+	return nil
+}
+
+func (r *InMemoryIdempotencyRepository) DeleteIdempotencyRecord(caller string, key string) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	delete(r.storage.IdempotencyRecords, recordKey(caller, key))
+	return nil
+}
+
+func (r *InMemoryIdempotencyRepository) DeleteExpiredIdempotencyRecords(createdBefore time.Time) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	for key, record := range r.storage.IdempotencyRecords {
+		if record.CreatedAt.Before(createdBefore) {
+			delete(r.storage.IdempotencyRecords, key)
+		}
+	}
+
+	return nil
+}
+
+// recordKey joins caller and key with a space, which neither of them may contain.
+func recordKey(caller string, key string) string {
+	return caller + " " + key
+}
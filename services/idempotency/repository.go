@@ -0,0 +1,72 @@
+package idempotency
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+var (
+	ErrIdempotencyKeyExists      = errors.New("idempotency key already exists")
+	ErrIdempotencyRecordNotFound = errors.New("idempotency record not found")
+)
+
+const mySqlDuplicateEntryErrorNumber = 1062
+
+func NewRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+func (r *IdempotencyRepository) CreateIdempotencyRecord(record types.IdempotencyRecord) error {
+	_, err := r.db.Exec("INSERT INTO idempotency_keys (caller, idempotency_key, request_hash, created_at) VALUES (?, ?, ?, ?)",
+		record.Caller, record.Key, record.RequestHash, record.CreatedAt)
+
+	var mySqlErr *mysql.MySQLError
+	if errors.As(err, &mySqlErr) && mySqlErr.Number == mySqlDuplicateEntryErrorNumber {
+		return ErrIdempotencyKeyExists
+	}
+
+	return err
+}
+
+func (r *IdempotencyRepository) GetIdempotencyRecord(caller string, key string) (*types.IdempotencyRecord, error) {
+	row := r.db.QueryRow("SELECT caller, idempotency_key, request_hash, status_code, response_body, created_at FROM idempotency_keys WHERE caller = ? AND idempotency_key = ?",
+		caller, key)
+
+	var record types.IdempotencyRecord
+	var statusCode sql.NullInt32
+	err := row.Scan(&record.Caller, &record.Key, &record.RequestHash, &statusCode, &record.ResponseBody, &record.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrIdempotencyRecordNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	record.StatusCode = int(statusCode.Int32)
+	return &record, nil
+}
+
+func (r *IdempotencyRepository) CompleteIdempotencyRecord(record types.IdempotencyRecord) error {
+	_, err := r.db.Exec("UPDATE idempotency_keys SET status_code = ?, response_body = ? WHERE caller = ? AND idempotency_key = ?",
+		record.StatusCode, record.ResponseBody, record.Caller, record.Key)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteIdempotencyRecord(caller string, key string) error {
+	_, err := r.db.Exec("DELETE FROM idempotency_keys WHERE caller = ? AND idempotency_key = ?", caller, key)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteExpiredIdempotencyRecords(createdBefore time.Time) error {
+	_, err := r.db.Exec("DELETE FROM idempotency_keys WHERE created_at < ?", createdBefore)
+	return err
+}
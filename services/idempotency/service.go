@@ -0,0 +1,202 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyService stores the first response given to a request carrying an Idempotency-Key header,
+// so that retries within the ttl get the same response instead of repeating the side effects.
+type IdempotencyService struct {
+	repository interfaces.IdempotencyRepository
+	ttl        time.Duration
+	mu         sync.Mutex
+	purgedAt   time.Time
+	now        func() time.Time
+}
+
+// bodyRecorder keeps a copy of everything written to the response.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+func NewIdempotencyService(repository interfaces.IdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{repository: repository, ttl: ttl, purgedAt: time.Now(), now: time.Now}
+}
+
+// Handle must be chained after authentication, keys are only unique per caller.
+// Requests without the header are passed through untouched.
+func (s *IdempotencyService) Handle(c *gin.Context) {
+	key := c.GetHeader(IdempotencyKeyHeader)
+	if key == "" {
+		c.Next()
+		return
+	}
+
+	if len(key) > maxIdempotencyKeyLength || strings.ContainsAny(key, " \t\r\n") {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"Bad request": "invalid idempotency key"})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	now := s.now()
+	s.purgeExpiredRecords(now)
+
+	record := types.IdempotencyRecord{
+		Caller:      utils.GetCallerKey(c),
+		Key:         key,
+		RequestHash: hashRequest(c.Request.Method, c.Request.URL.Path, body),
+		CreatedAt:   now.UTC().Truncate(time.Second),
+	}
+
+	existing, err := s.reserve(record, now)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error reserving idempotency key"})
+		return
+	}
+
+	if existing != nil {
+		s.replay(c, existing, record.RequestHash)
+		return
+	}
+
+	// The key is released unless the response gets stored, also when the handler panics,
+	// otherwise retries would be rejected as in progress until the record expires
+	completed := false
+	defer func() {
+		if !completed {
+			s.release(record)
+		}
+	}()
+
+	recorder := &bodyRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	c.Next()
+
+	// Server errors are not stored, the request may succeed when retried
+	if recorder.Status() >= http.StatusInternalServerError {
+		return
+	}
+
+	record.StatusCode = recorder.Status()
+	record.ResponseBody = recorder.body.Bytes()
+	if err := s.repository.CompleteIdempotencyRecord(record); err != nil {
+		log.Println("Error storing idempotent response:", err)
+		return
+	}
+
+	completed = true
+}
+
+func (s *IdempotencyService) release(record types.IdempotencyRecord) {
+	if err := s.repository.DeleteIdempotencyRecord(record.Caller, record.Key); err != nil {
+		log.Println("Error releasing idempotency key:", err)
+	}
+}
+
+// reserve stores the record unless the key is already taken, in which case the existing record is returned.
+func (s *IdempotencyService) reserve(record types.IdempotencyRecord, now time.Time) (*types.IdempotencyRecord, error) {
+	err := s.repository.CreateIdempotencyRecord(record)
+	if err == nil {
+		return nil, nil
+	}
+
+	if !errors.Is(err, ErrIdempotencyKeyExists) {
+		return nil, err
+	}
+
+	existing, err := s.repository.GetIdempotencyRecord(record.Caller, record.Key)
+	if errors.Is(err, ErrIdempotencyRecordNotFound) {
+		// Released by a failed request in the meantime
+		return nil, s.repository.CreateIdempotencyRecord(record)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if now.Sub(existing.CreatedAt) < s.ttl {
+		return existing, nil
+	}
+
+	if err := s.repository.DeleteExpiredIdempotencyRecords(now.Add(-s.ttl)); err != nil {
+		return nil, err
+	}
+
+	return nil, s.repository.CreateIdempotencyRecord(record)
+}
+
+func (s *IdempotencyService) replay(c *gin.Context, record *types.IdempotencyRecord, requestHash string) {
+	if record.RequestHash != requestHash {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"Unprocessable Entity": "idempotency key was already used for a different request"})
+		return
+	}
+
+	if record.StatusCode == 0 {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"Conflict": "request with the same idempotency key is still in progress"})
+		return
+	}
+
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(record.StatusCode, "application/json; charset=utf-8", record.ResponseBody)
+	c.Abort()
+}
+
+// purgeExpiredRecords runs at most once per ttl, so that the table does not grow forever.
+func (s *IdempotencyService) purgeExpiredRecords(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.purgedAt) < s.ttl {
+		return
+	}
+
+	if err := s.repository.DeleteExpiredIdempotencyRecords(now.Add(-s.ttl)); err != nil {
+		log.Println("Error purging expired idempotency keys:", err)
+		return
+	}
+
+	s.purgedAt = now
+}
+
+func hashRequest(method string, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,157 @@
+package idempotency
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+func TestIdempotencyService(t *testing.T) {
+	newRouter := func(service *IdempotencyService, calls *int, status int) *gin.Engine {
+		router := gin.Default()
+		router.POST("/client/trips", func(c *gin.Context) {
+			c.Set(utils.ClientIdKey, c.GetHeader("client-id"))
+		}, service.Handle, func(c *gin.Context) {
+			*calls++
+			c.JSON(status, gin.H{"call": *calls})
+		})
+		return router
+	}
+
+	serveRequest := func(router *gin.Engine, clientId string, key string, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/client/trips", bytes.NewBufferString(body))
+		request.Header.Set("client-id", clientId)
+		request.Header.Set(IdempotencyKeyHeader, key)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+		return responseRecoreder
+	}
+
+	t.Run("When retrying request with the same key returns stored response without handling it again", func(t *testing.T) {
+		service := NewIdempotencyService(NewInMemoryRepository(db.NewInMemoryStorage()), time.Hour)
+		calls := 0
+		router := newRouter(service, &calls, http.StatusCreated)
+
+		first := serveRequest(router, "rider-1", "key-1", `{"scooter_id":"1"}`)
+		retry := serveRequest(router, "rider-1", "key-1", `{"scooter_id":"1"}`)
+
+		if calls != 1 {
+			t.Errorf("expected handler to be called once, got %d", calls)
+		}
+
+		if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+			t.Errorf("expected %d %s to be replayed, got %d %s", first.Code, first.Body.String(), retry.Code, retry.Body.String())
+		}
+
+		if retry.Header().Get("Idempotent-Replayed") != "true" {
+			t.Errorf("expected response to be marked as replayed")
+		}
+	})
+
+	t.Run("When reusing key with a different body returns unprocessable entity", func(t *testing.T) {
+		service := NewIdempotencyService(NewInMemoryRepository(db.NewInMemoryStorage()), time.Hour)
+		calls := 0
+		router := newRouter(service, &calls, http.StatusCreated)
+
+		serveRequest(router, "rider-1", "key-1", `{"scooter_id":"1"}`)
+		responseRecoreder := serveRequest(router, "rider-1", "key-1", `{"scooter_id":"2"}`)
+
+		if responseRecoreder.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status code %d but got %d", http.StatusUnprocessableEntity, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When using the same key as another rider handles request", func(t *testing.T) {
+		service := NewIdempotencyService(NewInMemoryRepository(db.NewInMemoryStorage()), time.Hour)
+		calls := 0
+		router := newRouter(service, &calls, http.StatusCreated)
+
+		serveRequest(router, "rider-1", "key-1", `{"scooter_id":"1"}`)
+		serveRequest(router, "rider-2", "key-1", `{"scooter_id":"1"}`)
+
+		if calls != 2 {
+			t.Errorf("expected handler to be called twice, got %d", calls)
+		}
+	})
+
+	t.Run("When retrying request while first one failed with server error handles it again", func(t *testing.T) {
+		service := NewIdempotencyService(NewInMemoryRepository(db.NewInMemoryStorage()), time.Hour)
+		calls := 0
+		router := newRouter(service, &calls, http.StatusInternalServerError)
+
+		serveRequest(router, "rider-1", "key-1", `{"scooter_id":"1"}`)
+		serveRequest(router, "rider-1", "key-1", `{"scooter_id":"1"}`)
+
+		if calls != 2 {
+			t.Errorf("expected handler to be called twice, got %d", calls)
+		}
+	})
+
+	t.Run("When retrying request while first one panicked handles it again", func(t *testing.T) {
+		service := NewIdempotencyService(NewInMemoryRepository(db.NewInMemoryStorage()), time.Hour)
+		calls := 0
+		router := gin.Default()
+		router.POST("/client/trips", service.Handle, func(c *gin.Context) {
+			calls++
+			if calls == 1 {
+				panic("handler failed")
+			}
+			c.JSON(http.StatusCreated, gin.H{"call": calls})
+		})
+
+		first := serveRequest(router, "", "key-1", `{}`)
+		retry := serveRequest(router, "", "key-1", `{}`)
+
+		if first.Code != http.StatusInternalServerError {
+			t.Errorf("expected status code %d but got %d", http.StatusInternalServerError, first.Code)
+		}
+
+		if retry.Code != http.StatusCreated || calls != 2 {
+			t.Errorf("expected handler to be called again with status code %d, got %d after %d calls", http.StatusCreated, retry.Code, calls)
+		}
+	})
+
+	t.Run("When retrying request after ttl handles it again", func(t *testing.T) {
+		service := NewIdempotencyService(NewInMemoryRepository(db.NewInMemoryStorage()), time.Hour)
+		now := time.Now()
+		service.now = func() time.Time { return now }
+		calls := 0
+		router := newRouter(service, &calls, http.StatusCreated)
+
+		serveRequest(router, "rider-1", "key-1", `{"scooter_id":"1"}`)
+		now = now.Add(2 * time.Hour)
+		serveRequest(router, "rider-1", "key-1", `{"scooter_id":"1"}`)
+
+		if calls != 2 {
+			t.Errorf("expected handler to be called twice, got %d", calls)
+		}
+	})
+
+	t.Run("When retrying request while first one is in progress returns conflict", func(t *testing.T) {
+		repository := NewInMemoryRepository(db.NewInMemoryStorage())
+		service := NewIdempotencyService(repository, time.Hour)
+		calls := 0
+		router := gin.Default()
+		router.POST("/client/trips", service.Handle, func(c *gin.Context) {
+			calls++
+			retry := serveRequest(router, "", "key-1", `{}`)
+			c.JSON(retry.Code, gin.H{})
+		})
+
+		responseRecoreder := serveRequest(router, "", "key-1", `{}`)
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+
+		if calls != 1 {
+			t.Errorf("expected handler to be called once, got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package maintenance
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+type MaintenanceHandler struct {
+	repository          interfaces.MaintenanceTicketRepository
+	scootersRepository  interfaces.ScooterRepository
+	validator           interfaces.MaintenanceTicketValidator
+	lowBatteryThreshold int
+}
+
+func NewMaintenanceHandler(
+	repository interfaces.MaintenanceTicketRepository,
+	scootersRepository interfaces.ScooterRepository,
+	validator interfaces.MaintenanceTicketValidator,
+	lowBatteryThreshold int) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		repository:          repository,
+		scootersRepository:  scootersRepository,
+		validator:           validator,
+		lowBatteryThreshold: lowBatteryThreshold,
+	}
+}
+
+func (h *MaintenanceHandler) RegisterEndpoints(routes interfaces.Routes) {
+	routes.Admin(enums.WriteScooters).POST("/scooters/:id/tickets", h.openTicket)
+	routes.Admin(enums.ReadScooters).GET("/scooters/:id/tickets", h.getTickets)
+	routes.Admin(enums.WriteScooters).POST("/scooters/:id/tickets/:ticketId/resolve", h.resolveTicket)
+}
+
+// openTicket puts the scooter in maintenance, where it stays until every ticket opened for it is resolved.
+func (h *MaintenanceHandler) openTicket(c *gin.Context) {
+	scooterId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	var request types.CreateTicketRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateCreateTicketRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	storedScooter, scooterOptLockVersion, err := h.scootersRepository.GetScooterById(scooterId.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	if err := utils.ValidateScooterTransition(storedScooter.Status, enums.MaintenanceScooter); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	ticket := types.MaintenanceTicket{
+		ID:          uuid.New(),
+		ScooterID:   storedScooter.ID,
+		Status:      enums.OpenTicket,
+		Description: request.Description,
+		OpenedBy:    utils.GetCallerKey(c),
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+	storedScooter.Status = enums.MaintenanceScooter
+
+	err = h.repository.OpenTicket(ticket, storedScooter, scooterOptLockVersion)
+	if errors.Is(err, scooter.ErrConcurrentUpdate) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "maintenance ticket could not be opened"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.MaintenanceTicketResponse{Ticket: &ticket, ScooterStatus: storedScooter.Status})
+}
+
+func (h *MaintenanceHandler) getTickets(c *gin.Context) {
+	scooterId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	if _, _, err := h.scootersRepository.GetScooterById(scooterId.String()); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	tickets, err := h.repository.GetTicketsByScooterId(scooterId.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting maintenance tickets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.GetMaintenanceTicketsResponse{Tickets: tickets})
+}
+
+// resolveTicket releases the scooter from maintenance once its last open ticket is resolved.
+// A scooter with a low battery is left charging instead.
+func (h *MaintenanceHandler) resolveTicket(c *gin.Context) {
+	scooterId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	ticketId, err := uuid.Parse(c.Param("ticketId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	var request types.ResolveTicketRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateResolveTicketRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	ticket, err := h.repository.GetTicketById(ticketId.String())
+	if err != nil || ticket.ScooterID != scooterId {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": ErrTicketNotFound.Error()})
+		return
+	}
+
+	if ticket.Status != enums.OpenTicket {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": ErrTicketNotOpen.Error()})
+		return
+	}
+
+	storedScooter, scooterOptLockVersion, err := h.scootersRepository.GetScooterById(scooterId.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	tickets, err := h.repository.GetTicketsByScooterId(scooterId.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting maintenance tickets"})
+		return
+	}
+
+	if storedScooter.Status == enums.MaintenanceScooter && !hasOtherOpenTickets(tickets, ticket.ID) {
+		storedScooter.Status = utils.IdleScooterStatus(storedScooter.BatteryLevel, h.lowBatteryThreshold)
+	}
+
+	resolvedBy := utils.GetCallerKey(c)
+	resolvedAt := time.Now().UTC().Truncate(time.Second)
+	ticket.Status = enums.ResolvedTicket
+	ticket.Resolution = &request.Resolution
+	ticket.ResolvedBy = &resolvedBy
+	ticket.ResolvedAt = &resolvedAt
+
+	err = h.repository.ResolveTicket(ticket, storedScooter, scooterOptLockVersion)
+	if errors.Is(err, ErrTicketNotOpen) || errors.Is(err, scooter.ErrConcurrentUpdate) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "maintenance ticket could not be resolved"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.MaintenanceTicketResponse{Ticket: ticket, ScooterStatus: storedScooter.Status})
+}
+
+func hasOtherOpenTickets(tickets []*types.MaintenanceTicket, ticketId uuid.UUID) bool {
+	for _, ticket := range tickets {
+		if ticket.ID != ticketId && ticket.Status == enums.OpenTicket {
+			return true
+		}
+	}
+
+	return false
+}
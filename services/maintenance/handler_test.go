@@ -0,0 +1,409 @@
+package maintenance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+const (
+	availableScooterId         = "e3344268-d649-4c19-a20c-a0c64a5a6623"
+	inTripScooterId            = "e3344268-d649-4c19-a20c-a0c64a5a1111"
+	concurrentScooterId        = "e3344268-d649-4c19-a20c-a0c64a5a3333"
+	maintenanceScooterId       = "e3344268-d649-4c19-a20c-a0c64a5a5555"
+	lowBatteryMaintenanceId    = "e3344268-d649-4c19-a20c-a0c64a5a7777"
+	nonExistentScooterId       = "e3344268-d649-4c19-a20c-a0c64a5a9999"
+	openTicketId               = "b1d2c3e4-0000-4000-8000-000000000001"
+	otherOpenTicketId          = "b1d2c3e4-0000-4000-8000-000000000002"
+	resolvedTicketId           = "b1d2c3e4-0000-4000-8000-000000000003"
+	lowBatteryScooterTicketId  = "b1d2c3e4-0000-4000-8000-000000000004"
+	operatorId                 = "4f1c2a6e-5d3b-4c7a-9e8f-0a1b2c3d4e5f"
+	expectedOpenedByOperatorId = "operator:" + operatorId
+)
+
+func TestMaintenanceHandler(t *testing.T) {
+	newTicket := func(id string, scooterId string, status enums.TicketStatus) *types.MaintenanceTicket {
+		return &types.MaintenanceTicket{
+			ID:          uuid.MustParse(id),
+			ScooterID:   uuid.MustParse(scooterId),
+			Status:      status,
+			Description: "Brake lever is loose",
+			OpenedBy:    expectedOpenedByOperatorId,
+			CreatedAt:   time.Now().Add(-time.Hour),
+		}
+	}
+
+	serveRequest := func(handler *MaintenanceHandler, method string, path string, requestBody any) *httptest.ResponseRecorder {
+		var body *bytes.Buffer
+		if requestBody != nil {
+			marshalledRequestBody, _ := json.Marshal(requestBody)
+			body = bytes.NewBuffer(marshalledRequestBody)
+		} else {
+			body = bytes.NewBuffer(nil)
+		}
+
+		request, err := http.NewRequest(method, path, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		setOperator := func(c *gin.Context) {
+			c.Set(utils.OperatorIdKey, operatorId)
+			c.Next()
+		}
+		router.POST("/admin/scooters/:id/tickets", setOperator, handler.openTicket)
+		router.GET("/admin/scooters/:id/tickets", setOperator, handler.getTickets)
+		router.POST("/admin/scooters/:id/tickets/:ticketId/resolve", setOperator, handler.resolveTicket)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+		return responseRecoreder
+	}
+
+	t.Run("When opening ticket while scooter is available returns created and puts scooter in maintenance", func(t *testing.T) {
+		ticketsRepository := &mockTicketRepository{}
+		handler := NewMaintenanceHandler(ticketsRepository, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+availableScooterId+"/tickets", types.CreateTicketRequest{Description: "Brake lever is loose"})
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Fatalf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+
+		var response types.MaintenanceTicketResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.ScooterStatus != enums.MaintenanceScooter {
+			t.Errorf("expected scooter status to be maintenance, got %s", response.ScooterStatus)
+		}
+
+		if response.Ticket.Status != enums.OpenTicket || response.Ticket.OpenedBy != expectedOpenedByOperatorId {
+			t.Errorf("expected open ticket opened by %s, got %s ticket opened by %s", expectedOpenedByOperatorId, response.Ticket.Status, response.Ticket.OpenedBy)
+		}
+
+		if ticketsRepository.savedScooter == nil || ticketsRepository.savedScooter.Status != enums.MaintenanceScooter {
+			t.Errorf("expected scooter to be saved in maintenance, got %v", ticketsRepository.savedScooter)
+		}
+	})
+
+	t.Run("When opening ticket while scooter is already in maintenance returns created", func(t *testing.T) {
+		handler := NewMaintenanceHandler(&mockTicketRepository{}, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+maintenanceScooterId+"/tickets", types.CreateTicketRequest{Description: "Bell is missing"})
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When opening ticket while scooter is in an active trip returns conflict", func(t *testing.T) {
+		ticketsRepository := &mockTicketRepository{}
+		handler := NewMaintenanceHandler(ticketsRepository, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+inTripScooterId+"/tickets", types.CreateTicketRequest{Description: "Brake lever is loose"})
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+
+		if ticketsRepository.savedScooter != nil {
+			t.Errorf("expected no ticket to be opened")
+		}
+	})
+
+	t.Run("When opening ticket while description is missing returns bad request", func(t *testing.T) {
+		handler := NewMaintenanceHandler(&mockTicketRepository{}, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+availableScooterId+"/tickets", types.CreateTicketRequest{})
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When opening ticket while scooter id is invalid returns bad request", func(t *testing.T) {
+		handler := NewMaintenanceHandler(&mockTicketRepository{}, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/not-a-uuid/tickets", types.CreateTicketRequest{Description: "Brake lever is loose"})
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When opening ticket while scooter is not existant returns not found", func(t *testing.T) {
+		handler := NewMaintenanceHandler(&mockTicketRepository{}, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+nonExistentScooterId+"/tickets", types.CreateTicketRequest{Description: "Brake lever is loose"})
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When opening ticket while scooter was updated concurrently returns conflict", func(t *testing.T) {
+		handler := NewMaintenanceHandler(&mockTicketRepository{}, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+concurrentScooterId+"/tickets", types.CreateTicketRequest{Description: "Brake lever is loose"})
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting tickets while scooter has tickets returns ok", func(t *testing.T) {
+		ticketsRepository := &mockTicketRepository{tickets: []*types.MaintenanceTicket{
+			newTicket(openTicketId, maintenanceScooterId, enums.OpenTicket),
+			newTicket(resolvedTicketId, maintenanceScooterId, enums.ResolvedTicket),
+			newTicket(lowBatteryScooterTicketId, lowBatteryMaintenanceId, enums.OpenTicket),
+		}}
+		handler := NewMaintenanceHandler(ticketsRepository, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodGet, "/admin/scooters/"+maintenanceScooterId+"/tickets", nil)
+		if responseRecoreder.Code != http.StatusOK {
+			t.Fatalf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.GetMaintenanceTicketsResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(response.Tickets) != 2 {
+			t.Errorf("expected 2 tickets, got %d", len(response.Tickets))
+		}
+	})
+
+	t.Run("When getting tickets while scooter is not existant returns not found", func(t *testing.T) {
+		handler := NewMaintenanceHandler(&mockTicketRepository{}, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodGet, "/admin/scooters/"+nonExistentScooterId+"/tickets", nil)
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When resolving ticket while it is the last open one returns ok and makes scooter available", func(t *testing.T) {
+		ticketsRepository := &mockTicketRepository{tickets: []*types.MaintenanceTicket{
+			newTicket(openTicketId, maintenanceScooterId, enums.OpenTicket),
+			newTicket(resolvedTicketId, maintenanceScooterId, enums.ResolvedTicket),
+		}}
+		handler := NewMaintenanceHandler(ticketsRepository, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+maintenanceScooterId+"/tickets/"+openTicketId+"/resolve", types.ResolveTicketRequest{Resolution: "Brake lever tightened"})
+		if responseRecoreder.Code != http.StatusOK {
+			t.Fatalf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.MaintenanceTicketResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.ScooterStatus != enums.AvailableScooter {
+			t.Errorf("expected scooter status to be available, got %s", response.ScooterStatus)
+		}
+
+		if response.Ticket.Status != enums.ResolvedTicket || response.Ticket.ResolvedBy == nil || *response.Ticket.ResolvedBy != expectedOpenedByOperatorId {
+			t.Errorf("expected ticket to be resolved by %s, got %s ticket", expectedOpenedByOperatorId, response.Ticket.Status)
+		}
+
+		if ticketsRepository.savedScooter == nil || ticketsRepository.savedScooter.Status != enums.AvailableScooter {
+			t.Errorf("expected scooter to be saved as available, got %v", ticketsRepository.savedScooter)
+		}
+	})
+
+	t.Run("When resolving ticket while scooter battery is low returns ok and leaves scooter charging", func(t *testing.T) {
+		ticketsRepository := &mockTicketRepository{tickets: []*types.MaintenanceTicket{
+			newTicket(lowBatteryScooterTicketId, lowBatteryMaintenanceId, enums.OpenTicket),
+		}}
+		handler := NewMaintenanceHandler(ticketsRepository, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+lowBatteryMaintenanceId+"/tickets/"+lowBatteryScooterTicketId+"/resolve", types.ResolveTicketRequest{Resolution: "Brake lever tightened"})
+		if responseRecoreder.Code != http.StatusOK {
+			t.Fatalf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		if ticketsRepository.savedScooter == nil || ticketsRepository.savedScooter.Status != enums.ChargingScooter {
+			t.Errorf("expected scooter to be saved as charging, got %v", ticketsRepository.savedScooter)
+		}
+	})
+
+	t.Run("When resolving ticket while another ticket is still open returns ok and keeps scooter in maintenance", func(t *testing.T) {
+		ticketsRepository := &mockTicketRepository{tickets: []*types.MaintenanceTicket{
+			newTicket(openTicketId, maintenanceScooterId, enums.OpenTicket),
+			newTicket(otherOpenTicketId, maintenanceScooterId, enums.OpenTicket),
+		}}
+		handler := NewMaintenanceHandler(ticketsRepository, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+maintenanceScooterId+"/tickets/"+openTicketId+"/resolve", types.ResolveTicketRequest{Resolution: "Brake lever tightened"})
+		if responseRecoreder.Code != http.StatusOK {
+			t.Fatalf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		if ticketsRepository.savedScooter == nil || ticketsRepository.savedScooter.Status != enums.MaintenanceScooter {
+			t.Errorf("expected scooter to stay in maintenance, got %v", ticketsRepository.savedScooter)
+		}
+	})
+
+	t.Run("When resolving ticket while it is already resolved returns conflict", func(t *testing.T) {
+		ticketsRepository := &mockTicketRepository{tickets: []*types.MaintenanceTicket{
+			newTicket(resolvedTicketId, maintenanceScooterId, enums.ResolvedTicket),
+		}}
+		handler := NewMaintenanceHandler(ticketsRepository, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+maintenanceScooterId+"/tickets/"+resolvedTicketId+"/resolve", types.ResolveTicketRequest{Resolution: "Brake lever tightened"})
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When resolving ticket while it belongs to another scooter returns not found", func(t *testing.T) {
+		ticketsRepository := &mockTicketRepository{tickets: []*types.MaintenanceTicket{
+			newTicket(lowBatteryScooterTicketId, lowBatteryMaintenanceId, enums.OpenTicket),
+		}}
+		handler := NewMaintenanceHandler(ticketsRepository, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+maintenanceScooterId+"/tickets/"+lowBatteryScooterTicketId+"/resolve", types.ResolveTicketRequest{Resolution: "Brake lever tightened"})
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+
+		if ticketsRepository.savedScooter != nil {
+			t.Errorf("expected no ticket to be resolved")
+		}
+	})
+
+	t.Run("When resolving ticket while resolution is missing returns bad request", func(t *testing.T) {
+		handler := NewMaintenanceHandler(&mockTicketRepository{}, &mockScooterRepository{}, &mockTicketValidator{}, 15)
+
+		responseRecoreder := serveRequest(handler, http.MethodPost, "/admin/scooters/"+maintenanceScooterId+"/tickets/"+openTicketId+"/resolve", types.ResolveTicketRequest{})
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+}
+
+type mockTicketValidator struct{}
+
+func (m *mockTicketValidator) ValidateCreateTicketRequest(request *types.CreateTicketRequest) error {
+	if request.Description == "" {
+		return errors.New("invalid description")
+	}
+
+	return nil
+}
+
+func (m *mockTicketValidator) ValidateResolveTicketRequest(request *types.ResolveTicketRequest) error {
+	if request.Resolution == "" {
+		return errors.New("invalid resolution")
+	}
+
+	return nil
+}
+
+type mockTicketRepository struct {
+	tickets      []*types.MaintenanceTicket
+	savedScooter *types.Scooter
+}
+
+func (m *mockTicketRepository) OpenTicket(ticket types.MaintenanceTicket, updatedScooter *types.Scooter, scooterOptLockVersion *int) error {
+	if updatedScooter.ID.String() == concurrentScooterId {
+		return scooter.ErrConcurrentUpdate
+	}
+
+	m.tickets = append(m.tickets, &ticket)
+	m.savedScooter = updatedScooter
+	return nil
+}
+
+func (m *mockTicketRepository) GetTicketById(id string) (*types.MaintenanceTicket, error) {
+	for _, ticket := range m.tickets {
+		if ticket.ID.String() == id {
+			storedTicket := *ticket
+			return &storedTicket, nil
+		}
+	}
+
+	return nil, ErrTicketNotFound
+}
+
+func (m *mockTicketRepository) GetTicketsByScooterId(scooterId string) ([]*types.MaintenanceTicket, error) {
+	tickets := []*types.MaintenanceTicket{}
+	for _, ticket := range m.tickets {
+		if ticket.ScooterID.String() == scooterId {
+			tickets = append(tickets, ticket)
+		}
+	}
+
+	return tickets, nil
+}
+
+func (m *mockTicketRepository) ResolveTicket(ticket *types.MaintenanceTicket, updatedScooter *types.Scooter, scooterOptLockVersion *int) error {
+	m.savedScooter = updatedScooter
+	return nil
+}
+
+type mockScooterRepository struct{}
+
+func (m *mockScooterRepository) GetScooterById(id string) (*types.Scooter, *int, error) {
+	idInUUID, err := uuid.Parse(id)
+	if err != nil || id == nonExistentScooterId {
+		return nil, nil, errors.New("issue while getting scooter by id")
+	}
+
+	storedScooter := &types.Scooter{ID: idInUUID, Status: enums.AvailableScooter, BatteryLevel: 80}
+	switch id {
+	case inTripScooterId:
+		storedScooter.Status = enums.InTripScooter
+	case maintenanceScooterId:
+		storedScooter.Status = enums.MaintenanceScooter
+	case lowBatteryMaintenanceId:
+		storedScooter.Status = enums.MaintenanceScooter
+		storedScooter.BatteryLevel = 5
+	}
+
+	return storedScooter, new(int), nil
+}
+
+// GetAllScooters implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) GetAllScooters(queryParams types.GetAllScootersQueryParameters) ([]*types.Scooter, error) {
+	panic("unimplemented")
+}
+
+// GetScootersByArea implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) GetScootersByArea(queryParams types.GetScootersQueryParameters) ([]*types.Scooter, error) {
+	panic("unimplemented")
+}
+
+// GetScootersByRadius implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) GetScootersByRadius(queryParams types.GetScootersQueryParameters) ([]*types.NearbyScooter, error) {
+	panic("unimplemented")
+}
+
+// CreateScooter implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) CreateScooter(scooter types.Scooter) error {
+	panic("unimplemented")
+}
+
+// UpdateScooter implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) UpdateScooter(scooter types.Scooter, optLockVersion *int) error {
+	panic("unimplemented")
+}
+
+// IsInActiveTrip implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) IsInActiveTrip(id string) (bool, error) {
+	panic("unimplemented")
+}
+
+// IsReserved implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) IsReserved(id string) (bool, error) {
+	panic("unimplemented")
+}
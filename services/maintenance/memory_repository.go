@@ -0,0 +1,100 @@
+package maintenance
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+type InMemoryMaintenanceTicketRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryMaintenanceTicketRepository {
+	return &InMemoryMaintenanceTicketRepository{storage: storage}
+}
+
+func (r *InMemoryMaintenanceTicketRepository) OpenTicket(ticket types.MaintenanceTicket, updatedScooter *types.Scooter, scooterOptLockVersion *int) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if _, ok := r.storage.MaintenanceTickets[ticket.ID.String()]; ok {
+		return fmt.Errorf("maintenance ticket with id %s already exists", ticket.ID.String())
+	}
+
+	if err := r.updateScooterStatus(updatedScooter, scooterOptLockVersion); err != nil {
+		return err
+	}
+
+	r.storage.MaintenanceTickets[ticket.ID.String()] = &ticket
+	return nil
+}
+
+func (r *InMemoryMaintenanceTicketRepository) GetTicketById(id string) (*types.MaintenanceTicket, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	stored, ok := r.storage.MaintenanceTickets[id]
+	if !ok {
+		return nil, ErrTicketNotFound
+	}
+
+	ticket := *stored
+	return &ticket, nil
+}
+
+func (r *InMemoryMaintenanceTicketRepository) GetTicketsByScooterId(scooterId string) ([]*types.MaintenanceTicket, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	tickets := make([]*types.MaintenanceTicket, 0)
+	for _, stored := range r.storage.MaintenanceTickets {
+		if stored.ScooterID.String() == scooterId {
+			ticket := *stored
+			tickets = append(tickets, &ticket)
+		}
+	}
+
+	sort.Slice(tickets, func(i, j int) bool {
+		if !tickets[i].CreatedAt.Equal(tickets[j].CreatedAt) {
+			return tickets[i].CreatedAt.After(tickets[j].CreatedAt)
+		}
+		return tickets[i].ID.String() < tickets[j].ID.String()
+	})
+
+	return tickets, nil
+}
+
+func (r *InMemoryMaintenanceTicketRepository) ResolveTicket(ticket *types.MaintenanceTicket, updatedScooter *types.Scooter, scooterOptLockVersion *int) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	stored, ok := r.storage.MaintenanceTickets[ticket.ID.String()]
+	if !ok || stored.Status != enums.OpenTicket {
+		return ErrTicketNotOpen
+	}
+
+	if err := r.updateScooterStatus(updatedScooter, scooterOptLockVersion); err != nil {
+		return err
+	}
+
+	resolved := *ticket
+	r.storage.MaintenanceTickets[ticket.ID.String()] = &resolved
+	return nil
+}
+
+// updateScooterStatus mimics the lock version check of the MySql repository, the caller must hold the lock.
+func (r *InMemoryMaintenanceTicketRepository) updateScooterStatus(updatedScooter *types.Scooter, optLockVersion *int) error {
+	record, ok := r.storage.Scooters[updatedScooter.ID.String()]
+	if !ok || record.OptLockVersion != *optLockVersion {
+		return scooter.ErrConcurrentUpdate
+	}
+
+	record.Value.Status = updatedScooter.Status
+	record.OptLockVersion++
+	return nil
+}
@@ -0,0 +1,147 @@
+package maintenance
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+type MaintenanceTicketRepository struct {
+	db *sql.DB
+}
+
+var (
+	ErrTicketNotFound = errors.New("maintenance ticket not found")
+	ErrTicketNotOpen  = errors.New("maintenance ticket is already resolved")
+)
+
+const ticketColumns = "id, scooter_id, status, description, opened_by, created_at, resolution, resolved_by, resolved_at"
+
+var updateScooterStatusQuery = "UPDATE scooters SET status = ?, opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ?"
+
+func NewRepository(db *sql.DB) *MaintenanceTicketRepository {
+	return &MaintenanceTicketRepository{db: db}
+}
+
+func (r *MaintenanceTicketRepository) OpenTicket(ticket types.MaintenanceTicket, scooter *types.Scooter, scooterOptLockVersion *int) error {
+	openTicketQuery := "INSERT INTO maintenance_tickets (id, scooter_id, status, description, opened_by, created_at) VALUES (UUID_TO_BIN(?, false), UUID_TO_BIN(?, false), ?, ?, ?, ?)"
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(openTicketQuery, ticket.ID.String(), ticket.ScooterID.String(), ticket.Status, ticket.Description, ticket.OpenedBy, ticket.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := updateScooterStatus(tx, scooter, scooterOptLockVersion); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *MaintenanceTicketRepository) GetTicketById(id string) (*types.MaintenanceTicket, error) {
+	rows, err := r.db.Query("SELECT "+ticketColumns+" FROM maintenance_tickets WHERE id = UUID_TO_BIN(?, false)", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tickets, err := scanRowsIntoTickets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tickets) == 0 {
+		return nil, ErrTicketNotFound
+	}
+
+	return tickets[0], nil
+}
+
+func (r *MaintenanceTicketRepository) GetTicketsByScooterId(scooterId string) ([]*types.MaintenanceTicket, error) {
+	rows, err := r.db.Query("SELECT "+ticketColumns+" FROM maintenance_tickets WHERE scooter_id = UUID_TO_BIN(?, false) ORDER BY created_at DESC, id", scooterId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRowsIntoTickets(rows)
+}
+
+// ResolveTicket always bumps the scooter's lock version, so that tickets of the same scooter are resolved one at a time
+// and the last one to be resolved is guaranteed to release the scooter.
+func (r *MaintenanceTicketRepository) ResolveTicket(ticket *types.MaintenanceTicket, scooter *types.Scooter, scooterOptLockVersion *int) error {
+	resolveTicketQuery := "UPDATE maintenance_tickets SET status = ?, resolution = ?, resolved_by = ?, resolved_at = ? WHERE id = UUID_TO_BIN(?, false) AND status = ?"
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(resolveTicketQuery, ticket.Status, ticket.Resolution, ticket.ResolvedBy, ticket.ResolvedAt, ticket.ID.String(), enums.OpenTicket)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return ErrTicketNotOpen
+	}
+
+	if err := updateScooterStatus(tx, scooter, scooterOptLockVersion); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func updateScooterStatus(tx *sql.Tx, updatedScooter *types.Scooter, optLockVersion *int) error {
+	result, err := tx.Exec(updateScooterStatusQuery, updatedScooter.Status, *optLockVersion, updatedScooter.ID.String(), *optLockVersion)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return scooter.ErrConcurrentUpdate
+	}
+
+	return nil
+}
+
+func scanRowsIntoTickets(rows *sql.Rows) ([]*types.MaintenanceTicket, error) {
+	tickets := make([]*types.MaintenanceTicket, 0)
+	for rows.Next() {
+		var ticket types.MaintenanceTicket
+		err := rows.Scan(&ticket.ID, &ticket.ScooterID, &ticket.Status, &ticket.Description, &ticket.OpenedBy, &ticket.CreatedAt,
+			&ticket.Resolution, &ticket.ResolvedBy, &ticket.ResolvedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		tickets = append(tickets, &ticket)
+	}
+
+	return tickets, rows.Err()
+}
@@ -0,0 +1,42 @@
+package maintenance
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type MaintenanceTicketValidator struct{}
+
+var Validator = validator.New()
+
+const maxTicketTextLength = 1024
+
+func NewMaintenanceTicketValidator() *MaintenanceTicketValidator {
+	return &MaintenanceTicketValidator{}
+}
+
+func (v *MaintenanceTicketValidator) ValidateCreateTicketRequest(request *types.CreateTicketRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	if len(request.Description) > maxTicketTextLength {
+		return errors.New("invalid description")
+	}
+
+	return nil
+}
+
+func (v *MaintenanceTicketValidator) ValidateResolveTicketRequest(request *types.ResolveTicketRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	if len(request.Resolution) > maxTicketTextLength {
+		return errors.New("invalid resolution")
+	}
+
+	return nil
+}
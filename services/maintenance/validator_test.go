@@ -0,0 +1,66 @@
+package maintenance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+func TestMaintenanceTicketValidator(t *testing.T) {
+	validator := NewMaintenanceTicketValidator()
+
+	t.Run("When validating create ticket request while given valid description returns nil", func(t *testing.T) {
+		request := types.CreateTicketRequest{Description: "Brake lever is loose"}
+
+		result := validator.ValidateCreateTicketRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create ticket request while description is missing returns error", func(t *testing.T) {
+		request := types.CreateTicketRequest{}
+
+		result := validator.ValidateCreateTicketRequest(&request)
+		if result == nil {
+			t.Errorf("expected result to be an error, got nil")
+		}
+	})
+
+	t.Run("When validating create ticket request while description is too long returns error", func(t *testing.T) {
+		request := types.CreateTicketRequest{Description: strings.Repeat("a", maxTicketTextLength+1)}
+
+		result := validator.ValidateCreateTicketRequest(&request)
+		if result == nil || result.Error() != "invalid description" {
+			t.Errorf("expected result to be: invalid description, got %v", result)
+		}
+	})
+
+	t.Run("When validating resolve ticket request while given valid resolution returns nil", func(t *testing.T) {
+		request := types.ResolveTicketRequest{Resolution: "Brake lever tightened"}
+
+		result := validator.ValidateResolveTicketRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating resolve ticket request while resolution is missing returns error", func(t *testing.T) {
+		request := types.ResolveTicketRequest{}
+
+		result := validator.ValidateResolveTicketRequest(&request)
+		if result == nil {
+			t.Errorf("expected result to be an error, got nil")
+		}
+	})
+
+	t.Run("When validating resolve ticket request while resolution is too long returns error", func(t *testing.T) {
+		request := types.ResolveTicketRequest{Resolution: strings.Repeat("a", maxTicketTextLength+1)}
+
+		result := validator.ValidateResolveTicketRequest(&request)
+		if result == nil || result.Error() != "invalid resolution" {
+			t.Errorf("expected result to be: invalid resolution, got %v", result)
+		}
+	})
+}
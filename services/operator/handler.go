@@ -0,0 +1,108 @@
+package operator
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type OperatorHandler struct {
+	repository interfaces.OperatorRepository
+	validator  interfaces.OperatorValidator
+	tokens     interfaces.TokenService
+}
+
+// dummyPasswordHash is compared against when the username is unknown,
+// so that response times do not reveal which usernames exist.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+func NewOperatorHandler(repository interfaces.OperatorRepository, validator interfaces.OperatorValidator, tokens interfaces.TokenService) *OperatorHandler {
+	return &OperatorHandler{repository: repository, validator: validator, tokens: tokens}
+}
+
+func (h *OperatorHandler) RegisterEndpoints(routes interfaces.Routes) {
+	routes.Public().POST("/admin/login", h.login)
+	routes.Admin(enums.ManageOperators).POST("/operators", h.createOperator)
+}
+
+func (h *OperatorHandler) login(c *gin.Context) {
+	var request types.LoginRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateLoginRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	operator, err := h.repository.GetOperatorByUsername(request.Username)
+	if err != nil && !errors.Is(err, ErrOperatorNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting operator"})
+		return
+	}
+
+	passwordHash := dummyPasswordHash
+	if operator != nil {
+		passwordHash = []byte(operator.PasswordHash)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(passwordHash, []byte(request.Password)); err != nil || operator == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "invalid username or password"})
+		return
+	}
+
+	token, err := h.tokens.IssueToken(types.TokenClaims{Subject: operator.ID, Role: operator.Role})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error issuing token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+func (h *OperatorHandler) createOperator(c *gin.Context) {
+	var request types.CreateOperatorRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateCreateOperatorRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error hashing password"})
+		return
+	}
+
+	operator := types.Operator{
+		ID:           uuid.New(),
+		Username:     request.Username,
+		Role:         request.Role,
+		PasswordHash: string(passwordHash),
+	}
+
+	err = h.repository.CreateOperator(operator)
+	if errors.Is(err, ErrUsernameTaken) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, operator)
+}
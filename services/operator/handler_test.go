@@ -0,0 +1,243 @@
+package operator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestOperatorHandler(t *testing.T) {
+	repository := &mockOperatorRepository{}
+	validator := &mockOperatorValidator{}
+	tokens := &mockTokenService{}
+	handler := NewOperatorHandler(repository, validator, tokens)
+
+	t.Run("When logging in while credentials are valid returns token", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.LoginRequest{Username: "fleet.manager", Password: "correct horse battery"})
+		request, err := http.NewRequest(http.MethodPost, "/admin/login", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/login", handler.login)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.AuthToken
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Token != "token-"+operatorId.String() {
+			t.Errorf("expected token to be issued for %s, got %s", operatorId.String(), response.Token)
+		}
+	})
+
+	t.Run("When logging in while password is wrong returns unauthorized", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.LoginRequest{Username: "fleet.manager", Password: "wrong password"})
+		request, err := http.NewRequest(http.MethodPost, "/admin/login", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/login", handler.login)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When logging in while operator does not exist returns unauthorized", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.LoginRequest{Username: "nobody", Password: "dummy-password"})
+		request, err := http.NewRequest(http.MethodPost, "/admin/login", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/login", handler.login)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When logging in while repository fails returns internal server error", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.LoginRequest{Username: "broken", Password: "whatever"})
+		request, err := http.NewRequest(http.MethodPost, "/admin/login", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/login", handler.login)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusInternalServerError {
+			t.Errorf("expected status code %d but got %d", http.StatusInternalServerError, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When logging in while request body is not valid returns bad request", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/admin/login", bytes.NewBuffer([]byte("{}")))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/login", handler.login)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When creating operator while everything is valid returns status created", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CreateOperatorRequest{Username: "support.agent", Password: "long enough password", Role: enums.SupportRole})
+		request, err := http.NewRequest(http.MethodPost, "/admin/operators", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/operators", handler.createOperator)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+
+		var response map[string]interface{}
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response["username"] != "support.agent" {
+			t.Errorf("expected username to be support.agent, got %v", response["username"])
+		}
+
+		if _, ok := response["PasswordHash"]; ok {
+			t.Errorf("expected password hash not to be returned")
+		}
+	})
+
+	t.Run("When creating operator while username is taken returns conflict", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CreateOperatorRequest{Username: "fleet.manager", Password: "long enough password", Role: enums.SupportRole})
+		request, err := http.NewRequest(http.MethodPost, "/admin/operators", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/operators", handler.createOperator)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When creating operator while password is too short returns bad request", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CreateOperatorRequest{Username: "support.agent", Password: "short", Role: enums.SupportRole})
+		request, err := http.NewRequest(http.MethodPost, "/admin/operators", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/operators", handler.createOperator)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+}
+
+var operatorId = uuid.MustParse("0b6f3c1e-5c2a-4a8e-1111-6d1f0c7e9a21")
+
+type mockOperatorRepository struct{}
+
+func (m *mockOperatorRepository) CreateOperator(operator types.Operator) error {
+	if operator.Username == "fleet.manager" {
+		return ErrUsernameTaken
+	}
+
+	return nil
+}
+
+func (m *mockOperatorRepository) GetOperatorByUsername(username string) (*types.Operator, error) {
+	if username == "broken" {
+		return nil, errors.New("connection refused")
+	}
+
+	if username != "fleet.manager" {
+		return nil, ErrOperatorNotFound
+	}
+
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("correct horse battery"), bcrypt.MinCost)
+	return &types.Operator{ID: operatorId, Username: username, PasswordHash: string(passwordHash)}, nil
+}
+
+type mockOperatorValidator struct{}
+
+func (m *mockOperatorValidator) ValidateLoginRequest(request *types.LoginRequest) error {
+	if request.Username == "" || request.Password == "" {
+		return errors.New("username and password are required")
+	}
+
+	return nil
+}
+
+func (m *mockOperatorValidator) ValidateCreateOperatorRequest(request *types.CreateOperatorRequest) error {
+	if len(request.Password) < 12 {
+		return errors.New("password must be at least 12 characters long")
+	}
+
+	return nil
+}
+
+type mockTokenService struct{}
+
+func (m *mockTokenService) IssueToken(claims types.TokenClaims) (*types.AuthToken, error) {
+	return &types.AuthToken{Token: "token-" + claims.Subject.String(), ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+// ParseToken implements interfaces.TokenService.
+func (m *mockTokenService) ParseToken(token string) (*types.TokenClaims, error) {
+	panic("unimplemented")
+}
@@ -0,0 +1,39 @@
+package operator
+
+import (
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type InMemoryOperatorRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryOperatorRepository {
+	return &InMemoryOperatorRepository{storage: storage}
+}
+
+func (r *InMemoryOperatorRepository) CreateOperator(operator types.Operator) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if _, ok := r.storage.Operators[operator.Username]; ok {
+		return ErrUsernameTaken
+	}
+
+	r.storage.Operators[operator.Username] = &operator
+	return nil
+}
+
+func (r *InMemoryOperatorRepository) GetOperatorByUsername(username string) (*types.Operator, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	operator, ok := r.storage.Operators[username]
+	if !ok {
+		return nil, ErrOperatorNotFound
+	}
+
+	result := *operator
+	return &result, nil
+}
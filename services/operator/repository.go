@@ -0,0 +1,52 @@
+package operator
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type OperatorRepository struct {
+	db *sql.DB
+}
+
+var (
+	ErrUsernameTaken    = errors.New("operator with the same username already exists")
+	ErrOperatorNotFound = errors.New("operator not found")
+)
+
+const mySqlDuplicateEntryErrorNumber = 1062
+
+func NewRepository(db *sql.DB) *OperatorRepository {
+	return &OperatorRepository{db: db}
+}
+
+func (r *OperatorRepository) CreateOperator(operator types.Operator) error {
+	_, err := r.db.Exec("INSERT INTO operators (id, username, role, password_hash) VALUES (UUID_TO_BIN(?, false), ?, ?, ?)",
+		operator.ID.String(), operator.Username, operator.Role, operator.PasswordHash)
+
+	var mySqlErr *mysql.MySQLError
+	if errors.As(err, &mySqlErr) && mySqlErr.Number == mySqlDuplicateEntryErrorNumber {
+		return ErrUsernameTaken
+	}
+
+	return err
+}
+
+func (r *OperatorRepository) GetOperatorByUsername(username string) (*types.Operator, error) {
+	row := r.db.QueryRow("SELECT id, username, role, password_hash FROM operators WHERE username = ?", username)
+
+	var operator types.Operator
+	err := row.Scan(&operator.ID, &operator.Username, &operator.Role, &operator.PasswordHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOperatorNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &operator, nil
+}
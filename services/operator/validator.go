@@ -0,0 +1,52 @@
+package operator
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+type OperatorValidator struct{}
+
+var Validator = validator.New()
+
+const (
+	maxUsernameLength = 64
+	minPasswordLength = 12
+	// bcrypt ignores everything past 72 bytes, longer passwords would only give a false sense of security
+	maxPasswordLength = 72
+)
+
+func NewOperatorValidator() *OperatorValidator {
+	return &OperatorValidator{}
+}
+
+func (v *OperatorValidator) ValidateLoginRequest(request *types.LoginRequest) error {
+	return Validator.Struct(request)
+}
+
+func (v *OperatorValidator) ValidateCreateOperatorRequest(request *types.CreateOperatorRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	if len(request.Username) > maxUsernameLength {
+		return errors.New("username must not be longer than 64 characters")
+	}
+
+	if len(request.Password) < minPasswordLength {
+		return errors.New("password must be at least 12 characters long")
+	}
+
+	if len(request.Password) > maxPasswordLength {
+		return errors.New("password must not be longer than 72 bytes")
+	}
+
+	if !utils.IsValidRole(request.Role) {
+		return errors.New("invalid role")
+	}
+
+	return nil
+}
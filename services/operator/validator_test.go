@@ -0,0 +1,76 @@
+package operator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestOperatorValidator(t *testing.T) {
+	validator := NewOperatorValidator()
+
+	t.Run("When validating login request while credentials are given returns nil", func(t *testing.T) {
+		request := types.LoginRequest{Username: "fleet.manager", Password: "secret"}
+
+		result := validator.ValidateLoginRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating login request while password is missing returns error", func(t *testing.T) {
+		request := types.LoginRequest{Username: "fleet.manager"}
+
+		result := validator.ValidateLoginRequest(&request)
+		if result == nil {
+			t.Errorf("expected result to be an error, got nil")
+		}
+	})
+
+	t.Run("When validating create operator request while given valid request returns nil", func(t *testing.T) {
+		request := types.CreateOperatorRequest{Username: "fleet.manager", Password: "long enough password", Role: enums.SupportRole}
+
+		result := validator.ValidateCreateOperatorRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create operator request while password is too short returns error", func(t *testing.T) {
+		request := types.CreateOperatorRequest{Username: "fleet.manager", Password: "short", Role: enums.SupportRole}
+
+		result := validator.ValidateCreateOperatorRequest(&request)
+		if result.Error() != "password must be at least 12 characters long" {
+			t.Errorf("expected result to be password must be at least 12 characters long, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create operator request while password is too long returns error", func(t *testing.T) {
+		request := types.CreateOperatorRequest{Username: "fleet.manager", Password: strings.Repeat("a", 73), Role: enums.SupportRole}
+
+		result := validator.ValidateCreateOperatorRequest(&request)
+		if result.Error() != "password must not be longer than 72 bytes" {
+			t.Errorf("expected result to be password must not be longer than 72 bytes, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create operator request while username is too long returns error", func(t *testing.T) {
+		request := types.CreateOperatorRequest{Username: strings.Repeat("a", 65), Password: "long enough password", Role: enums.SupportRole}
+
+		result := validator.ValidateCreateOperatorRequest(&request)
+		if result.Error() != "username must not be longer than 64 characters" {
+			t.Errorf("expected result to be username must not be longer than 64 characters, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create operator request while role is unknown returns error", func(t *testing.T) {
+		request := types.CreateOperatorRequest{Username: "fleet.manager", Password: "long enough password", Role: "superuser"}
+
+		result := validator.ValidateCreateOperatorRequest(&request)
+		if result == nil || result.Error() != "invalid role" {
+			t.Errorf("expected result to be invalid role, got %v", result)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package parking
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+)
+
+const defaultNearbyStationsLimit = 20
+
+type ParkingStationHandler struct {
+	repository interfaces.ParkingStationRepository
+	validator  interfaces.ParkingStationValidator
+}
+
+func NewParkingStationHandler(repository interfaces.ParkingStationRepository, validator interfaces.ParkingStationValidator) *ParkingStationHandler {
+	return &ParkingStationHandler{repository: repository, validator: validator}
+}
+
+func (h *ParkingStationHandler) RegisterEndpoints(routes interfaces.Routes) {
+	routes.Admin(enums.WriteZones).POST("/parking", h.createParkingStation)
+	routes.Admin(enums.ReadZones).GET("/parking", h.getParkingStations)
+	routes.Admin(enums.WriteZones).DELETE("/parking/:id", h.deleteParkingStation)
+
+	routes.Client().GET("/parking", h.getNearbyParkingStations)
+}
+
+func (h *ParkingStationHandler) createParkingStation(c *gin.Context) {
+	var request types.CreateParkingStationRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateCreateParkingStationRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	station := types.ParkingStation{
+		ID:        uuid.New(),
+		Name:      request.Name,
+		Capacity:  request.Capacity,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if request.Geometry != nil {
+		station.Geometry = request.Geometry
+		station.Center = boundaryCenter(*request.Geometry)
+	} else {
+		station.Center = *request.Center
+		station.RadiusMeters = request.RadiusMeters
+	}
+
+	if err := h.repository.CreateParkingStation(station); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, station)
+}
+
+func (h *ParkingStationHandler) getParkingStations(c *gin.Context) {
+	stations, err := h.repository.GetParkingStations()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.GetParkingStationsResponse{ParkingStations: stations})
+}
+
+func (h *ParkingStationHandler) getNearbyParkingStations(c *gin.Context) {
+	var queryParameters types.GetParkingStationsQueryParameters
+	if err := c.BindQuery(&queryParameters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateGetParkingStationsQueryParameters(&queryParameters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	if queryParameters.Limit == 0 {
+		queryParameters.Limit = defaultNearbyStationsLimit
+	}
+
+	stations, err := h.repository.GetNearbyParkingStations(queryParameters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.GetNearbyParkingStationsResponse{ParkingStations: stations})
+}
+
+func (h *ParkingStationHandler) deleteParkingStation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	station, err := h.repository.GetParkingStationById(id.String())
+	if errors.Is(err, ErrParkingStationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	if err := h.repository.DeleteParkingStation(id.String()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, station)
+}
+
+// boundaryCenter averages the positions of the polygon's boundary, skipping the closing one which repeats the first.
+func boundaryCenter(polygon types.GeoJSONPolygon) types.Location {
+	boundary := polygon.Coordinates[0]
+	positions := boundary[:len(boundary)-1]
+
+	var center types.Location
+	for _, position := range positions {
+		center.Longitude += position[0]
+		center.Latitude += position[1]
+	}
+
+	center.Longitude /= float64(len(positions))
+	center.Latitude /= float64(len(positions))
+	return center
+}
@@ -0,0 +1,176 @@
+package parking
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+func TestParkingStationHandler(t *testing.T) {
+	repository := &mockParkingStationRepository{}
+	validator := &mockParkingStationValidator{}
+	handler := NewParkingStationHandler(repository, validator)
+
+	t.Run("When creating parking station while given polygon returns station with its center", func(t *testing.T) {
+		geometry := types.GeoJSONPolygon{
+			Type:        "Polygon",
+			Coordinates: [][][]float64{{{25.28, 54.68}, {25.30, 54.68}, {25.30, 54.70}, {25.28, 54.70}, {25.28, 54.68}}},
+		}
+		marshalledRequestBody, _ := json.Marshal(types.CreateParkingStationRequest{Name: "Town hall", Geometry: &geometry, Capacity: 10})
+		request, err := http.NewRequest(http.MethodPost, "/admin/parking", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/parking", handler.createParkingStation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+
+		center := repository.created.Center
+		if center.Latitude < 54.6899 || center.Latitude > 54.6901 || center.Longitude < 25.2899 || center.Longitude > 25.2901 {
+			t.Errorf("expected center to be 54.69, 25.29, got %v", center)
+		}
+
+		if repository.created.RadiusMeters != 0 || repository.created.Geometry == nil {
+			t.Errorf("expected polygon station without radius, got %v", repository.created)
+		}
+	})
+
+	t.Run("When creating parking station while request is invalid returns bad request", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CreateParkingStationRequest{Name: "Town hall", Capacity: 0})
+		request, err := http.NewRequest(http.MethodPost, "/admin/parking", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/parking", handler.createParkingStation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting nearby parking stations while limit is not given uses default limit", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/parking?lat=54.68&lng=25.28&radius_m=500", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/client/parking", handler.getNearbyParkingStations)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		if repository.queried.Limit != defaultNearbyStationsLimit {
+			t.Errorf("expected limit %d, got %d", defaultNearbyStationsLimit, repository.queried.Limit)
+		}
+	})
+
+	t.Run("When getting nearby parking stations while point is missing returns bad request", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/parking?radius_m=500", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/client/parking", handler.getNearbyParkingStations)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When deleting parking station while station does not exist returns not found", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodDelete, "/admin/parking/"+uuid.New().String(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.DELETE("/admin/parking/:id", handler.deleteParkingStation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+}
+
+type mockParkingStationRepository struct {
+	created types.ParkingStation
+	queried types.GetParkingStationsQueryParameters
+}
+
+func (m *mockParkingStationRepository) CreateParkingStation(station types.ParkingStation) error {
+	m.created = station
+	return nil
+}
+
+// GetParkingStations implements interfaces.ParkingStationRepository.
+func (m *mockParkingStationRepository) GetParkingStations() ([]*types.ParkingStation, error) {
+	panic("unimplemented")
+}
+
+func (m *mockParkingStationRepository) GetParkingStationById(id string) (*types.ParkingStation, error) {
+	return nil, ErrParkingStationNotFound
+}
+
+func (m *mockParkingStationRepository) GetNearbyParkingStations(queryParams types.GetParkingStationsQueryParameters) ([]*types.NearbyParkingStation, error) {
+	m.queried = queryParams
+	return make([]*types.NearbyParkingStation, 0), nil
+}
+
+// DeleteParkingStation implements interfaces.ParkingStationRepository.
+func (m *mockParkingStationRepository) DeleteParkingStation(id string) error {
+	panic("unimplemented")
+}
+
+// GetParkedScooterLocations implements interfaces.ParkingStationRepository.
+func (m *mockParkingStationRepository) GetParkedScooterLocations(minLatitude float64, maxLatitude float64, minLongitude float64, maxLongitude float64) ([]types.Location, error) {
+	panic("unimplemented")
+}
+
+type mockParkingStationValidator struct{}
+
+func (m *mockParkingStationValidator) ValidateCreateParkingStationRequest(request *types.CreateParkingStationRequest) error {
+	if request.Capacity <= 0 {
+		return errors.New("invalid capacity")
+	}
+
+	return nil
+}
+
+func (m *mockParkingStationValidator) ValidateGetParkingStationsQueryParameters(queryParams *types.GetParkingStationsQueryParameters) error {
+	if queryParams.Latitude == nil || queryParams.Longitude == nil {
+		return errors.New("invalid lat")
+	}
+
+	return nil
+}
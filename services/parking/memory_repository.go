@@ -0,0 +1,119 @@
+package parking
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+type InMemoryParkingStationRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryParkingStationRepository {
+	return &InMemoryParkingStationRepository{storage: storage}
+}
+
+func (r *InMemoryParkingStationRepository) CreateParkingStation(station types.ParkingStation) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if _, ok := r.storage.ParkingStations[station.ID.String()]; ok {
+		return fmt.Errorf("parking station with id %s already exists", station.ID.String())
+	}
+
+	r.storage.ParkingStations[station.ID.String()] = &station
+	return nil
+}
+
+func (r *InMemoryParkingStationRepository) GetParkingStations() ([]*types.ParkingStation, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	stations := make([]*types.ParkingStation, 0, len(r.storage.ParkingStations))
+	for _, station := range r.storage.ParkingStations {
+		result := *station
+		stations = append(stations, &result)
+	}
+
+	sort.Slice(stations, func(i, j int) bool {
+		if !stations[i].CreatedAt.Equal(stations[j].CreatedAt) {
+			return stations[i].CreatedAt.Before(stations[j].CreatedAt)
+		}
+		return stations[i].ID.String() < stations[j].ID.String()
+	})
+
+	return stations, nil
+}
+
+func (r *InMemoryParkingStationRepository) GetParkingStationById(id string) (*types.ParkingStation, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	station, ok := r.storage.ParkingStations[id]
+	if !ok {
+		return nil, ErrParkingStationNotFound
+	}
+
+	result := *station
+	return &result, nil
+}
+
+func (r *InMemoryParkingStationRepository) GetNearbyParkingStations(queryParams types.GetParkingStationsQueryParameters) ([]*types.NearbyParkingStation, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	center := types.Location{Latitude: *queryParams.Latitude, Longitude: *queryParams.Longitude}
+	stations := make([]*types.NearbyParkingStation, 0)
+	for _, station := range r.storage.ParkingStations {
+		distance := utils.HaversineDistance(center, station.Center)
+		if distance > queryParams.RadiusMeters {
+			continue
+		}
+
+		stations = append(stations, &types.NearbyParkingStation{ParkingStation: *station, DistanceMeters: distance})
+	}
+
+	sort.Slice(stations, func(i, j int) bool {
+		if stations[i].DistanceMeters != stations[j].DistanceMeters {
+			return stations[i].DistanceMeters < stations[j].DistanceMeters
+		}
+		return stations[i].ID.String() < stations[j].ID.String()
+	})
+
+	return stations[:min(queryParams.Limit, len(stations))], nil
+}
+
+func (r *InMemoryParkingStationRepository) DeleteParkingStation(id string) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	delete(r.storage.ParkingStations, id)
+	return nil
+}
+
+func (r *InMemoryParkingStationRepository) GetParkedScooterLocations(minLatitude float64, maxLatitude float64, minLongitude float64, maxLongitude float64) ([]types.Location, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	locations := make([]types.Location, 0)
+	for _, record := range r.storage.Scooters {
+		scooter := record.Value
+		if scooter.Status == enums.InTripScooter || scooter.Status == enums.RetiredScooter {
+			continue
+		}
+
+		if scooter.Location.Latitude < minLatitude || scooter.Location.Latitude > maxLatitude ||
+			scooter.Location.Longitude < minLongitude || scooter.Location.Longitude > maxLongitude {
+			continue
+		}
+
+		locations = append(locations, scooter.Location)
+	}
+
+	return locations, nil
+}
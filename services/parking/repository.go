@@ -0,0 +1,164 @@
+package parking
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+type ParkingStationRepository struct {
+	db *sql.DB
+}
+
+var ErrParkingStationNotFound = errors.New("parking station not found")
+
+const parkingStationColumns = "id, name, latitude, longitude, radius_m, geometry, capacity, created_at"
+
+func NewRepository(db *sql.DB) *ParkingStationRepository {
+	return &ParkingStationRepository{db: db}
+}
+
+func (r *ParkingStationRepository) CreateParkingStation(station types.ParkingStation) error {
+	// Columns of the other kind of station are left NULL
+	var radiusMeters *float64
+	var geometry []byte
+	if station.Geometry != nil {
+		var err error
+		geometry, err = json.Marshal(station.Geometry)
+		if err != nil {
+			return err
+		}
+	} else {
+		radiusMeters = &station.RadiusMeters
+	}
+
+	_, err := r.db.Exec("INSERT INTO parking_stations (id, name, latitude, longitude, radius_m, geometry, capacity, created_at) VALUES (UUID_TO_BIN(?, false), ?, ?, ?, ?, ?, ?, ?)",
+		station.ID.String(), station.Name, station.Center.Latitude, station.Center.Longitude, radiusMeters, geometry, station.Capacity, station.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ParkingStationRepository) GetParkingStations() ([]*types.ParkingStation, error) {
+	rows, err := r.db.Query("SELECT " + parkingStationColumns + " FROM parking_stations ORDER BY created_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stations := make([]*types.ParkingStation, 0)
+	for rows.Next() {
+		var station types.ParkingStation
+		if err := scanRowIntoParkingStation(rows, &station); err != nil {
+			return nil, err
+		}
+
+		stations = append(stations, &station)
+	}
+
+	return stations, rows.Err()
+}
+
+func (r *ParkingStationRepository) GetParkingStationById(id string) (*types.ParkingStation, error) {
+	rows, err := r.db.Query("SELECT "+parkingStationColumns+" FROM parking_stations WHERE id = UUID_TO_BIN(?, false)", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var station *types.ParkingStation
+	for rows.Next() {
+		station = &types.ParkingStation{}
+		if err := scanRowIntoParkingStation(rows, station); err != nil {
+			return nil, err
+		}
+	}
+
+	if station == nil {
+		return nil, ErrParkingStationNotFound
+	}
+
+	return station, rows.Err()
+}
+
+// GetNearbyParkingStations measures the distance to the center of every station, nearest first.
+func (r *ParkingStationRepository) GetNearbyParkingStations(queryParams types.GetParkingStationsQueryParameters) ([]*types.NearbyParkingStation, error) {
+	query := "SELECT " + parkingStationColumns + ", ST_Distance_Sphere(POINT(longitude, latitude), POINT(?, ?), ?) AS distance FROM parking_stations " +
+		"HAVING distance <= ? ORDER BY distance, id LIMIT ?"
+
+	rows, err := r.db.Query(query, *queryParams.Longitude, *queryParams.Latitude, utils.EarthRadiusMeters, queryParams.RadiusMeters, queryParams.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stations := make([]*types.NearbyParkingStation, 0)
+	for rows.Next() {
+		var station types.NearbyParkingStation
+		if err := scanRowIntoParkingStation(rows, &station.ParkingStation, &station.DistanceMeters); err != nil {
+			return nil, err
+		}
+
+		stations = append(stations, &station)
+	}
+
+	return stations, rows.Err()
+}
+
+func (r *ParkingStationRepository) DeleteParkingStation(id string) error {
+	_, err := r.db.Exec("DELETE FROM parking_stations WHERE id = UUID_TO_BIN(?, false)", id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetParkedScooterLocations returns where the scooters standing within the given bounds are.
+// Scooters in a trip or retired do not take up a parking spot.
+func (r *ParkingStationRepository) GetParkedScooterLocations(minLatitude float64, maxLatitude float64, minLongitude float64, maxLongitude float64) ([]types.Location, error) {
+	query := "SELECT latitude, longitude FROM scooters WHERE latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ? AND status NOT IN (?, ?)"
+
+	rows, err := r.db.Query(query, minLatitude, maxLatitude, minLongitude, maxLongitude, enums.InTripScooter, enums.RetiredScooter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := make([]types.Location, 0)
+	for rows.Next() {
+		var location types.Location
+		if err := rows.Scan(&location.Latitude, &location.Longitude); err != nil {
+			return nil, err
+		}
+
+		locations = append(locations, location)
+	}
+
+	return locations, rows.Err()
+}
+
+// scanRowIntoParkingStation scans the parking station columns followed by any extra ones.
+func scanRowIntoParkingStation(row *sql.Rows, station *types.ParkingStation, extra ...any) error {
+	var radiusMeters sql.NullFloat64
+	var geometry []byte
+
+	destinations := append([]any{&station.ID, &station.Name, &station.Center.Latitude, &station.Center.Longitude, &radiusMeters, &geometry, &station.Capacity, &station.CreatedAt}, extra...)
+	if err := row.Scan(destinations...); err != nil {
+		return err
+	}
+
+	station.RadiusMeters = radiusMeters.Float64
+	if geometry == nil {
+		return nil
+	}
+
+	station.Geometry = &types.GeoJSONPolygon{}
+	return json.Unmarshal(geometry, station.Geometry)
+}
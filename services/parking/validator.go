@@ -0,0 +1,87 @@
+package parking
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nerijusro/scootinAboot/services/zone"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type ParkingStationValidator struct{}
+
+var Validator = validator.New()
+
+const (
+	maxStationRadiusMeters = 500
+	maxStationsPageSize    = 100
+	maxSearchRadiusMeters  = 50000
+)
+
+func NewParkingStationValidator() *ParkingStationValidator {
+	return &ParkingStationValidator{}
+}
+
+func (v *ParkingStationValidator) ValidateCreateParkingStationRequest(request *types.CreateParkingStationRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	if len(request.Name) > 255 {
+		return errors.New("name must not be longer than 255 characters")
+	}
+
+	if request.Capacity <= 0 {
+		return errors.New("invalid capacity")
+	}
+
+	if (request.Center == nil) == (request.Geometry == nil) {
+		return errors.New("either center and radius_m or geometry must be given")
+	}
+
+	if request.Geometry != nil {
+		if request.RadiusMeters != 0 {
+			return errors.New("radius_m can not be combined with geometry")
+		}
+
+		return zone.ValidatePolygon(*request.Geometry)
+	}
+
+	if request.Center.Latitude < -90 || request.Center.Latitude > 90 {
+		return errors.New("invalid latitude")
+	}
+
+	if request.Center.Longitude < -180 || request.Center.Longitude > 180 {
+		return errors.New("invalid longitude")
+	}
+
+	if request.RadiusMeters <= 0 || request.RadiusMeters > maxStationRadiusMeters {
+		return errors.New("invalid radius_m")
+	}
+
+	return nil
+}
+
+func (v *ParkingStationValidator) ValidateGetParkingStationsQueryParameters(queryParams *types.GetParkingStationsQueryParameters) error {
+	if err := Validator.Struct(queryParams); err != nil {
+		return err
+	}
+
+	if *queryParams.Latitude < -90 || *queryParams.Latitude > 90 {
+		return errors.New("invalid lat")
+	}
+
+	if *queryParams.Longitude < -180 || *queryParams.Longitude > 180 {
+		return errors.New("invalid lng")
+	}
+
+	if queryParams.RadiusMeters <= 0 || queryParams.RadiusMeters > maxSearchRadiusMeters {
+		return errors.New("invalid radius_m")
+	}
+
+	if queryParams.Limit < 0 || queryParams.Limit > maxStationsPageSize {
+		return errors.New("invalid limit")
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package parking
+
+import (
+	"testing"
+
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+func TestParkingStationValidator(t *testing.T) {
+	validator := NewParkingStationValidator()
+	geometry := types.GeoJSONPolygon{
+		Type:        "Polygon",
+		Coordinates: [][][]float64{{{25.28, 54.68}, {25.30, 54.68}, {25.30, 54.70}, {25.28, 54.70}, {25.28, 54.68}}},
+	}
+
+	t.Run("When validating create parking station request while given center and radius returns nil", func(t *testing.T) {
+		request := types.CreateParkingStationRequest{Name: "Cathedral", Center: &types.Location{Latitude: 54.68, Longitude: 25.28}, RadiusMeters: 30, Capacity: 10}
+
+		result := validator.ValidateCreateParkingStationRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create parking station request while given polygon returns nil", func(t *testing.T) {
+		request := types.CreateParkingStationRequest{Name: "Town hall", Geometry: &geometry, Capacity: 10}
+
+		result := validator.ValidateCreateParkingStationRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create parking station request while given both center and polygon returns error", func(t *testing.T) {
+		request := types.CreateParkingStationRequest{Name: "Town hall", Center: &types.Location{Latitude: 54.68, Longitude: 25.28}, RadiusMeters: 30, Geometry: &geometry, Capacity: 10}
+
+		result := validator.ValidateCreateParkingStationRequest(&request)
+		if result == nil || result.Error() != "either center and radius_m or geometry must be given" {
+			t.Errorf("expected result to be: either center and radius_m or geometry must be given, got %v", result)
+		}
+	})
+
+	t.Run("When validating create parking station request while radius is too large returns error", func(t *testing.T) {
+		request := types.CreateParkingStationRequest{Name: "Cathedral", Center: &types.Location{Latitude: 54.68, Longitude: 25.28}, RadiusMeters: 501, Capacity: 10}
+
+		result := validator.ValidateCreateParkingStationRequest(&request)
+		if result == nil || result.Error() != "invalid radius_m" {
+			t.Errorf("expected result to be: invalid radius_m, got %v", result)
+		}
+	})
+
+	t.Run("When validating create parking station request while capacity is not positive returns error", func(t *testing.T) {
+		request := types.CreateParkingStationRequest{Name: "Cathedral", Center: &types.Location{Latitude: 54.68, Longitude: 25.28}, RadiusMeters: 30}
+
+		result := validator.ValidateCreateParkingStationRequest(&request)
+		if result == nil || result.Error() != "invalid capacity" {
+			t.Errorf("expected result to be: invalid capacity, got %v", result)
+		}
+	})
+
+	t.Run("When validating get parking stations query while radius is missing returns error", func(t *testing.T) {
+		latitude, longitude := 54.68, 25.28
+		queryParams := types.GetParkingStationsQueryParameters{Latitude: &latitude, Longitude: &longitude}
+
+		result := validator.ValidateGetParkingStationsQueryParameters(&queryParams)
+		if result == nil || result.Error() != "invalid radius_m" {
+			t.Errorf("expected result to be: invalid radius_m, got %v", result)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package pricing
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+// FareCalculator prices trips in minor currency units (e.g. cents).
+type FareCalculator struct {
+	currency            string
+	unlockFee           int
+	perMinuteRate       int
+	pausedPerMinuteRate int
+	perKmRate           int
+	minimumFare         int
+}
+
+func NewFareCalculator(currency string, unlockFee int, perMinuteRate int, pausedPerMinuteRate int, perKmRate int, minimumFare int) *FareCalculator {
+	return &FareCalculator{
+		currency:            currency,
+		unlockFee:           unlockFee,
+		perMinuteRate:       perMinuteRate,
+		pausedPerMinuteRate: pausedPerMinuteRate,
+		perKmRate:           perKmRate,
+		minimumFare:         minimumFare,
+	}
+}
+
+// CalculateFare charges every started minute between the first and the last event
+// and the distance travelled between consecutive event locations.
+// Minutes between a pause and the following event are charged at the paused rate and the scooter is not expected to move.
+func (c *FareCalculator) CalculateFare(events []*types.TripEvent) types.Fare {
+	fare := types.Fare{Currency: c.currency}
+	if len(events) == 0 {
+		return fare
+	}
+
+	orderedEvents := make([]*types.TripEvent, len(events))
+	copy(orderedEvents, events)
+	sort.SliceStable(orderedEvents, func(i, j int) bool {
+		return orderedEvents[i].Sequence < orderedEvents[j].Sequence
+	})
+
+	var pausedDuration time.Duration
+	for i := 1; i < len(orderedEvents); i++ {
+		if orderedEvents[i-1].Type == enums.PauseTrip {
+			pausedDuration += max(orderedEvents[i].CreatedAt.Sub(orderedEvents[i-1].CreatedAt), 0)
+			continue
+		}
+
+		fare.DistanceMeters += utils.HaversineDistance(orderedEvents[i-1].Location, orderedEvents[i].Location)
+	}
+
+	duration := orderedEvents[len(orderedEvents)-1].CreatedAt.Sub(orderedEvents[0].CreatedAt)
+	if duration > 0 {
+		fare.DurationSeconds = int(math.Ceil(duration.Seconds()))
+	}
+	fare.PausedSeconds = min(int(math.Ceil(pausedDuration.Seconds())), fare.DurationSeconds)
+
+	billedMinutes := int(math.Ceil(float64(fare.DurationSeconds-fare.PausedSeconds) / 60))
+	billedPausedMinutes := int(math.Ceil(float64(fare.PausedSeconds) / 60))
+	fare.Amount = c.unlockFee +
+		billedMinutes*c.perMinuteRate +
+		billedPausedMinutes*c.pausedPerMinuteRate +
+		int(math.Round(fare.DistanceMeters/1000*float64(c.perKmRate)))
+	if fare.Amount < c.minimumFare {
+		fare.Amount = c.minimumFare
+	}
+
+	return fare
+}
@@ -0,0 +1,93 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestFareCalculator(t *testing.T) {
+	calculator := NewFareCalculator("EUR", 100, 25, 10, 10, 150)
+	tripId := uuid.New()
+	startedAt := time.Date(2024, 4, 26, 17, 0, 0, 0, time.UTC)
+
+	t.Run("When calculating fare while trip is long returns unlock fee, time and distance", func(t *testing.T) {
+		events := []*types.TripEvent{
+			{TripID: tripId, Type: enums.EndTrip, Location: types.Location{Latitude: 54.1, Longitude: 25.0}, CreatedAt: startedAt.Add(10*time.Minute + time.Second), Sequence: 3},
+			{TripID: tripId, Type: enums.StartTrip, Location: types.Location{Latitude: 54.0, Longitude: 25.0}, CreatedAt: startedAt, Sequence: 1},
+			{TripID: tripId, Type: enums.UpdateTrip, Location: types.Location{Latitude: 54.05, Longitude: 25.0}, CreatedAt: startedAt.Add(5 * time.Minute), Sequence: 2},
+		}
+
+		fare := calculator.CalculateFare(events)
+
+		if fare.DistanceMeters < 11110 || fare.DistanceMeters > 11125 {
+			t.Errorf("expected distance to be around 11119 meters, got %f", fare.DistanceMeters)
+		}
+
+		if fare.DurationSeconds != 601 {
+			t.Errorf("expected duration to be 601 seconds, got %d", fare.DurationSeconds)
+		}
+
+		// 100 unlock + 11 started minutes * 25 + 11.12 km * 10
+		if fare.Amount != 486 {
+			t.Errorf("expected amount to be 486, got %d", fare.Amount)
+		}
+
+		if fare.Currency != "EUR" {
+			t.Errorf("expected currency to be EUR, got %s", fare.Currency)
+		}
+	})
+
+	t.Run("When calculating fare while trip is short returns minimum fare", func(t *testing.T) {
+		events := []*types.TripEvent{
+			{TripID: tripId, Type: enums.StartTrip, Location: types.Location{Latitude: 54.0, Longitude: 25.0}, CreatedAt: startedAt, Sequence: 1},
+			{TripID: tripId, Type: enums.EndTrip, Location: types.Location{Latitude: 54.0, Longitude: 25.0}, CreatedAt: startedAt.Add(30 * time.Second), Sequence: 2},
+		}
+
+		fare := calculator.CalculateFare(events)
+		if fare.Amount != 150 {
+			t.Errorf("expected amount to be 150, got %d", fare.Amount)
+		}
+	})
+	t.Run("When calculating fare while trip was paused charges paused minutes at paused rate", func(t *testing.T) {
+		events := []*types.TripEvent{
+			{TripID: tripId, Type: enums.StartTrip, Location: types.Location{Latitude: 54.0, Longitude: 25.0}, CreatedAt: startedAt, Sequence: 1},
+			{TripID: tripId, Type: enums.PauseTrip, Location: types.Location{Latitude: 54.0, Longitude: 25.0}, CreatedAt: startedAt.Add(4 * time.Minute), Sequence: 2},
+			{TripID: tripId, Type: enums.ResumeTrip, Location: types.Location{Latitude: 54.0, Longitude: 25.0}, CreatedAt: startedAt.Add(14 * time.Minute), Sequence: 3},
+			{TripID: tripId, Type: enums.EndTrip, Location: types.Location{Latitude: 54.0, Longitude: 25.0}, CreatedAt: startedAt.Add(20 * time.Minute), Sequence: 4},
+		}
+
+		fare := calculator.CalculateFare(events)
+
+		if fare.DurationSeconds != 1200 {
+			t.Errorf("expected duration to be 1200 seconds, got %d", fare.DurationSeconds)
+		}
+
+		if fare.PausedSeconds != 600 {
+			t.Errorf("expected paused duration to be 600 seconds, got %d", fare.PausedSeconds)
+		}
+
+		// 100 unlock + 10 riding minutes * 25 + 10 paused minutes * 10
+		if fare.Amount != 450 {
+			t.Errorf("expected amount to be 450, got %d", fare.Amount)
+		}
+	})
+
+	t.Run("When calculating fare while trip ends paused charges until the end at paused rate", func(t *testing.T) {
+		events := []*types.TripEvent{
+			{TripID: tripId, Type: enums.StartTrip, Location: types.Location{Latitude: 54.0, Longitude: 25.0}, CreatedAt: startedAt, Sequence: 1},
+			{TripID: tripId, Type: enums.PauseTrip, Location: types.Location{Latitude: 54.0, Longitude: 25.0}, CreatedAt: startedAt.Add(10 * time.Minute), Sequence: 2},
+			{TripID: tripId, Type: enums.EndTrip, Location: types.Location{Latitude: 54.0, Longitude: 25.0}, CreatedAt: startedAt.Add(15 * time.Minute), Sequence: 3},
+		}
+
+		fare := calculator.CalculateFare(events)
+
+		// 100 unlock + 10 riding minutes * 25 + 5 paused minutes * 10
+		if fare.Amount != 400 {
+			t.Errorf("expected amount to be 400, got %d", fare.Amount)
+		}
+	})
+}
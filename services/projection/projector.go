@@ -0,0 +1,190 @@
+package projection
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+const locationTolerance = 1e-5
+
+// Project replays trip events to rebuild trip, scooter and user state.
+// Scooters are only projected if they took part in at least one trip, since nothing else is event sourced.
+// Their location is only projected while they are in a trip, otherwise telemetry and operators may have moved them since.
+// Suspended users stay locked regardless of their trips, and so do scooters which are locked in a status trips do not set,
+// unless they are still in a trip.
+func Project(trips []*types.Trip, events []*types.TripEvent, suspendedUserIds []uuid.UUID, lockedScooterStatuses map[uuid.UUID]enums.ScooterStatus) *types.Projection {
+	eventsByTrip := groupEventsByTrip(events)
+
+	projection := &types.Projection{
+		Trips:            make(map[uuid.UUID]*types.Trip),
+		Scooters:         make(map[uuid.UUID]*types.Scooter),
+		ScooterLocations: make(map[uuid.UUID]types.Location),
+		Users:            make(map[uuid.UUID]*types.MobileClient),
+	}
+
+	projectedTrips := make([]*types.Trip, 0, len(trips))
+	for _, trip := range trips {
+		tripEvents := eventsByTrip[trip.ID]
+		if len(tripEvents) == 0 {
+			continue
+		}
+
+		projectedTrip := *trip
+		projectedTrip.IsFinished = tripEvents[len(tripEvents)-1].EndsTrip()
+		projection.Trips[trip.ID] = &projectedTrip
+		projectedTrips = append(projectedTrips, &projectedTrip)
+	}
+
+	// Trips are applied in the order the server received their start, so the last one defines where the scooter is.
+	// Clocks of the riders' phones can not be trusted to agree with each other.
+	sort.Slice(projectedTrips, func(i, j int) bool {
+		startedAt := eventsByTrip[projectedTrips[i].ID][0].ReceivedAt
+		otherStartedAt := eventsByTrip[projectedTrips[j].ID][0].ReceivedAt
+		if !startedAt.Equal(otherStartedAt) {
+			return startedAt.Before(otherStartedAt)
+		}
+		return projectedTrips[i].ID.String() < projectedTrips[j].ID.String()
+	})
+
+	for _, trip := range projectedTrips {
+		tripEvents := eventsByTrip[trip.ID]
+		lastEvent := tripEvents[len(tripEvents)-1]
+
+		scooter, ok := projection.Scooters[trip.ScooterId]
+		if !ok {
+			scooter = &types.Scooter{ID: trip.ScooterId, Status: enums.AvailableScooter}
+			projection.Scooters[trip.ScooterId] = scooter
+		}
+		if !trip.IsFinished {
+			scooter.Status = enums.InTripScooter
+			projection.ScooterLocations[trip.ScooterId] = lastEvent.Location
+		}
+
+		user, ok := projection.Users[trip.ClientId]
+		if !ok {
+			user = &types.MobileClient{ID: trip.ClientId, IsEligibleToTravel: true}
+			projection.Users[trip.ClientId] = user
+		}
+		user.IsEligibleToTravel = user.IsEligibleToTravel && trip.IsFinished
+	}
+
+	for _, userId := range suspendedUserIds {
+		projection.Users[userId] = &types.MobileClient{ID: userId, IsEligibleToTravel: false}
+	}
+
+	for scooterId, status := range lockedScooterStatuses {
+		if scooter, ok := projection.Scooters[scooterId]; ok && scooter.Status == enums.AvailableScooter {
+			scooter.Status = status
+		}
+	}
+
+	return projection
+}
+
+// FindDrift lists every projected field that differs from the stored rows.
+func FindDrift(projected *types.Projection, stored *types.Projection) []types.ProjectionDrift {
+	drift := make([]types.ProjectionDrift, 0)
+
+	for id, trip := range projected.Trips {
+		storedTrip, ok := stored.Trips[id]
+		if !ok {
+			drift = append(drift, missingRow("trip", id))
+			continue
+		}
+
+		if storedTrip.IsFinished != trip.IsFinished {
+			drift = append(drift, boolDrift("trip", id, "is_finished", storedTrip.IsFinished, trip.IsFinished))
+		}
+	}
+
+	for id, scooter := range projected.Scooters {
+		storedScooter, ok := stored.Scooters[id]
+		if !ok {
+			drift = append(drift, missingRow("scooter", id))
+			continue
+		}
+
+		if storedScooter.Status != scooter.Status {
+			drift = append(drift, stringDrift("scooter", id, "status", string(storedScooter.Status), string(scooter.Status)))
+		}
+
+		location, ok := projected.ScooterLocations[id]
+		if !ok {
+			continue
+		}
+
+		if math.Abs(storedScooter.Location.Latitude-location.Latitude) > locationTolerance {
+			drift = append(drift, floatDrift("scooter", id, "latitude", storedScooter.Location.Latitude, location.Latitude))
+		}
+
+		if math.Abs(storedScooter.Location.Longitude-location.Longitude) > locationTolerance {
+			drift = append(drift, floatDrift("scooter", id, "longitude", storedScooter.Location.Longitude, location.Longitude))
+		}
+	}
+
+	for id, user := range projected.Users {
+		storedUser, ok := stored.Users[id]
+		if !ok {
+			drift = append(drift, missingRow("user", id))
+			continue
+		}
+
+		if storedUser.IsEligibleToTravel != user.IsEligibleToTravel {
+			drift = append(drift, boolDrift("user", id, "is_eligible_to_travel", storedUser.IsEligibleToTravel, user.IsEligibleToTravel))
+		}
+	}
+
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Entity != drift[j].Entity {
+			return drift[i].Entity < drift[j].Entity
+		}
+		if drift[i].ID != drift[j].ID {
+			return drift[i].ID.String() < drift[j].ID.String()
+		}
+		return drift[i].Field < drift[j].Field
+	})
+
+	return drift
+}
+
+func groupEventsByTrip(events []*types.TripEvent) map[uuid.UUID][]*types.TripEvent {
+	eventsByTrip := make(map[uuid.UUID][]*types.TripEvent)
+	for _, event := range events {
+		eventsByTrip[event.TripID] = append(eventsByTrip[event.TripID], event)
+	}
+
+	for _, tripEvents := range eventsByTrip {
+		sort.SliceStable(tripEvents, func(i, j int) bool {
+			return tripEvents[i].Sequence < tripEvents[j].Sequence
+		})
+	}
+
+	return eventsByTrip
+}
+
+func missingRow(entity string, id uuid.UUID) types.ProjectionDrift {
+	return types.ProjectionDrift{Entity: entity, ID: id, Field: "id", Stored: "missing", Projected: id.String()}
+}
+
+func boolDrift(entity string, id uuid.UUID, field string, stored bool, projected bool) types.ProjectionDrift {
+	return types.ProjectionDrift{Entity: entity, ID: id, Field: field, Stored: strconv.FormatBool(stored), Projected: strconv.FormatBool(projected)}
+}
+
+func stringDrift(entity string, id uuid.UUID, field string, stored string, projected string) types.ProjectionDrift {
+	return types.ProjectionDrift{Entity: entity, ID: id, Field: field, Stored: stored, Projected: projected}
+}
+
+func floatDrift(entity string, id uuid.UUID, field string, stored float64, projected float64) types.ProjectionDrift {
+	return types.ProjectionDrift{
+		Entity:    entity,
+		ID:        id,
+		Field:     field,
+		Stored:    strconv.FormatFloat(stored, 'f', -1, 64),
+		Projected: strconv.FormatFloat(projected, 'f', -1, 64),
+	}
+}
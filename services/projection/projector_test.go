@@ -0,0 +1,205 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestProjector(t *testing.T) {
+	scooterId := uuid.New()
+	userId := uuid.New()
+	finishedTrip := &types.Trip{ID: uuid.New(), ScooterId: scooterId, ClientId: userId}
+	activeTrip := &types.Trip{ID: uuid.New(), ScooterId: scooterId, ClientId: userId}
+	startedAt := time.Date(2024, 4, 26, 17, 0, 0, 0, time.UTC)
+
+	events := []*types.TripEvent{
+		{TripID: activeTrip.ID, Type: enums.UpdateTrip, Location: types.Location{Latitude: 54.5, Longitude: 25.5}, CreatedAt: startedAt.Add(time.Hour + time.Minute), ReceivedAt: startedAt.Add(time.Hour + time.Minute), Sequence: 2},
+		{TripID: finishedTrip.ID, Type: enums.EndTrip, Location: types.Location{Latitude: 54.2, Longitude: 25.2}, CreatedAt: startedAt.Add(time.Minute), ReceivedAt: startedAt.Add(time.Minute), Sequence: 2},
+		{TripID: activeTrip.ID, Type: enums.StartTrip, Location: types.Location{Latitude: 54.2, Longitude: 25.2}, CreatedAt: startedAt.Add(time.Hour), ReceivedAt: startedAt.Add(time.Hour), Sequence: 1},
+		{TripID: finishedTrip.ID, Type: enums.StartTrip, Location: types.Location{Latitude: 54.1, Longitude: 25.1}, CreatedAt: startedAt, ReceivedAt: startedAt, Sequence: 1},
+	}
+
+	t.Run("When projecting events while a trip is still active returns scooter and user locked", func(t *testing.T) {
+		projection := Project([]*types.Trip{activeTrip, finishedTrip}, events, nil, nil)
+
+		if !projection.Trips[finishedTrip.ID].IsFinished {
+			t.Errorf("expected trip %s to be finished", finishedTrip.ID.String())
+		}
+
+		if projection.Trips[activeTrip.ID].IsFinished {
+			t.Errorf("expected trip %s not to be finished", activeTrip.ID.String())
+		}
+
+		scooter := projection.Scooters[scooterId]
+		if scooter.Status != enums.InTripScooter {
+			t.Errorf("expected scooter to be in trip, got %s", scooter.Status)
+		}
+
+		location, ok := projection.ScooterLocations[scooterId]
+		if !ok || location.Latitude != 54.5 || location.Longitude != 25.5 {
+			t.Errorf("expected scooter location to be 54.5, 25.5, got %+v", location)
+		}
+
+		if projection.Users[userId].IsEligibleToTravel {
+			t.Errorf("expected user not to be eligible to travel")
+		}
+	})
+
+	t.Run("When projecting events while every trip is finished returns scooter and user released", func(t *testing.T) {
+		projection := Project([]*types.Trip{finishedTrip}, []*types.TripEvent{events[1], events[3]}, nil, nil)
+
+		if projection.Scooters[scooterId].Status != enums.AvailableScooter {
+			t.Errorf("expected scooter to be available, got %s", projection.Scooters[scooterId].Status)
+		}
+
+		if !projection.Users[userId].IsEligibleToTravel {
+			t.Errorf("expected user to be eligible to travel")
+		}
+	})
+
+	t.Run("When projecting events while user is suspended returns user not eligible to travel", func(t *testing.T) {
+		suspendedUserId := uuid.New()
+		projection := Project([]*types.Trip{finishedTrip}, []*types.TripEvent{events[1], events[3]}, []uuid.UUID{userId, suspendedUserId}, nil)
+
+		if projection.Users[userId].IsEligibleToTravel {
+			t.Errorf("expected user with finished trips not to be eligible to travel")
+		}
+
+		if projection.Users[suspendedUserId].IsEligibleToTravel {
+			t.Errorf("expected user without trips not to be eligible to travel")
+		}
+	})
+
+	t.Run("When projecting events while scooter is retired returns scooter retired", func(t *testing.T) {
+		lockedScooterStatuses := map[uuid.UUID]enums.ScooterStatus{scooterId: enums.RetiredScooter}
+		projection := Project([]*types.Trip{finishedTrip}, []*types.TripEvent{events[1], events[3]}, nil, lockedScooterStatuses)
+
+		if projection.Scooters[scooterId].Status != enums.RetiredScooter {
+			t.Errorf("expected scooter to be retired, got %s", projection.Scooters[scooterId].Status)
+		}
+	})
+
+	t.Run("When projecting events while charging scooter is still in a trip returns scooter in trip", func(t *testing.T) {
+		lockedScooterStatuses := map[uuid.UUID]enums.ScooterStatus{scooterId: enums.ChargingScooter}
+		projection := Project([]*types.Trip{activeTrip, finishedTrip}, events, nil, lockedScooterStatuses)
+
+		if projection.Scooters[scooterId].Status != enums.InTripScooter {
+			t.Errorf("expected scooter to be in trip, got %s", projection.Scooters[scooterId].Status)
+		}
+	})
+
+	t.Run("When projecting events while every trip is finished returns no scooter location", func(t *testing.T) {
+		projection := Project([]*types.Trip{finishedTrip}, []*types.TripEvent{events[1], events[3]}, nil, nil)
+
+		if location, ok := projection.ScooterLocations[scooterId]; ok {
+			t.Errorf("expected scooter location not to be projected, got %+v", location)
+		}
+	})
+
+	t.Run("When projecting events while the rider's phone clock is behind returns location of the trip received last", func(t *testing.T) {
+		lateTrip := &types.Trip{ID: uuid.New(), ScooterId: scooterId, ClientId: userId}
+		lateStart := &types.TripEvent{TripID: lateTrip.ID, Type: enums.StartTrip, Location: types.Location{Latitude: 54.3, Longitude: 25.3}, CreatedAt: startedAt, ReceivedAt: startedAt.Add(2 * time.Hour), Sequence: 1}
+
+		projection := Project([]*types.Trip{lateTrip, activeTrip}, []*types.TripEvent{lateStart, events[0], events[2]}, nil, nil)
+
+		location, ok := projection.ScooterLocations[scooterId]
+		if !ok || location.Latitude != 54.3 || location.Longitude != 25.3 {
+			t.Errorf("expected scooter location to be 54.3, 25.3, got %+v", location)
+		}
+	})
+
+	t.Run("When finding drift while scooter was moved by telemetry after its trip returns no drift", func(t *testing.T) {
+		projection := Project([]*types.Trip{finishedTrip}, []*types.TripEvent{events[1], events[3]}, nil, nil)
+		stored := &types.Projection{
+			Trips: map[uuid.UUID]*types.Trip{
+				finishedTrip.ID: {ID: finishedTrip.ID, IsFinished: true},
+			},
+			Scooters: map[uuid.UUID]*types.Scooter{
+				scooterId: {ID: scooterId, Location: types.Location{Latitude: 54.6872, Longitude: 25.2797}, Status: enums.AvailableScooter},
+			},
+			Users: map[uuid.UUID]*types.MobileClient{
+				userId: {ID: userId, IsEligibleToTravel: true},
+			},
+		}
+
+		drift := FindDrift(projection, stored)
+		if len(drift) != 0 {
+			t.Errorf("expected no drift, got %+v", drift)
+		}
+	})
+
+	t.Run("When finding drift while scooter was relocated by an operator after its trip returns no drift", func(t *testing.T) {
+		lockedScooterStatuses := map[uuid.UUID]enums.ScooterStatus{scooterId: enums.ChargingScooter}
+		projection := Project([]*types.Trip{finishedTrip}, []*types.TripEvent{events[1], events[3]}, nil, lockedScooterStatuses)
+		stored := &types.Projection{
+			Trips: map[uuid.UUID]*types.Trip{
+				finishedTrip.ID: {ID: finishedTrip.ID, IsFinished: true},
+			},
+			Scooters: map[uuid.UUID]*types.Scooter{
+				scooterId: {ID: scooterId, Location: types.Location{Latitude: 54.9, Longitude: 23.9}, Status: enums.ChargingScooter},
+			},
+			Users: map[uuid.UUID]*types.MobileClient{
+				userId: {ID: userId, IsEligibleToTravel: true},
+			},
+		}
+
+		drift := FindDrift(projection, stored)
+		if len(drift) != 0 {
+			t.Errorf("expected no drift, got %+v", drift)
+		}
+	})
+
+	t.Run("When finding drift while scooter in a trip is elsewhere returns location drift", func(t *testing.T) {
+		projection := Project([]*types.Trip{activeTrip, finishedTrip}, events, nil, nil)
+		stored := &types.Projection{
+			Trips: map[uuid.UUID]*types.Trip{
+				activeTrip.ID:   {ID: activeTrip.ID, IsFinished: false},
+				finishedTrip.ID: {ID: finishedTrip.ID, IsFinished: true},
+			},
+			Scooters: map[uuid.UUID]*types.Scooter{
+				scooterId: {ID: scooterId, Location: types.Location{Latitude: 54.2, Longitude: 25.5}, Status: enums.InTripScooter},
+			},
+			Users: map[uuid.UUID]*types.MobileClient{
+				userId: {ID: userId, IsEligibleToTravel: false},
+			},
+		}
+
+		drift := FindDrift(projection, stored)
+		if len(drift) != 1 || drift[0].Field != "latitude" || drift[0].Projected != "54.5" {
+			t.Errorf("expected scooter latitude drift, got %+v", drift)
+		}
+	})
+
+	t.Run("When finding drift while stored rows differ returns every differing field", func(t *testing.T) {
+		projection := Project([]*types.Trip{activeTrip, finishedTrip}, events, nil, nil)
+		stored := &types.Projection{
+			Trips: map[uuid.UUID]*types.Trip{
+				activeTrip.ID:   {ID: activeTrip.ID, IsFinished: true},
+				finishedTrip.ID: {ID: finishedTrip.ID, IsFinished: true},
+			},
+			Scooters: map[uuid.UUID]*types.Scooter{
+				scooterId: {ID: scooterId, Location: types.Location{Latitude: 54.5, Longitude: 25.5}, Status: enums.AvailableScooter},
+			},
+			Users: map[uuid.UUID]*types.MobileClient{
+				userId: {ID: userId, IsEligibleToTravel: false},
+			},
+		}
+
+		drift := FindDrift(projection, stored)
+		if len(drift) != 2 {
+			t.Fatalf("expected 2 drifts, got %d", len(drift))
+		}
+
+		if drift[0].Entity != "scooter" || drift[0].Field != "status" || drift[0].Stored != "available" || drift[0].Projected != "in_trip" {
+			t.Errorf("expected scooter status drift, got %+v", drift[0])
+		}
+
+		if drift[1].Entity != "trip" || drift[1].ID != activeTrip.ID || drift[1].Field != "is_finished" {
+			t.Errorf("expected trip is_finished drift, got %+v", drift[1])
+		}
+	})
+}
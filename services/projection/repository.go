@@ -0,0 +1,216 @@
+package projection
+
+import (
+	"database/sql"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+type ProjectionRepository struct {
+	db                  *sql.DB
+	lowBatteryThreshold int
+}
+
+func NewRepository(db *sql.DB, lowBatteryThreshold int) *ProjectionRepository {
+	return &ProjectionRepository{db: db, lowBatteryThreshold: lowBatteryThreshold}
+}
+
+func (r *ProjectionRepository) GetTrips() ([]*types.Trip, error) {
+	rows, err := r.db.Query("SELECT id, user_id, scooter_id, is_finished FROM trips")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trips := make([]*types.Trip, 0)
+	for rows.Next() {
+		var trip types.Trip
+		if err := rows.Scan(&trip.ID, &trip.ClientId, &trip.ScooterId, &trip.IsFinished); err != nil {
+			return nil, err
+		}
+
+		trips = append(trips, &trip)
+	}
+
+	return trips, rows.Err()
+}
+
+func (r *ProjectionRepository) GetEvents() ([]*types.TripEvent, error) {
+	rows, err := r.db.Query("SELECT trip_id, event_type, latitude, longitude, created_at, received_at, sequence FROM events ORDER BY trip_id, sequence")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*types.TripEvent, 0)
+	for rows.Next() {
+		var event types.TripEvent
+		if err := rows.Scan(&event.TripID, &event.Type, &event.Location.Latitude, &event.Location.Longitude, &event.CreatedAt, &event.ReceivedAt, &event.Sequence); err != nil {
+			return nil, err
+		}
+
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+func (r *ProjectionRepository) GetSuspendedUserIds() ([]uuid.UUID, error) {
+	return r.queryIds("SELECT id FROM users WHERE suspension_reason IS NOT NULL")
+}
+
+var lockedScooterStatuses = []enums.ScooterStatus{enums.ReservedScooter, enums.MaintenanceScooter, enums.ChargingScooter, enums.RetiredScooter}
+
+// GetLockedScooterStatuses returns the statuses which are not event sourced: reserved, in maintenance, charging or retired.
+// Other scooters are reported in the status utils.IdleScooterStatus leaves them in once their trip ends, unless they are available.
+func (r *ProjectionRepository) GetLockedScooterStatuses() (map[uuid.UUID]enums.ScooterStatus, error) {
+	rows, err := r.db.Query("SELECT id, status, battery_level FROM scooters")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := make(map[uuid.UUID]enums.ScooterStatus)
+	for rows.Next() {
+		var id uuid.UUID
+		var status enums.ScooterStatus
+		var batteryLevel int
+		if err := rows.Scan(&id, &status, &batteryLevel); err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(lockedScooterStatuses, status) {
+			statuses[id] = status
+			continue
+		}
+
+		if idleStatus := utils.IdleScooterStatus(batteryLevel, r.lowBatteryThreshold); idleStatus != enums.AvailableScooter {
+			statuses[id] = idleStatus
+		}
+	}
+
+	return statuses, rows.Err()
+}
+
+func (r *ProjectionRepository) GetStoredState() (*types.Projection, error) {
+	trips, err := r.GetTrips()
+	if err != nil {
+		return nil, err
+	}
+
+	state := &types.Projection{
+		Trips:    make(map[uuid.UUID]*types.Trip),
+		Scooters: make(map[uuid.UUID]*types.Scooter),
+		Users:    make(map[uuid.UUID]*types.MobileClient),
+	}
+
+	for _, trip := range trips {
+		state.Trips[trip.ID] = trip
+	}
+
+	scooterRows, err := r.db.Query("SELECT id, latitude, longitude, status FROM scooters")
+	if err != nil {
+		return nil, err
+	}
+	defer scooterRows.Close()
+
+	for scooterRows.Next() {
+		var scooter types.Scooter
+		if err := scooterRows.Scan(&scooter.ID, &scooter.Location.Latitude, &scooter.Location.Longitude, &scooter.Status); err != nil {
+			return nil, err
+		}
+
+		state.Scooters[scooter.ID] = &scooter
+	}
+
+	userRows, err := r.db.Query("SELECT id, full_name, is_eligible_to_travel FROM users")
+	if err != nil {
+		return nil, err
+	}
+	defer userRows.Close()
+
+	for userRows.Next() {
+		var user types.MobileClient
+		if err := userRows.Scan(&user.ID, &user.FullName, &user.IsEligibleToTravel); err != nil {
+			return nil, err
+		}
+
+		state.Users[user.ID] = &user
+	}
+
+	return state, nil
+}
+
+// SaveProjection overwrites projected columns in a single transaction.
+// Lock versions are bumped so that requests which read the rows before the rebuild are rejected.
+func (r *ProjectionRepository) SaveProjection(projection *types.Projection) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, trip := range projection.Trips {
+		_, err = tx.Exec("UPDATE trips SET is_finished = ? WHERE id = UUID_TO_BIN(?, false)", trip.IsFinished, trip.ID.String())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, scooter := range projection.Scooters {
+		_, err = tx.Exec("UPDATE scooters SET status = ?, opt_lock_version = opt_lock_version + 1 WHERE id = UUID_TO_BIN(?, false)",
+			scooter.Status, scooter.ID.String())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for scooterId, location := range projection.ScooterLocations {
+		_, err = tx.Exec("UPDATE scooters SET latitude = ?, longitude = ? WHERE id = UUID_TO_BIN(?, false)",
+			location.Latitude, location.Longitude, scooterId.String())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, user := range projection.Users {
+		_, err = tx.Exec("UPDATE users SET is_eligible_to_travel = ?, opt_lock_version = opt_lock_version + 1 WHERE id = UUID_TO_BIN(?, false)",
+			user.IsEligibleToTravel, user.ID.String())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ProjectionRepository) queryIds(query string, args ...any) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
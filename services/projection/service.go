@@ -0,0 +1,65 @@
+package projection
+
+import (
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+)
+
+type ProjectionService struct {
+	repository interfaces.ProjectionRepository
+}
+
+func NewProjectionService(repository interfaces.ProjectionRepository) *ProjectionService {
+	return &ProjectionService{repository: repository}
+}
+
+func (s *ProjectionService) BuildProjection() (*types.Projection, error) {
+	trips, err := s.repository.GetTrips()
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repository.GetEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	suspendedUserIds, err := s.repository.GetSuspendedUserIds()
+	if err != nil {
+		return nil, err
+	}
+
+	lockedScooterStatuses, err := s.repository.GetLockedScooterStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	return Project(trips, events, suspendedUserIds, lockedScooterStatuses), nil
+}
+
+func (s *ProjectionService) Rebuild() (*types.Projection, error) {
+	projection, err := s.BuildProjection()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.SaveProjection(projection); err != nil {
+		return nil, err
+	}
+
+	return projection, nil
+}
+
+func (s *ProjectionService) CheckConsistency() ([]types.ProjectionDrift, error) {
+	projection, err := s.BuildProjection()
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.repository.GetStoredState()
+	if err != nil {
+		return nil, err
+	}
+
+	return FindDrift(projection, stored), nil
+}
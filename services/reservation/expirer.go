@@ -0,0 +1,68 @@
+package reservation
+
+import (
+	"log"
+	"time"
+
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+)
+
+// ReservationExpirer periodically releases scooters held by reservations which were not consumed in time.
+type ReservationExpirer struct {
+	reservationsRepository interfaces.ReservationRepository
+	scootersRepository     interfaces.ScooterRepository
+	interval               time.Duration
+	now                    func() time.Time
+}
+
+func NewReservationExpirer(
+	reservationsRepository interfaces.ReservationRepository,
+	scootersRepository interfaces.ScooterRepository,
+	interval time.Duration) *ReservationExpirer {
+	return &ReservationExpirer{
+		reservationsRepository: reservationsRepository,
+		scootersRepository:     scootersRepository,
+		interval:               interval,
+		now:                    time.Now,
+	}
+}
+
+func (e *ReservationExpirer) Run() {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		e.ExpireReservations()
+	}
+}
+
+// ExpireReservations gives up on a reservation whenever it races with a trip start or an admin,
+// it is picked up again on the next run if it is still active.
+func (e *ReservationExpirer) ExpireReservations() {
+	now := e.now()
+	reservations, err := e.reservationsRepository.GetExpiredReservations(now)
+	if err != nil {
+		log.Println("Error getting expired reservations:", err)
+		return
+	}
+
+	for _, reservation := range reservations {
+		_, scooterOptLockVersion, err := e.scootersRepository.GetScooterById(reservation.ScooterId.String())
+		if err != nil {
+			log.Println("Error getting reserved scooter:", err)
+			continue
+		}
+
+		event := types.ReservationEvent{
+			ReservationID: reservation.ID,
+			Type:          enums.ExpireReservation,
+			CreatedAt:     now.UTC().Truncate(time.Second),
+		}
+
+		if err := e.reservationsRepository.ExpireReservation(reservation, scooterOptLockVersion, event); err != nil {
+			log.Println("Error expiring reservation", reservation.ID.String()+":", err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestReservationExpirer(t *testing.T) {
+	setup := func() (*db.InMemoryStorage, *ReservationExpirer, types.Reservation) {
+		storage := db.NewInMemoryStorage()
+		reservedScooter := types.Scooter{ID: uuid.New(), Location: types.Location{Latitude: 54.68, Longitude: 25.27}, Status: enums.AvailableScooter}
+		storage.Scooters[reservedScooter.ID.String()] = &db.VersionedRecord[types.Scooter]{Value: reservedScooter}
+
+		reservationsRepository := NewInMemoryRepository(storage)
+		now := time.Now().UTC().Truncate(time.Second)
+		reservation := types.Reservation{
+			ID:        uuid.New(),
+			ScooterId: reservedScooter.ID,
+			ClientId:  uuid.New(),
+			Status:    enums.ActiveReservation,
+			ExpiresAt: now.Add(10 * time.Minute),
+			CreatedAt: now,
+		}
+
+		event := types.ReservationEvent{ReservationID: reservation.ID, Type: enums.CreateReservation, CreatedAt: now}
+		if err := reservationsRepository.CreateReservation(reservation, new(int), event); err != nil {
+			t.Fatal(err)
+		}
+
+		expirer := NewReservationExpirer(reservationsRepository, scooter.NewInMemoryRepository(storage), time.Minute)
+		return storage, expirer, reservation
+	}
+
+	t.Run("When expiring reservations while reservation window has passed releases scooter", func(t *testing.T) {
+		storage, expirer, reservation := setup()
+		expirer.now = func() time.Time { return reservation.ExpiresAt.Add(time.Second) }
+
+		expirer.ExpireReservations()
+
+		if storage.Reservations[reservation.ID.String()].Status != enums.ExpiredReservation {
+			t.Errorf("expected reservation to be expired, got %s", storage.Reservations[reservation.ID.String()].Status)
+		}
+
+		if storage.Scooters[reservation.ScooterId.String()].Value.Status != enums.AvailableScooter {
+			t.Errorf("expected scooter to be available")
+		}
+
+		lastEvent := storage.ReservationEvents[len(storage.ReservationEvents)-1]
+		if lastEvent.Type != enums.ExpireReservation {
+			t.Errorf("expected last event to be %s, got %s", enums.ExpireReservation, lastEvent.Type)
+		}
+	})
+
+	t.Run("When expiring reservations while reservation window has not passed keeps scooter held", func(t *testing.T) {
+		storage, expirer, reservation := setup()
+		expirer.now = func() time.Time { return reservation.ExpiresAt.Add(-time.Second) }
+
+		expirer.ExpireReservations()
+
+		if storage.Reservations[reservation.ID.String()].Status != enums.ActiveReservation {
+			t.Errorf("expected reservation to stay active, got %s", storage.Reservations[reservation.ID.String()].Status)
+		}
+
+		if storage.Scooters[reservation.ScooterId.String()].Value.Status != enums.ReservedScooter {
+			t.Errorf("expected scooter to stay reserved")
+		}
+	})
+
+	t.Run("When expiring reservations while scooter left reserved status in the meantime keeps its status", func(t *testing.T) {
+		storage, expirer, reservation := setup()
+		storage.Scooters[reservation.ScooterId.String()].Value.Status = enums.MaintenanceScooter
+		expirer.now = func() time.Time { return reservation.ExpiresAt.Add(time.Second) }
+
+		expirer.ExpireReservations()
+
+		if storage.Scooters[reservation.ScooterId.String()].Value.Status != enums.MaintenanceScooter {
+			t.Errorf("expected scooter to stay in maintenance")
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package reservation
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+type ReservationHandler struct {
+	validator              interfaces.ReservationValidator
+	reservationsRepository interfaces.ReservationRepository
+	scootersRepository     interfaces.ScooterRepository
+	usersRepository        interfaces.ClientRepository
+	window                 time.Duration
+}
+
+func NewReservationHandler(
+	validator interfaces.ReservationValidator,
+	reservationsRepository interfaces.ReservationRepository,
+	scootersRepository interfaces.ScooterRepository,
+	usersRepository interfaces.ClientRepository,
+	window time.Duration) *ReservationHandler {
+	return &ReservationHandler{
+		validator:              validator,
+		reservationsRepository: reservationsRepository,
+		scootersRepository:     scootersRepository,
+		usersRepository:        usersRepository,
+		window:                 window,
+	}
+}
+
+func (h *ReservationHandler) RegisterEndpoints(routes interfaces.Routes) {
+	routes.IdempotentClient().POST("/reservations", h.createReservation)
+}
+
+func (h *ReservationHandler) createReservation(c *gin.Context) {
+	clientId := c.GetString(utils.ClientIdKey)
+	if clientId == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "missing client token"})
+		return
+	}
+
+	var request types.CreateReservationRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateCreateReservationRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	scooter, scooterOptLockVersion, err := h.scootersRepository.GetScooterById(request.ScooterID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	user, _, err := h.usersRepository.GetUserById(clientId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting user by id"})
+		return
+	}
+
+	if scooter.Status != enums.AvailableScooter {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": "scooter is not available"})
+		return
+	}
+
+	// Riders in a trip are not eligible either, so they can not hold a scooter while riding another one
+	if !user.IsEligibleToTravel {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": "user is not eligible to travel"})
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	reservation := types.Reservation{
+		ID:        uuid.New(),
+		ScooterId: scooter.ID,
+		ClientId:  user.ID,
+		Status:    enums.ActiveReservation,
+		ExpiresAt: now.Add(h.window),
+		CreatedAt: now,
+	}
+
+	event := types.ReservationEvent{
+		ReservationID: reservation.ID,
+		Type:          enums.CreateReservation,
+		CreatedAt:     now,
+	}
+
+	err = h.reservationsRepository.CreateReservation(reservation, scooterOptLockVersion, event)
+	if errors.Is(err, ErrActiveReservationExists) || errors.Is(err, ErrConcurrentUpdate) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "reservation could not be created"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, reservation)
+}
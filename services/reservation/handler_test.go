@@ -0,0 +1,354 @@
+package reservation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+func TestReservationHandler(t *testing.T) {
+	validator := &mockReservationValidator{}
+	reservationRepository := &mockReservationRepository{}
+	scooterRepository := &mockScooterRepository{}
+	userRepository := &mockClientRepository{}
+
+	handler := NewReservationHandler(validator, reservationRepository, scooterRepository, userRepository, 10*time.Minute)
+
+	t.Run("When creating reservation while everything is valid returns created", func(t *testing.T) {
+		scooterId := uuid.New()
+		marshalledRequestBody, _ := json.Marshal(types.CreateReservationRequest{ScooterID: scooterId})
+		request, err := http.NewRequest(http.MethodPost, "/client/reservations", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request.Header.Set("client-id", uuid.New().String())
+
+		router := newClientRouter()
+		router.POST("/client/reservations", handler.createReservation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+
+		var response types.Reservation
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.ScooterId != scooterId {
+			t.Errorf("expected scooter id to be %s, got %s", scooterId.String(), response.ScooterId.String())
+		}
+
+		if response.Status != enums.ActiveReservation {
+			t.Errorf("expected status to be %s, got %s", enums.ActiveReservation, response.Status)
+		}
+
+		if response.ExpiresAt.Sub(response.CreatedAt) != 10*time.Minute {
+			t.Errorf("expected reservation to be held for 10 minutes, got %s", response.ExpiresAt.Sub(response.CreatedAt))
+		}
+	})
+
+	t.Run("When creating reservation while client id is not set returns unauthorized", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CreateReservationRequest{ScooterID: uuid.New()})
+		request, err := http.NewRequest(http.MethodPost, "/client/reservations", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := newClientRouter()
+		router.POST("/client/reservations", handler.createReservation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When creating reservation while request body content is not valid returns bad request", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CreateReservationRequest{ScooterID: uuid.Nil})
+		request, err := http.NewRequest(http.MethodPost, "/client/reservations", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request.Header.Set("client-id", uuid.New().String())
+
+		router := newClientRouter()
+		router.POST("/client/reservations", handler.createReservation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When creating reservation while scooter is not existant returns not found", func(t *testing.T) {
+		id, _ := uuid.Parse("03de5edd-e9d7-4c4e-1111-0ff9c07b6a37")
+		marshalledRequestBody, _ := json.Marshal(types.CreateReservationRequest{ScooterID: id})
+		request, err := http.NewRequest(http.MethodPost, "/client/reservations", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request.Header.Set("client-id", uuid.New().String())
+
+		router := newClientRouter()
+		router.POST("/client/reservations", handler.createReservation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When creating reservation while scooter is not available returns bad request", func(t *testing.T) {
+		id, _ := uuid.Parse("03de5edd-e9d7-4c4e-2222-0ff9c07b6a37")
+		marshalledRequestBody, _ := json.Marshal(types.CreateReservationRequest{ScooterID: id})
+		request, err := http.NewRequest(http.MethodPost, "/client/reservations", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request.Header.Set("client-id", uuid.New().String())
+
+		router := newClientRouter()
+		router.POST("/client/reservations", handler.createReservation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When creating reservation while user is not eligible to travel returns bad request", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CreateReservationRequest{ScooterID: uuid.New()})
+		request, err := http.NewRequest(http.MethodPost, "/client/reservations", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request.Header.Set("client-id", "bec6a2fb-896f-473e-3333-a4208d033498")
+
+		router := newClientRouter()
+		router.POST("/client/reservations", handler.createReservation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When creating reservation while user already holds a reservation returns conflict", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CreateReservationRequest{ScooterID: uuid.New()})
+		request, err := http.NewRequest(http.MethodPost, "/client/reservations", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request.Header.Set("client-id", "bec6a2fb-896f-473e-4444-a4208d033498")
+
+		router := newClientRouter()
+		router.POST("/client/reservations", handler.createReservation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When creating reservation while scooter was updated concurrently returns conflict", func(t *testing.T) {
+		id, _ := uuid.Parse("03de5edd-e9d7-4c4e-3333-0ff9c07b6a37")
+		marshalledRequestBody, _ := json.Marshal(types.CreateReservationRequest{ScooterID: id})
+		request, err := http.NewRequest(http.MethodPost, "/client/reservations", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request.Header.Set("client-id", uuid.New().String())
+
+		router := newClientRouter()
+		router.POST("/client/reservations", handler.createReservation)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+}
+
+func newClientRouter() *gin.Engine {
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		if clientId := c.GetHeader("client-id"); clientId != "" {
+			c.Set(utils.ClientIdKey, clientId)
+		}
+	})
+
+	return router
+}
+
+type mockReservationValidator struct{}
+
+func (m *mockReservationValidator) ValidateCreateReservationRequest(request *types.CreateReservationRequest) error {
+	if request.ScooterID == uuid.Nil {
+		return errors.New("invalid scooter_id")
+	}
+
+	return nil
+}
+
+type mockReservationRepository struct{}
+
+func (m *mockReservationRepository) CreateReservation(reservation types.Reservation, scooterOptLockVersion *int, event types.ReservationEvent) error {
+	if reservation.ClientId.String() == "bec6a2fb-896f-473e-4444-a4208d033498" {
+		return ErrActiveReservationExists
+	}
+
+	if reservation.ScooterId.String() == "03de5edd-e9d7-4c4e-3333-0ff9c07b6a37" {
+		return ErrConcurrentUpdate
+	}
+
+	return nil
+}
+
+// GetActiveReservation implements interfaces.ReservationRepository.
+func (m *mockReservationRepository) GetActiveReservation(clientId string) (*types.Reservation, error) {
+	panic("unimplemented")
+}
+
+// GetExpiredReservations implements interfaces.ReservationRepository.
+func (m *mockReservationRepository) GetExpiredReservations(now time.Time) ([]*types.Reservation, error) {
+	panic("unimplemented")
+}
+
+// ExpireReservation implements interfaces.ReservationRepository.
+func (m *mockReservationRepository) ExpireReservation(reservation *types.Reservation, scooterOptLockVersion *int, event types.ReservationEvent) error {
+	panic("unimplemented")
+}
+
+type mockScooterRepository struct{}
+
+func (m *mockScooterRepository) GetScooterById(id string) (*types.Scooter, *int, error) {
+	if id == "03de5edd-e9d7-4c4e-1111-0ff9c07b6a37" {
+		return nil, nil, errors.New("scooter with id 03de5edd-e9d7-4c4e-1111-0ff9c07b6a37 not found")
+	}
+
+	idUuid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if id == "03de5edd-e9d7-4c4e-2222-0ff9c07b6a37" {
+		return &types.Scooter{ID: idUuid, Status: enums.InTripScooter}, new(int), nil
+	}
+
+	return &types.Scooter{ID: idUuid, Status: enums.AvailableScooter}, new(int), nil
+}
+
+// CreateScooter implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) CreateScooter(scooter types.Scooter) error {
+	panic("unimplemented")
+}
+
+// GetScootersByArea implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) GetScootersByArea(queryParams types.GetScootersQueryParameters) ([]*types.Scooter, error) {
+	panic("unimplemented")
+}
+
+// GetScootersByRadius implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) GetScootersByRadius(queryParams types.GetScootersQueryParameters) ([]*types.NearbyScooter, error) {
+	panic("unimplemented")
+}
+
+// GetAllScooters implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) GetAllScooters(queryParams types.GetAllScootersQueryParameters) ([]*types.Scooter, error) {
+	panic("unimplemented")
+}
+
+// UpdateScooter implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) UpdateScooter(scooter types.Scooter, optLockVersion *int) error {
+	panic("unimplemented")
+}
+
+// IsInActiveTrip implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) IsInActiveTrip(id string) (bool, error) {
+	panic("unimplemented")
+}
+
+// IsReserved implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) IsReserved(id string) (bool, error) {
+	panic("unimplemented")
+}
+
+type mockClientRepository struct{}
+
+func (m *mockClientRepository) GetUserById(id string) (*types.MobileClient, *int, error) {
+	idUuid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if id == "bec6a2fb-896f-473e-3333-a4208d033498" {
+		return &types.MobileClient{ID: idUuid, IsEligibleToTravel: false}, new(int), nil
+	}
+
+	return &types.MobileClient{ID: idUuid, IsEligibleToTravel: true}, new(int), nil
+}
+
+// GetUserByEmail implements interfaces.ClientRepository.
+func (m *mockClientRepository) GetUserByEmail(email string) (*types.MobileClient, error) {
+	panic("unimplemented")
+}
+
+// SetUserCredentials implements interfaces.ClientRepository.
+func (m *mockClientRepository) SetUserCredentials(client types.MobileClient) error {
+	panic("unimplemented")
+}
+
+// CreateUser implements interfaces.ClientRepository.
+func (m *mockClientRepository) CreateUser(client types.MobileClient) error {
+	panic("unimplemented")
+}
+
+// GetUsers implements interfaces.ClientRepository.
+func (m *mockClientRepository) GetUsers(queryParams types.GetUsersQueryParameters) ([]*types.MobileClient, error) {
+	panic("unimplemented")
+}
+
+// IsInActiveTrip implements interfaces.ClientRepository.
+func (m *mockClientRepository) IsInActiveTrip(id string) (bool, error) {
+	panic("unimplemented")
+}
+
+// UpdateUserEligibility implements interfaces.ClientRepository.
+func (m *mockClientRepository) UpdateUserEligibility(client types.MobileClient, optLockVersion *int) error {
+	panic("unimplemented")
+}
@@ -0,0 +1,100 @@
+package reservation
+
+import (
+	"sort"
+	"time"
+
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+type InMemoryReservationRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryReservationRepository {
+	return &InMemoryReservationRepository{storage: storage}
+}
+
+func (r *InMemoryReservationRepository) CreateReservation(reservation types.Reservation, scooterOptLockVersion *int, event types.ReservationEvent) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	for _, stored := range r.storage.Reservations {
+		if stored.ClientId == reservation.ClientId && stored.Status == enums.ActiveReservation {
+			return ErrActiveReservationExists
+		}
+	}
+
+	scooter, ok := r.storage.Scooters[reservation.ScooterId.String()]
+	if !ok || scooter.OptLockVersion != *scooterOptLockVersion || scooter.Value.Status != enums.AvailableScooter {
+		return ErrConcurrentUpdate
+	}
+
+	r.storage.Reservations[reservation.ID.String()] = &reservation
+
+	scooter.Value.Status = enums.ReservedScooter
+	scooter.OptLockVersion++
+
+	r.storage.ReservationEvents = append(r.storage.ReservationEvents, event)
+	return nil
+}
+
+func (r *InMemoryReservationRepository) GetActiveReservation(clientId string) (*types.Reservation, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	for _, stored := range r.storage.Reservations {
+		if stored.ClientId.String() == clientId && stored.Status == enums.ActiveReservation {
+			reservation := *stored
+			return &reservation, nil
+		}
+	}
+
+	return nil, ErrReservationNotFound
+}
+
+func (r *InMemoryReservationRepository) GetExpiredReservations(now time.Time) ([]*types.Reservation, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	reservations := make([]*types.Reservation, 0)
+	for _, stored := range r.storage.Reservations {
+		if stored.Status == enums.ActiveReservation && !stored.ExpiresAt.After(now) {
+			reservation := *stored
+			reservations = append(reservations, &reservation)
+		}
+	}
+
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].ExpiresAt.Before(reservations[j].ExpiresAt)
+	})
+
+	return reservations, nil
+}
+
+func (r *InMemoryReservationRepository) ExpireReservation(reservation *types.Reservation, scooterOptLockVersion *int, event types.ReservationEvent) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	stored, ok := r.storage.Reservations[reservation.ID.String()]
+	if !ok || stored.Status != enums.ActiveReservation {
+		return ErrReservationNotActive
+	}
+
+	scooter, ok := r.storage.Scooters[reservation.ScooterId.String()]
+	if !ok || scooter.OptLockVersion != *scooterOptLockVersion {
+		return ErrConcurrentUpdate
+	}
+
+	stored.Status = enums.ExpiredReservation
+
+	if scooter.Value.Status == enums.ReservedScooter {
+		scooter.Value.Status = enums.AvailableScooter
+	}
+	scooter.OptLockVersion++
+
+	r.storage.ReservationEvents = append(r.storage.ReservationEvents, event)
+	return nil
+}
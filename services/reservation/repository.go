@@ -0,0 +1,166 @@
+package reservation
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+type ReservationRepository struct {
+	db *sql.DB
+}
+
+var (
+	ErrReservationNotFound     = errors.New("reservation not found")
+	ErrActiveReservationExists = errors.New("user already has an active reservation")
+	ErrReservationNotActive    = errors.New("reservation is no longer active")
+	ErrConcurrentUpdate        = errors.New("scooter was updated by another transaction")
+)
+
+const mySqlDuplicateEntryErrorNumber = 1062
+
+const reservationColumns = "id, user_id, scooter_id, status, expires_at, created_at"
+
+var publishReservationEventQuery = "INSERT INTO reservation_events (reservation_id, event_type, created_at) VALUES (UUID_TO_BIN(?, false), ?, ?)"
+
+func NewRepository(db *sql.DB) *ReservationRepository {
+	return &ReservationRepository{db: db}
+}
+
+func (r *ReservationRepository) CreateReservation(reservation types.Reservation, scooterOptLockVersion *int, event types.ReservationEvent) error {
+	createReservationQuery := "INSERT INTO reservations (id, user_id, scooter_id, status, expires_at, created_at) VALUES (UUID_TO_BIN(?, false), UUID_TO_BIN(?, false), UUID_TO_BIN(?, false), ?, ?, ?)"
+	holdScooterQuery := "UPDATE scooters SET status = 'reserved', opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ? AND status = 'available'"
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(createReservationQuery, reservation.ID.String(), reservation.ClientId.String(), reservation.ScooterId.String(),
+		reservation.Status, reservation.ExpiresAt, reservation.CreatedAt)
+
+	var mySqlErr *mysql.MySQLError
+	if errors.As(err, &mySqlErr) && mySqlErr.Number == mySqlDuplicateEntryErrorNumber {
+		tx.Rollback()
+		return ErrActiveReservationExists
+	}
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = execAffectingOneRow(tx, ErrConcurrentUpdate, holdScooterQuery, *scooterOptLockVersion, reservation.ScooterId.String(), *scooterOptLockVersion)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(publishReservationEventQuery, event.ReservationID.String(), event.Type, event.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ReservationRepository) GetActiveReservation(clientId string) (*types.Reservation, error) {
+	rows, err := r.db.Query("SELECT "+reservationColumns+" FROM reservations WHERE user_id = UUID_TO_BIN(?, false) AND status = ?",
+		clientId, enums.ActiveReservation)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations, err := scanRowsIntoReservations(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reservations) == 0 {
+		return nil, ErrReservationNotFound
+	}
+
+	return reservations[0], nil
+}
+
+func (r *ReservationRepository) GetExpiredReservations(now time.Time) ([]*types.Reservation, error) {
+	rows, err := r.db.Query("SELECT "+reservationColumns+" FROM reservations WHERE status = ? AND expires_at <= ? ORDER BY expires_at",
+		enums.ActiveReservation, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRowsIntoReservations(rows)
+}
+
+// ExpireReservation releases the scooter, unless it left the reserved status in the meantime.
+func (r *ReservationRepository) ExpireReservation(reservation *types.Reservation, scooterOptLockVersion *int, event types.ReservationEvent) error {
+	expireReservationQuery := "UPDATE reservations SET status = ? WHERE id = UUID_TO_BIN(?, false) AND status = ?"
+	releaseScooterQuery := "UPDATE scooters SET status = IF(status = 'reserved', 'available', status), opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ?"
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = execAffectingOneRow(tx, ErrReservationNotActive, expireReservationQuery, enums.ExpiredReservation, reservation.ID.String(), enums.ActiveReservation)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = execAffectingOneRow(tx, ErrConcurrentUpdate, releaseScooterQuery, *scooterOptLockVersion, reservation.ScooterId.String(), *scooterOptLockVersion)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(publishReservationEventQuery, event.ReservationID.String(), event.Type, event.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// execAffectingOneRow returns notAffectedErr when the query did not change any row.
+func execAffectingOneRow(tx *sql.Tx, notAffectedErr error, query string, args ...any) error {
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return notAffectedErr
+	}
+
+	return nil
+}
+
+func scanRowsIntoReservations(rows *sql.Rows) ([]*types.Reservation, error) {
+	reservations := make([]*types.Reservation, 0)
+	for rows.Next() {
+		var reservation types.Reservation
+		err := rows.Scan(&reservation.ID, &reservation.ClientId, &reservation.ScooterId, &reservation.Status, &reservation.ExpiresAt, &reservation.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		reservations = append(reservations, &reservation)
+	}
+
+	return reservations, rows.Err()
+}
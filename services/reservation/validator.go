@@ -0,0 +1,29 @@
+package reservation
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type ReservationValidator struct{}
+
+var Validator = validator.New()
+
+func NewReservationValidator() *ReservationValidator {
+	return &ReservationValidator{}
+}
+
+func (v *ReservationValidator) ValidateCreateReservationRequest(request *types.CreateReservationRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	if request.ScooterID == uuid.Nil {
+		return errors.New("invalid scooter_id")
+	}
+
+	return nil
+}
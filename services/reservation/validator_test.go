@@ -0,0 +1,30 @@
+package reservation
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+func TestReservationValidator(t *testing.T) {
+	validator := NewReservationValidator()
+
+	t.Run("When validating create reservation request while given valid request returns nil", func(t *testing.T) {
+		request := types.CreateReservationRequest{ScooterID: uuid.New()}
+
+		result := validator.ValidateCreateReservationRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create reservation request while scooter id is missing returns error", func(t *testing.T) {
+		request := types.CreateReservationRequest{}
+
+		result := validator.ValidateCreateReservationRequest(&request)
+		if result == nil {
+			t.Errorf("expected result to be an error, got nil")
+		}
+	})
+}
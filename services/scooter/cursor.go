@@ -0,0 +1,57 @@
+package scooter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+// scootersCursor points at the last scooter of a page. It remembers the ordering it was issued for,
+// so it can not be reused with a different one.
+type scootersCursor struct {
+	SortBy enums.ScooterSortField `json:"sort_by"`
+	Order  enums.SortOrder        `json:"order"`
+	Value  float64                `json:"value"`
+	ID     uuid.UUID              `json:"id"`
+}
+
+func encodeCursor(scooter *types.Scooter, sortBy enums.ScooterSortField, order enums.SortOrder) string {
+	cursor := scootersCursor{
+		SortBy: sortBy,
+		Order:  order,
+		Value:  sortValue(scooter, sortBy),
+		ID:     scooter.ID,
+	}
+
+	marshalledCursor, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(marshalledCursor)
+}
+
+func decodeCursor(encodedCursor string) (*scootersCursor, error) {
+	marshalledCursor, err := base64.RawURLEncoding.DecodeString(encodedCursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var cursor scootersCursor
+	if err := json.Unmarshal(marshalledCursor, &cursor); err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &cursor, nil
+}
+
+func sortValue(scooter *types.Scooter, sortBy enums.ScooterSortField) float64 {
+	if sortBy == enums.SortByLatitude {
+		return scooter.Location.Latitude
+	}
+	if sortBy == enums.SortByLongitude {
+		return scooter.Location.Longitude
+	}
+
+	return 0
+}
@@ -1,29 +1,51 @@
 package scooter
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
 	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"github.com/nerijusro/scootinAboot/utils"
 )
 
 type ScooterHandler struct {
-	repository interfaces.ScooterRepository
-	validator  interfaces.ScooterValidator
+	repository          interfaces.ScooterRepository
+	deviceSecrets       interfaces.DeviceSecretRepository
+	validator           interfaces.ScooterValidator
+	lowBatteryThreshold int
 }
 
-func NewScooterHandler(repository interfaces.ScooterRepository, validator interfaces.ScooterValidator) *ScooterHandler {
-	return &ScooterHandler{repository: repository, validator: validator}
+const (
+	defaultNearbyScootersLimit = 20
+	defaultScootersPageSize    = 50
+	defaultBatteryLevel        = 100
+)
+
+func NewScooterHandler(
+	repository interfaces.ScooterRepository,
+	deviceSecrets interfaces.DeviceSecretRepository,
+	validator interfaces.ScooterValidator,
+	lowBatteryThreshold int) *ScooterHandler {
+	return &ScooterHandler{
+		repository:          repository,
+		deviceSecrets:       deviceSecrets,
+		validator:           validator,
+		lowBatteryThreshold: lowBatteryThreshold,
+	}
 }
 
-func (h *ScooterHandler) RegisterEndpoints(routerGroups map[string]*gin.RouterGroup) {
-	adminAuthorized := routerGroups["admin"]
-	adminAuthorized.POST("/scooters", h.createScooter)
-	adminAuthorized.GET("/scooters", h.getAllScooters)
+func (h *ScooterHandler) RegisterEndpoints(routes interfaces.Routes) {
+	routes.Admin(enums.WriteScooters).POST("/scooters", h.createScooter)
+	routes.Admin(enums.ReadScooters).GET("/scooters", h.getAllScooters)
+	routes.Admin(enums.WriteScooters).PATCH("/scooters/:id", h.updateScooter)
+	routes.Admin(enums.WriteScooters).DELETE("/scooters/:id", h.retireScooter)
+	routes.Admin(enums.WriteScooters).POST("/scooters/:id/secret", h.issueDeviceSecret)
 
-	userAuthorized := routerGroups["client"]
+	userAuthorized := routes.Client()
 	userAuthorized.GET("/scooters", h.getScootersByArea)
 	userAuthorized.GET("/scooters/:id", h.getScooter)
 }
@@ -40,10 +62,23 @@ func (h *ScooterHandler) createScooter(c *gin.Context) {
 		return
 	}
 
+	batteryLevel := defaultBatteryLevel
+	if scooterRequest.BatteryLevel != nil {
+		batteryLevel = *scooterRequest.BatteryLevel
+	}
+
+	// A scooter with a low battery starts out charging even when it was asked to be available
+	status := utils.IdleScooterStatus(batteryLevel, h.lowBatteryThreshold)
+	if scooterRequest.Status == enums.ChargingScooter {
+		status = enums.ChargingScooter
+	}
+
 	scooter := types.Scooter{
-		ID:          uuid.New(),
-		Location:    scooterRequest.Location,
-		IsAvailable: scooterRequest.IsAvailable,
+		ID:              uuid.New(),
+		Location:        scooterRequest.Location,
+		Status:          status,
+		BatteryLevel:    batteryLevel,
+		FirmwareVersion: scooterRequest.FirmwareVersion,
 	}
 
 	err := h.repository.CreateScooter(scooter)
@@ -67,6 +102,11 @@ func (h *ScooterHandler) getScootersByArea(c *gin.Context) {
 		return
 	}
 
+	if queryParameters.IsRadiusSearch() {
+		h.getScootersByRadius(c, queryParameters)
+		return
+	}
+
 	scooters, err := h.repository.GetScootersByArea(queryParameters)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"Not Found": err.Error()})
@@ -79,16 +119,56 @@ func (h *ScooterHandler) getScootersByArea(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *ScooterHandler) getScootersByRadius(c *gin.Context, queryParameters types.GetScootersQueryParameters) {
+	if queryParameters.Limit == 0 {
+		queryParameters.Limit = defaultNearbyScootersLimit
+	}
+
+	scooters, err := h.repository.GetScootersByRadius(queryParameters)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not Found": err.Error()})
+		return
+	}
+
+	response := types.GetNearbyScootersResponse{
+		Scooters: scooters,
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 func (h *ScooterHandler) getAllScooters(c *gin.Context) {
-	allScooters, err := h.repository.GetAllScooters()
+	var queryParameters types.GetAllScootersQueryParameters
+	if err := c.BindQuery(&queryParameters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	setDefaultPageParameters(&queryParameters)
+	if err := h.validator.ValidateGetAllScootersQueryParameters(&queryParameters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	// One scooter more than requested is fetched to know whether there is a next page
+	pageSize := queryParameters.Limit
+	queryParameters.Limit++
+
+	scooters, err := h.repository.GetAllScooters(queryParameters)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"Not Found": err.Error()})
 		return
 	}
 
 	response := types.GetScootersResponse{
-		Scooters: allScooters,
+		Scooters: scooters,
 	}
+
+	if len(scooters) > pageSize {
+		response.Scooters = scooters[:pageSize]
+		response.NextCursor = encodeCursor(scooters[pageSize-1],
+			enums.ScooterSortField(queryParameters.SortBy), enums.SortOrder(queryParameters.Order))
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
@@ -108,3 +188,197 @@ func (h *ScooterHandler) getScooter(c *gin.Context) {
 
 	c.JSON(http.StatusOK, scooter)
 }
+
+func (h *ScooterHandler) updateScooter(c *gin.Context) {
+	id := c.Param("id")
+	idInUUID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	var request types.UpdateScooterRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateUpdateScooterRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	scooter, optLockVersion, ok := h.getModifiableScooter(c, idInUUID.String())
+	if !ok {
+		return
+	}
+
+	if request.Location != nil {
+		scooter.Location = *request.Location
+	}
+
+	if request.Status != nil && *request.Status != scooter.Status {
+		if scooter.Status == enums.MaintenanceScooter {
+			c.JSON(http.StatusConflict, gin.H{"Conflict": "scooter is in maintenance until its tickets are resolved"})
+			return
+		}
+
+		if err := utils.ValidateScooterTransition(scooter.Status, *request.Status); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+			return
+		}
+
+		scooter.Status = *request.Status
+	}
+
+	if request.BatteryLevel != nil {
+		scooter.BatteryLevel = *request.BatteryLevel
+	}
+
+	if request.FirmwareVersion != nil {
+		scooter.FirmwareVersion = *request.FirmwareVersion
+	}
+
+	// A scooter with a low battery is taken out of service and can not be made available until it is charged
+	if scooter.BatteryLevel < h.lowBatteryThreshold {
+		if request.Status != nil && *request.Status == enums.AvailableScooter {
+			c.JSON(http.StatusConflict, gin.H{"Conflict": "scooter battery level is too low"})
+			return
+		}
+
+		if scooter.Status == enums.AvailableScooter {
+			scooter.Status = enums.ChargingScooter
+		}
+	}
+
+	h.saveScooter(c, scooter, optLockVersion)
+}
+
+// issueDeviceSecret generates the secret the scooter authenticates its telemetry with, replacing the previous one.
+func (h *ScooterHandler) issueDeviceSecret(c *gin.Context) {
+	id := c.Param("id")
+	idInUUID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	scooter, _, err := h.repository.GetScooterById(idInUUID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	if scooter.Status == enums.RetiredScooter {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": "scooter is retired"})
+		return
+	}
+
+	secret, err := utils.GenerateApiKey()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error generating device secret"})
+		return
+	}
+
+	if err := h.deviceSecrets.SetDeviceSecretHash(scooter.ID.String(), utils.HashApiKey(secret)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	// Only the hash is stored, so this is the one and only time the secret can be seen
+	c.JSON(http.StatusCreated, types.DeviceSecretResponse{ScooterID: scooter.ID, Secret: secret})
+}
+
+func (h *ScooterHandler) retireScooter(c *gin.Context) {
+	id := c.Param("id")
+	idInUUID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	scooter, optLockVersion, ok := h.getModifiableScooter(c, idInUUID.String())
+	if !ok {
+		return
+	}
+
+	if err := utils.ValidateScooterTransition(scooter.Status, enums.RetiredScooter); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	scooter.Status = enums.RetiredScooter
+
+	h.saveScooter(c, scooter, optLockVersion)
+}
+
+// getModifiableScooter writes the error response itself when the scooter cannot be changed by an admin.
+func (h *ScooterHandler) getModifiableScooter(c *gin.Context, id string) (*types.Scooter, *int, bool) {
+	scooter, optLockVersion, err := h.repository.GetScooterById(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return nil, nil, false
+	}
+
+	if scooter.Status == enums.RetiredScooter {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": "scooter is retired"})
+		return nil, nil, false
+	}
+
+	isInActiveTrip, err := h.repository.IsInActiveTrip(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error checking active trips"})
+		return nil, nil, false
+	}
+
+	if isInActiveTrip {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": "scooter is in an active trip"})
+		return nil, nil, false
+	}
+
+	isReserved, err := h.repository.IsReserved(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error checking reservations"})
+		return nil, nil, false
+	}
+
+	if isReserved {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": "scooter is reserved"})
+		return nil, nil, false
+	}
+
+	return scooter, optLockVersion, true
+}
+
+func (h *ScooterHandler) saveScooter(c *gin.Context, scooter *types.Scooter, optLockVersion *int) {
+	err := h.repository.UpdateScooter(*scooter, optLockVersion)
+	if errors.Is(err, ErrConcurrentUpdate) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, scooter)
+}
+
+func setDefaultPageParameters(queryParameters *types.GetAllScootersQueryParameters) {
+	if queryParameters.Availability == "" {
+		queryParameters.Availability = string(enums.All)
+	}
+
+	if queryParameters.SortBy == "" {
+		queryParameters.SortBy = string(enums.SortById)
+	}
+
+	if queryParameters.Order == "" {
+		queryParameters.Order = string(enums.Ascending)
+	}
+
+	if queryParameters.Limit == 0 {
+		queryParameters.Limit = defaultScootersPageSize
+	}
+}
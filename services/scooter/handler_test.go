@@ -11,17 +11,18 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
 )
 
 func TestScooterHandler(t *testing.T) {
 	scootersRepository := &mockScooterRepository{}
 	validator := &mockScooterRequestValidator{}
-	scootersHandler := NewScooterHandler(scootersRepository, validator)
+	scootersHandler := NewScooterHandler(scootersRepository, scootersRepository, validator, 15)
 
 	t.Run("When creating scooter while given valid request body returns status created", func(t *testing.T) {
 		requestBody := types.CreateScooterRequest{
-			Location:    types.Location{Latitude: 54.12, Longitude: 25.34},
-			IsAvailable: true,
+			Location: types.Location{Latitude: 54.12, Longitude: 25.34},
+			Status:   enums.AvailableScooter,
 		}
 
 		marshalledRequestBody, _ := json.Marshal(requestBody)
@@ -53,14 +54,48 @@ func TestScooterHandler(t *testing.T) {
 			t.Errorf("expected longitude to be %f, got %f", requestBody.Location.Longitude, response.Location.Longitude)
 		}
 
-		if response.IsAvailable != requestBody.IsAvailable {
-			t.Errorf("expected availability to be %t, got %t", requestBody.IsAvailable, response.IsAvailable)
+		if response.Status != enums.AvailableScooter {
+			t.Errorf("expected status to be available, got %s", response.Status)
+		}
+	})
+
+	t.Run("When creating scooter while battery level is low returns unavailable scooter", func(t *testing.T) {
+		batteryLevel := 10
+		requestBody := types.CreateScooterRequest{
+			Location:     types.Location{Latitude: 54.12, Longitude: 25.34},
+			Status:       enums.AvailableScooter,
+			BatteryLevel: &batteryLevel,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/admin/scooters", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/scooters", scootersHandler.createScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+
+		var response types.Scooter
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Status != enums.ChargingScooter || response.BatteryLevel != 10 {
+			t.Errorf("expected charging scooter with battery level 10, got %s and %d", response.Status, response.BatteryLevel)
 		}
 	})
 
 	t.Run("When creating scooter while required parameters are missing returns bad request", func(t *testing.T) {
 		requestBody := map[string]string{
-			"is_available": "true",
+			"battery_level": "10",
 		}
 
 		marshalledRequestBody, _ := json.Marshal(requestBody)
@@ -101,8 +136,8 @@ func TestScooterHandler(t *testing.T) {
 
 	t.Run("When creating scooter while request body content is not valid returns bad request", func(t *testing.T) {
 		requestBody := types.CreateScooterRequest{
-			Location:    types.Location{Latitude: 999.0, Longitude: 25.34},
-			IsAvailable: true,
+			Location: types.Location{Latitude: 999.0, Longitude: 25.34},
+			Status:   enums.AvailableScooter,
 		}
 
 		marshalledRequestBody, _ := json.Marshal(requestBody)
@@ -133,8 +168,8 @@ func TestScooterHandler(t *testing.T) {
 
 	t.Run("When creating scooter while repository fails returns internal server error", func(t *testing.T) {
 		requestBody := types.CreateScooterRequest{
-			Location:    types.Location{Latitude: 0, Longitude: 0},
-			IsAvailable: true,
+			Location: types.Location{Latitude: 0, Longitude: 0},
+			Status:   enums.AvailableScooter,
 		}
 
 		marshalledRequestBody, _ := json.Marshal(requestBody)
@@ -193,8 +228,8 @@ func TestScooterHandler(t *testing.T) {
 			t.Errorf("expected longitude to be 44.34, got %f", scooter.Location.Longitude)
 		}
 
-		if scooter.IsAvailable != true {
-			t.Errorf("expected availability to be true, got %t", scooter.IsAvailable)
+		if scooter.Status != enums.AvailableScooter {
+			t.Errorf("expected status to be available, got %s", scooter.Status)
 		}
 	})
 
@@ -258,6 +293,54 @@ func TestScooterHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("When getting scooters by radius while everything is valid returns ok", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/scooters?availability=available&lat=12.12&lng=44.34&radius_m=500", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/client/scooters", scootersHandler.getScootersByArea)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.GetNearbyScootersResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		scooter := response.Scooters[0]
+		if scooter.ID.String() != "e3344268-d649-4c19-a20c-a0c64a5a6623" {
+			t.Errorf("expected id to be: e3344268-d649-4c19-a20c-a0c64a5a6623, got %s", scooter.ID.String())
+		}
+
+		if scooter.DistanceMeters != 123.4 {
+			t.Errorf("expected distance to be 123.4, got %f", scooter.DistanceMeters)
+		}
+	})
+
+	t.Run("When getting scooters by radius while repository fails returns not found", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/scooters?availability=available&lat=12.12&lng=44.34&radius_m=500&limit=5", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/client/scooters", scootersHandler.getScootersByArea)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
 	t.Run("When getting all scooters while everything is valid returns ok", func(t *testing.T) {
 		request, err := http.NewRequest(http.MethodGet, "/admin/scooters", nil)
 		if err != nil {
@@ -288,8 +371,68 @@ func TestScooterHandler(t *testing.T) {
 			t.Errorf("expected longitude to be 44.34, got %f", firstScooter.Location.Longitude)
 		}
 
-		if firstScooter.IsAvailable != true {
-			t.Errorf("expected availability to be true, got %t", firstScooter.IsAvailable)
+		if firstScooter.Status != enums.AvailableScooter {
+			t.Errorf("expected status to be available, got %s", firstScooter.Status)
+		}
+
+		if response.NextCursor != "" {
+			t.Errorf("expected next cursor to be empty, got %s", response.NextCursor)
+		}
+	})
+
+	t.Run("When getting all scooters while there are more pages returns next cursor", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/admin/scooters?limit=1&sort_by=latitude", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/admin/scooters", scootersHandler.getAllScooters)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.GetScootersResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(response.Scooters) != 1 {
+			t.Fatalf("expected 1 scooter, got %d", len(response.Scooters))
+		}
+
+		cursor, err := decodeCursor(response.NextCursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if cursor.ID != response.Scooters[0].ID {
+			t.Errorf("expected cursor id to be %s, got %s", response.Scooters[0].ID, cursor.ID)
+		}
+
+		if cursor.Value != 12.12 {
+			t.Errorf("expected cursor value to be 12.12, got %f", cursor.Value)
+		}
+	})
+
+	t.Run("When getting all scooters while query parameter content is not valid returns bad request", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/admin/scooters?limit=1000", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/admin/scooters", scootersHandler.getAllScooters)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
 		}
 	})
 
@@ -326,8 +469,8 @@ func TestScooterHandler(t *testing.T) {
 			t.Errorf("expected longitude to be 44.34, got %f", response.Location.Longitude)
 		}
 
-		if response.IsAvailable != true {
-			t.Errorf("expected availability to be true, got %t", response.IsAvailable)
+		if response.Status != enums.AvailableScooter {
+			t.Errorf("expected status to be available, got %s", response.Status)
 		}
 	})
 
@@ -347,6 +490,323 @@ func TestScooterHandler(t *testing.T) {
 			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
 		}
 	})
+
+	t.Run("When updating scooter while everything is valid returns ok", func(t *testing.T) {
+		requestBody := types.UpdateScooterRequest{
+			Location: &types.Location{Latitude: 54.68, Longitude: 25.28},
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPatch, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a6623", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/scooters/:id", scootersHandler.updateScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.Scooter
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Location.Latitude != 54.68 {
+			t.Errorf("expected latitude to be: 54.68, got %f", response.Location.Latitude)
+		}
+
+		if response.Location.Longitude != 25.28 {
+			t.Errorf("expected longitude to be 25.28, got %f", response.Location.Longitude)
+		}
+
+		if response.Status != enums.AvailableScooter {
+			t.Errorf("expected status to be available, got %s", response.Status)
+		}
+	})
+
+	t.Run("When updating scooter while making it available with low battery returns conflict", func(t *testing.T) {
+		batteryLevel := 5
+		status := enums.AvailableScooter
+		requestBody := types.UpdateScooterRequest{
+			Status:       &status,
+			BatteryLevel: &batteryLevel,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPatch, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a6623", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/scooters/:id", scootersHandler.updateScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating scooter while request has nothing to update returns bad request", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPatch, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a6623", bytes.NewBuffer([]byte("{}")))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/scooters/:id", scootersHandler.updateScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating scooter while scooter is not existant returns not found", func(t *testing.T) {
+		status := enums.AvailableScooter
+		marshalledRequestBody, _ := json.Marshal(types.UpdateScooterRequest{Status: &status})
+		request, err := http.NewRequest(http.MethodPatch, "/admin/scooters/e3344268-1234-4c19-a20c-a0c64a5a6623", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/scooters/:id", scootersHandler.updateScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating scooter while scooter is in an active trip returns conflict", func(t *testing.T) {
+		status := enums.AvailableScooter
+		marshalledRequestBody, _ := json.Marshal(types.UpdateScooterRequest{Status: &status})
+		request, err := http.NewRequest(http.MethodPatch, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a1111", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/scooters/:id", scootersHandler.updateScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating scooter while scooter is reserved returns conflict", func(t *testing.T) {
+		status := enums.AvailableScooter
+		marshalledRequestBody, _ := json.Marshal(types.UpdateScooterRequest{Status: &status})
+		request, err := http.NewRequest(http.MethodPatch, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a4444", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/scooters/:id", scootersHandler.updateScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating scooter while scooter is retired returns conflict", func(t *testing.T) {
+		status := enums.AvailableScooter
+		marshalledRequestBody, _ := json.Marshal(types.UpdateScooterRequest{Status: &status})
+		request, err := http.NewRequest(http.MethodPatch, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a2222", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/scooters/:id", scootersHandler.updateScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating scooter while scooter was updated concurrently returns conflict", func(t *testing.T) {
+		status := enums.AvailableScooter
+		marshalledRequestBody, _ := json.Marshal(types.UpdateScooterRequest{Status: &status})
+		request, err := http.NewRequest(http.MethodPatch, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a3333", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/scooters/:id", scootersHandler.updateScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating scooter status while scooter is in maintenance returns conflict", func(t *testing.T) {
+		status := enums.AvailableScooter
+		marshalledRequestBody, _ := json.Marshal(types.UpdateScooterRequest{Status: &status})
+		request, err := http.NewRequest(http.MethodPatch, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a5555", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/scooters/:id", scootersHandler.updateScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating scooter status while transition is not allowed returns conflict", func(t *testing.T) {
+		status := enums.ReservedScooter
+		marshalledRequestBody, _ := json.Marshal(types.UpdateScooterRequest{Status: &status})
+		request, err := http.NewRequest(http.MethodPatch, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a3333", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.PATCH("/admin/scooters/:id", scootersHandler.updateScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When retiring scooter while everything is valid returns ok", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodDelete, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a6623", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.DELETE("/admin/scooters/:id", scootersHandler.retireScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.Scooter
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Status != enums.RetiredScooter {
+			t.Errorf("expected status to be retired, got %s", response.Status)
+		}
+	})
+
+	t.Run("When retiring scooter while scooter is in an active trip returns conflict", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodDelete, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a1111", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.DELETE("/admin/scooters/:id", scootersHandler.retireScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When retiring scooter while id is not valid returns bad request", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodDelete, "/admin/scooters/not-a-uuid", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.DELETE("/admin/scooters/:id", scootersHandler.retireScooter)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When issuing device secret while scooter exists returns status created", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a6623/secret", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/scooters/:id/secret", scootersHandler.issueDeviceSecret)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+
+		var response types.DeviceSecretResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Secret == "" {
+			t.Errorf("expected secret to be returned")
+		}
+	})
+
+	t.Run("When issuing device secret while scooter is retired returns conflict", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/admin/scooters/e3344268-d649-4c19-a20c-a0c64a5a2222/secret", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/scooters/:id/secret", scootersHandler.issueDeviceSecret)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
 }
 
 type mockScooterRequestValidator struct{}
@@ -367,6 +827,22 @@ func (m *mockScooterRequestValidator) ValidateGetScootersQueryParameters(queryPa
 	return nil
 }
 
+func (m *mockScooterRequestValidator) ValidateGetAllScootersQueryParameters(queryParams *types.GetAllScootersQueryParameters) error {
+	if queryParams.Limit > 100 {
+		return errors.New("invalid limit")
+	}
+
+	return nil
+}
+
+func (m *mockScooterRequestValidator) ValidateUpdateScooterRequest(request *types.UpdateScooterRequest) error {
+	if request.Location == nil && request.Status == nil && request.BatteryLevel == nil && request.FirmwareVersion == nil {
+		return errors.New("nothing to update")
+	}
+
+	return nil
+}
+
 type mockScooterRepository struct{}
 
 func (m *mockScooterRepository) CreateScooter(scooter types.Scooter) error {
@@ -377,16 +853,22 @@ func (m *mockScooterRepository) CreateScooter(scooter types.Scooter) error {
 	return nil
 }
 
-func (m *mockScooterRepository) GetAllScooters() ([]*types.Scooter, error) {
+func (m *mockScooterRepository) GetAllScooters(queryParams types.GetAllScootersQueryParameters) ([]*types.Scooter, error) {
 	id, _ := uuid.Parse("e3344268-d649-4c19-a20c-a0c64a5a6623")
+	secondId, _ := uuid.Parse("e3344268-d649-4c19-a20c-a0c64a5a7777")
 	var scooters []*types.Scooter
 	scooters = append(scooters, &types.Scooter{
-		ID:          id,
-		Location:    types.Location{Latitude: 12.12, Longitude: 44.34},
-		IsAvailable: true,
+		ID:       id,
+		Location: types.Location{Latitude: 12.12, Longitude: 44.34},
+		Status:   enums.AvailableScooter,
+	})
+	scooters = append(scooters, &types.Scooter{
+		ID:       secondId,
+		Location: types.Location{Latitude: 13.13, Longitude: 45.45},
+		Status:   enums.ChargingScooter,
 	})
 
-	return scooters, nil
+	return scooters[:min(queryParams.Limit, len(scooters))], nil
 }
 
 func (m *mockScooterRepository) GetScooterById(id string) (*types.Scooter, *int, error) {
@@ -394,12 +876,36 @@ func (m *mockScooterRepository) GetScooterById(id string) (*types.Scooter, *int,
 		id, _ := uuid.Parse("e3344268-d649-4c19-a20c-a0c64a5a6623")
 
 		return &types.Scooter{
-			ID:          id,
-			Location:    types.Location{Latitude: 12.12, Longitude: 44.34},
-			IsAvailable: true,
+			ID:           id,
+			Location:     types.Location{Latitude: 12.12, Longitude: 44.34},
+			Status:       enums.AvailableScooter,
+			BatteryLevel: 80,
 		}, nil, nil
 	}
 
+	if id == "e3344268-d649-4c19-a20c-a0c64a5a1111" || id == "e3344268-d649-4c19-a20c-a0c64a5a3333" || id == "e3344268-d649-4c19-a20c-a0c64a5a4444" {
+		id, _ := uuid.Parse(id)
+		status := enums.InTripScooter
+		switch id.String() {
+		case "e3344268-d649-4c19-a20c-a0c64a5a3333":
+			status = enums.ChargingScooter
+		case "e3344268-d649-4c19-a20c-a0c64a5a4444":
+			status = enums.ReservedScooter
+		}
+
+		return &types.Scooter{ID: id, Status: status, BatteryLevel: 80}, new(int), nil
+	}
+
+	if id == "e3344268-d649-4c19-a20c-a0c64a5a5555" {
+		id, _ := uuid.Parse(id)
+		return &types.Scooter{ID: id, Status: enums.MaintenanceScooter, BatteryLevel: 80}, new(int), nil
+	}
+
+	if id == "e3344268-d649-4c19-a20c-a0c64a5a2222" {
+		id, _ := uuid.Parse(id)
+		return &types.Scooter{ID: id, Status: enums.RetiredScooter}, new(int), nil
+	}
+
 	return nil, nil, errors.New("issue while getting scooter by id")
 }
 
@@ -411,9 +917,52 @@ func (m *mockScooterRepository) GetScootersByArea(queryParams types.GetScootersQ
 	id, _ := uuid.Parse("e3344268-d649-4c19-a20c-a0c64a5a6623")
 	return []*types.Scooter{
 		{
-			ID:          id,
-			Location:    types.Location{Latitude: 12.12, Longitude: 44.34},
-			IsAvailable: true,
+			ID:       id,
+			Location: types.Location{Latitude: 12.12, Longitude: 44.34},
+			Status:   enums.AvailableScooter,
+		},
+	}, nil
+}
+
+func (m *mockScooterRepository) GetScootersByRadius(queryParams types.GetScootersQueryParameters) ([]*types.NearbyScooter, error) {
+	if queryParams.Limit != defaultNearbyScootersLimit {
+		return nil, errors.New("issue while getting scooters by radius")
+	}
+
+	id, _ := uuid.Parse("e3344268-d649-4c19-a20c-a0c64a5a6623")
+	return []*types.NearbyScooter{
+		{
+			Scooter: types.Scooter{
+				ID:       id,
+				Location: types.Location{Latitude: 12.12, Longitude: 44.34},
+				Status:   enums.AvailableScooter,
+			},
+			DistanceMeters: 123.4,
 		},
 	}, nil
 }
+
+func (m *mockScooterRepository) UpdateScooter(scooter types.Scooter, optLockVersion *int) error {
+	if scooter.ID.String() == "e3344268-d649-4c19-a20c-a0c64a5a3333" {
+		return ErrConcurrentUpdate
+	}
+
+	return nil
+}
+
+// GetDeviceSecretHash implements interfaces.DeviceSecretRepository.
+func (m *mockScooterRepository) GetDeviceSecretHash(scooterId string) (string, error) {
+	panic("unimplemented")
+}
+
+func (m *mockScooterRepository) SetDeviceSecretHash(scooterId string, secretHash string) error {
+	return nil
+}
+
+func (m *mockScooterRepository) IsInActiveTrip(id string) (bool, error) {
+	return id == "e3344268-d649-4c19-a20c-a0c64a5a1111", nil
+}
+
+func (m *mockScooterRepository) IsReserved(id string) (bool, error) {
+	return id == "e3344268-d649-4c19-a20c-a0c64a5a4444", nil
+}
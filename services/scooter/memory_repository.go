@@ -0,0 +1,239 @@
+package scooter
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+type InMemoryScooterRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryScooterRepository {
+	return &InMemoryScooterRepository{storage: storage}
+}
+
+func (r *InMemoryScooterRepository) CreateScooter(scooter types.Scooter) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if _, ok := r.storage.Scooters[scooter.ID.String()]; ok {
+		return fmt.Errorf("scooter with id %s already exists", scooter.ID.String())
+	}
+
+	r.storage.Scooters[scooter.ID.String()] = &db.VersionedRecord[types.Scooter]{Value: scooter}
+	return nil
+}
+
+func (r *InMemoryScooterRepository) GetScootersByArea(queryParams types.GetScootersQueryParameters) ([]*types.Scooter, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	availabilityFilter := enums.Availability(queryParams.Availability)
+	scooters := make([]*types.Scooter, 0)
+	for _, record := range r.storage.Scooters {
+		scooter := record.Value
+		if scooter.Location.Latitude < queryParams.Y1 || scooter.Location.Latitude > queryParams.Y2 ||
+			scooter.Location.Longitude < queryParams.X1 || scooter.Location.Longitude > queryParams.X2 {
+			continue
+		}
+
+		if scooter.Status == enums.RetiredScooter || scooter.BatteryLevel < queryParams.MinBattery || !matchesAvailabilityFilter(scooter, availabilityFilter) {
+			continue
+		}
+
+		scooters = append(scooters, &scooter)
+	}
+
+	sortScootersById(scooters)
+	return scooters, nil
+}
+
+func (r *InMemoryScooterRepository) GetScootersByRadius(queryParams types.GetScootersQueryParameters) ([]*types.NearbyScooter, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	center := types.Location{Latitude: *queryParams.Latitude, Longitude: *queryParams.Longitude}
+	availabilityFilter := enums.Availability(queryParams.Availability)
+	scooters := make([]*types.NearbyScooter, 0)
+	for _, record := range r.storage.Scooters {
+		scooter := record.Value
+		if scooter.Status == enums.RetiredScooter || scooter.BatteryLevel < queryParams.MinBattery || !matchesAvailabilityFilter(scooter, availabilityFilter) {
+			continue
+		}
+
+		distance := utils.HaversineDistance(center, scooter.Location)
+		if distance > queryParams.RadiusMeters {
+			continue
+		}
+
+		scooters = append(scooters, &types.NearbyScooter{Scooter: scooter, DistanceMeters: distance})
+	}
+
+	sort.Slice(scooters, func(i, j int) bool {
+		if scooters[i].DistanceMeters != scooters[j].DistanceMeters {
+			return scooters[i].DistanceMeters < scooters[j].DistanceMeters
+		}
+		return scooters[i].ID.String() < scooters[j].ID.String()
+	})
+
+	return scooters[:min(queryParams.Limit, len(scooters))], nil
+}
+
+func (r *InMemoryScooterRepository) GetAllScooters(queryParams types.GetAllScootersQueryParameters) ([]*types.Scooter, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	sortBy := enums.ScooterSortField(queryParams.SortBy)
+	direction := 1
+	if enums.SortOrder(queryParams.Order) == enums.Descending {
+		direction = -1
+	}
+
+	var cursor *scootersCursor
+	if queryParams.Cursor != "" {
+		var err error
+		if cursor, err = decodeCursor(queryParams.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	availabilityFilter := enums.Availability(queryParams.Availability)
+	scooters := make([]*types.Scooter, 0, len(r.storage.Scooters))
+	for _, record := range r.storage.Scooters {
+		scooter := record.Value
+		if !matchesAvailabilityFilter(scooter, availabilityFilter) {
+			continue
+		}
+
+		if queryParams.Status != "" && scooter.Status != enums.ScooterStatus(queryParams.Status) {
+			continue
+		}
+
+		if cursor != nil && direction*compareToCursor(&scooter, sortBy, cursor) <= 0 {
+			continue
+		}
+
+		scooters = append(scooters, &scooter)
+	}
+
+	sort.Slice(scooters, func(i, j int) bool {
+		return direction*compareScooters(scooters[i], scooters[j], sortBy) < 0
+	})
+
+	return scooters[:min(queryParams.Limit, len(scooters))], nil
+}
+
+func (r *InMemoryScooterRepository) GetScooterById(id string) (*types.Scooter, *int, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	record, ok := r.storage.Scooters[id]
+	if !ok {
+		return nil, nil, fmt.Errorf("scooter with id %s not found", id)
+	}
+
+	scooter := record.Value
+	optLockVersion := record.OptLockVersion
+	return &scooter, &optLockVersion, nil
+}
+
+func (r *InMemoryScooterRepository) UpdateScooter(scooter types.Scooter, optLockVersion *int) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	record, ok := r.storage.Scooters[scooter.ID.String()]
+	if !ok || record.OptLockVersion != *optLockVersion {
+		return ErrConcurrentUpdate
+	}
+
+	record.Value = scooter
+	record.OptLockVersion++
+	return nil
+}
+
+func (r *InMemoryScooterRepository) GetDeviceSecretHash(scooterId string) (string, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	secretHash, ok := r.storage.DeviceSecrets[scooterId]
+	if !ok {
+		return "", ErrDeviceSecretNotFound
+	}
+
+	return secretHash, nil
+}
+
+func (r *InMemoryScooterRepository) SetDeviceSecretHash(scooterId string, secretHash string) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if _, ok := r.storage.Scooters[scooterId]; !ok {
+		return fmt.Errorf("scooter with id %s not found", scooterId)
+	}
+
+	r.storage.DeviceSecrets[scooterId] = secretHash
+	return nil
+}
+
+func (r *InMemoryScooterRepository) IsInActiveTrip(id string) (bool, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	for _, trip := range r.storage.Trips {
+		if trip.ScooterId.String() == id && !trip.IsFinished {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *InMemoryScooterRepository) IsReserved(id string) (bool, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	for _, reservation := range r.storage.Reservations {
+		if reservation.ScooterId.String() == id && reservation.Status == enums.ActiveReservation {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func matchesAvailabilityFilter(scooter types.Scooter, availability enums.Availability) bool {
+	if availability == enums.Available {
+		return scooter.Status == enums.AvailableScooter
+	}
+	if availability == enums.Unavailable {
+		return scooter.Status != enums.AvailableScooter
+	}
+
+	return true
+}
+
+func compareScooters(a *types.Scooter, b *types.Scooter, sortBy enums.ScooterSortField) int {
+	return cmp.Or(
+		cmp.Compare(sortValue(a, sortBy), sortValue(b, sortBy)),
+		strings.Compare(a.ID.String(), b.ID.String()))
+}
+
+func compareToCursor(scooter *types.Scooter, sortBy enums.ScooterSortField, cursor *scootersCursor) int {
+	return cmp.Or(
+		cmp.Compare(sortValue(scooter, sortBy), cursor.Value),
+		strings.Compare(scooter.ID.String(), cursor.ID.String()))
+}
+
+func sortScootersById(scooters []*types.Scooter) {
+	sort.Slice(scooters, func(i, j int) bool {
+		return scooters[i].ID.String() < scooters[j].ID.String()
+	})
+}
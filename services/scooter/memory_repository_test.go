@@ -0,0 +1,81 @@
+package scooter
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestInMemoryScooterRepository(t *testing.T) {
+	storage := db.NewInMemoryStorage()
+	repository := NewInMemoryRepository(storage)
+
+	latitudes := []float64{54.3, 54.1, 54.2, 54.1}
+	for _, latitude := range latitudes {
+		scooter := types.Scooter{ID: uuid.New(), Location: types.Location{Latitude: latitude, Longitude: 25.27}, Status: enums.AvailableScooter}
+		if err := repository.CreateScooter(scooter); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("When getting all scooters while paging with cursor returns every scooter once in order", func(t *testing.T) {
+		queryParams := types.GetAllScootersQueryParameters{
+			Availability: string(enums.All),
+			SortBy:       string(enums.SortByLatitude),
+			Order:        string(enums.Descending),
+			Limit:        3,
+		}
+
+		firstPage, err := repository.GetAllScooters(queryParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		queryParams.Cursor = encodeCursor(firstPage[len(firstPage)-1], enums.SortByLatitude, enums.Descending)
+		secondPage, err := repository.GetAllScooters(queryParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		scooters := append(firstPage, secondPage...)
+		if len(scooters) != len(latitudes) {
+			t.Fatalf("expected %d scooters, got %d", len(latitudes), len(scooters))
+		}
+
+		for i := 1; i < len(scooters); i++ {
+			if scooters[i].Location.Latitude > scooters[i-1].Location.Latitude {
+				t.Errorf("expected scooters to be sorted by latitude descending, got %f after %f", scooters[i].Location.Latitude, scooters[i-1].Location.Latitude)
+			}
+
+			if scooters[i].ID == scooters[i-1].ID {
+				t.Errorf("expected scooter %s to be returned once", scooters[i].ID)
+			}
+		}
+	})
+
+	t.Run("When getting scooters by area while given min battery skips scooters below it", func(t *testing.T) {
+		storage := db.NewInMemoryStorage()
+		repository := NewInMemoryRepository(storage)
+
+		charged := types.Scooter{ID: uuid.New(), Location: types.Location{Latitude: 54.68, Longitude: 25.27}, Status: enums.AvailableScooter, BatteryLevel: 80}
+		drained := types.Scooter{ID: uuid.New(), Location: types.Location{Latitude: 54.69, Longitude: 25.28}, Status: enums.AvailableScooter, BatteryLevel: 30}
+		for _, scooter := range []types.Scooter{charged, drained} {
+			if err := repository.CreateScooter(scooter); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		queryParams := types.GetScootersQueryParameters{Availability: string(enums.All), X1: 25, X2: 26, Y1: 54, Y2: 55, MinBattery: 50}
+		scooters, err := repository.GetScootersByArea(queryParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(scooters) != 1 || scooters[0].ID != charged.ID {
+			t.Errorf("expected only scooter %s, got %v", charged.ID, scooters)
+		}
+	})
+}
@@ -2,23 +2,32 @@ package scooter
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/nerijusro/scootinAboot/types"
 	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
 )
 
 type ScooterRepository struct {
 	db *sql.DB
 }
 
+var (
+	ErrConcurrentUpdate     = errors.New("scooter was updated by another transaction")
+	ErrDeviceSecretNotFound = errors.New("no device secret was issued for the scooter")
+)
+
+const scooterColumns = "id, latitude, longitude, status, battery_level, odometer_m, firmware_version, last_seen_at, opt_lock_version"
+
 func NewRepository(db *sql.DB) *ScooterRepository {
 	return &ScooterRepository{db: db}
 }
 
 func (r *ScooterRepository) CreateScooter(scooter types.Scooter) error {
-	_, err := r.db.Exec("INSERT INTO scooters (id, longitude, latitude, is_available) VALUES (UUID_TO_BIN(?, false), ?, ?, ?)",
-		scooter.ID.String(), scooter.Location.Longitude, scooter.Location.Latitude, scooter.IsAvailable)
+	_, err := r.db.Exec("INSERT INTO scooters (id, longitude, latitude, status, battery_level, firmware_version) VALUES (UUID_TO_BIN(?, false), ?, ?, ?, ?, ?)",
+		scooter.ID.String(), scooter.Location.Longitude, scooter.Location.Latitude, scooter.Status, scooter.BatteryLevel, scooter.FirmwareVersion)
 	if err != nil {
 		return err
 	}
@@ -29,14 +38,15 @@ func (r *ScooterRepository) CreateScooter(scooter types.Scooter) error {
 func (r *ScooterRepository) GetScootersByArea(queryParams types.GetScootersQueryParameters) ([]*types.Scooter, error) {
 	availabilityFilter := enums.Availability(queryParams.Availability)
 	getScootersByAreaQuery := tryAddingAvailabilityFilter(
-		"SELECT * FROM scooters WHERE latitude >= ? AND latitude <= ? AND longitude >= ? AND longitude <= ?",
+		"SELECT "+scooterColumns+" FROM scooters WHERE latitude >= ? AND latitude <= ? AND longitude >= ? AND longitude <= ? AND battery_level >= ? AND status <> 'retired'",
 		availabilityFilter)
 
 	rows, err := r.db.Query(getScootersByAreaQuery,
-		queryParams.Y1, queryParams.Y2, queryParams.X1, queryParams.X2)
+		queryParams.Y1, queryParams.Y2, queryParams.X1, queryParams.X2, queryParams.MinBattery)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	scooters := make([]*types.Scooter, 0)
 	for rows.Next() {
@@ -48,15 +58,78 @@ func (r *ScooterRepository) GetScootersByArea(queryParams types.GetScootersQuery
 		scooters = append(scooters, scooter)
 	}
 
-	return scooters, nil
+	return scooters, rows.Err()
+}
+
+func (r *ScooterRepository) GetScootersByRadius(queryParams types.GetScootersQueryParameters) ([]*types.NearbyScooter, error) {
+	// The bounding box lets the latitude index narrow the scooters down before distances are calculated
+	minLatitude, maxLatitude, minLongitude, maxLongitude := utils.BoundingBox(
+		types.Location{Latitude: *queryParams.Latitude, Longitude: *queryParams.Longitude}, queryParams.RadiusMeters)
+
+	availabilityFilter := enums.Availability(queryParams.Availability)
+	getScootersByRadiusQuery := tryAddingAvailabilityFilter(
+		"SELECT "+scooterColumns+", ST_Distance_Sphere(POINT(longitude, latitude), POINT(?, ?), ?) AS distance FROM scooters "+
+			"WHERE latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ? AND battery_level >= ? AND status <> 'retired'",
+		availabilityFilter) + " HAVING distance <= ? ORDER BY distance, id LIMIT ?"
+
+	rows, err := r.db.Query(getScootersByRadiusQuery,
+		*queryParams.Longitude, *queryParams.Latitude, utils.EarthRadiusMeters,
+		minLatitude, maxLatitude, minLongitude, maxLongitude,
+		queryParams.MinBattery, queryParams.RadiusMeters, queryParams.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scooters := make([]*types.NearbyScooter, 0)
+	for rows.Next() {
+		var distance float64
+		scooter, _, err := scanRowIntoScooter(rows, &distance)
+		if err != nil {
+			return nil, err
+		}
+
+		scooters = append(scooters, &types.NearbyScooter{Scooter: *scooter, DistanceMeters: distance})
+	}
+
+	return scooters, rows.Err()
 }
 
-func (r *ScooterRepository) GetAllScooters() ([]*types.Scooter, error) {
-	rows, err := r.db.Query("SELECT * FROM scooters")
+func (r *ScooterRepository) GetAllScooters(queryParams types.GetAllScootersQueryParameters) ([]*types.Scooter, error) {
+	sortBy := enums.ScooterSortField(queryParams.SortBy)
+	order := enums.SortOrder(queryParams.Order)
+
+	keysetCondition, args, err := buildKeysetCondition(queryParams.Cursor, sortBy, order)
 	if err != nil {
 		return nil, err
 	}
 
+	direction := "ASC"
+	if order == enums.Descending {
+		direction = "DESC"
+	}
+
+	availabilityFilter := enums.Availability(queryParams.Availability)
+	getAllScootersQuery := tryAddingAvailabilityFilter(
+		"SELECT "+scooterColumns+" FROM scooters WHERE "+keysetCondition,
+		availabilityFilter)
+	if queryParams.Status != "" {
+		getAllScootersQuery += " AND status = ?"
+		args = append(args, queryParams.Status)
+	}
+
+	if sortBy != enums.SortById {
+		getAllScootersQuery += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT ?", sortBy, direction, direction)
+	} else {
+		getAllScootersQuery += fmt.Sprintf(" ORDER BY id %s LIMIT ?", direction)
+	}
+
+	rows, err := r.db.Query(getAllScootersQuery, append(args, queryParams.Limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
 	scooters := make([]*types.Scooter, 0)
 	for rows.Next() {
 		scooter, _, err := scanRowIntoScooter(rows)
@@ -67,11 +140,11 @@ func (r *ScooterRepository) GetAllScooters() ([]*types.Scooter, error) {
 		scooters = append(scooters, scooter)
 	}
 
-	return scooters, nil
+	return scooters, rows.Err()
 }
 
 func (r *ScooterRepository) GetScooterById(id string) (*types.Scooter, *int, error) {
-	rows, err := r.db.Query("SELECT * FROM scooters WHERE id = UUID_TO_BIN(?, false)", id)
+	rows, err := r.db.Query("SELECT "+scooterColumns+" FROM scooters WHERE id = UUID_TO_BIN(?, false)", id)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -92,12 +165,78 @@ func (r *ScooterRepository) GetScooterById(id string) (*types.Scooter, *int, err
 	return scooter, optLockVersion, nil
 }
 
-func scanRowIntoScooter(row *sql.Rows) (*types.Scooter, *int, error) {
+func (r *ScooterRepository) UpdateScooter(scooter types.Scooter, optLockVersion *int) error {
+	result, err := r.db.Exec("UPDATE scooters SET latitude = ?, longitude = ?, status = ?, battery_level = ?, firmware_version = ?, opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ?",
+		scooter.Location.Latitude, scooter.Location.Longitude, scooter.Status, scooter.BatteryLevel, scooter.FirmwareVersion, *optLockVersion, scooter.ID.String(), *optLockVersion)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrConcurrentUpdate
+	}
+
+	return nil
+}
+
+func (r *ScooterRepository) GetDeviceSecretHash(scooterId string) (string, error) {
+	row := r.db.QueryRow("SELECT device_secret_hash FROM scooters WHERE id = UUID_TO_BIN(?, false)", scooterId)
+
+	var secretHash sql.NullString
+	err := row.Scan(&secretHash)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !secretHash.Valid) {
+		return "", ErrDeviceSecretNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return secretHash.String, nil
+}
+
+// SetDeviceSecretHash replaces the previous secret, so that a leaked one can be rotated.
+func (r *ScooterRepository) SetDeviceSecretHash(scooterId string, secretHash string) error {
+	_, err := r.db.Exec("UPDATE scooters SET device_secret_hash = ? WHERE id = UUID_TO_BIN(?, false)", secretHash, scooterId)
+	return err
+}
+
+func (r *ScooterRepository) IsInActiveTrip(id string) (bool, error) {
+	row := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM trips WHERE scooter_id = UUID_TO_BIN(?, false) AND is_finished = false)", id)
+
+	var isInActiveTrip bool
+	if err := row.Scan(&isInActiveTrip); err != nil {
+		return false, err
+	}
+
+	return isInActiveTrip, nil
+}
+
+func (r *ScooterRepository) IsReserved(id string) (bool, error) {
+	row := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM reservations WHERE scooter_id = UUID_TO_BIN(?, false) AND status = ?)", id, enums.ActiveReservation)
+
+	var isReserved bool
+	if err := row.Scan(&isReserved); err != nil {
+		return false, err
+	}
+
+	return isReserved, nil
+}
+
+// scanRowIntoScooter scans the scooter columns followed by any extra columns selected after them.
+func scanRowIntoScooter(row *sql.Rows, extra ...any) (*types.Scooter, *int, error) {
 	var location types.Location
 	var scooter types.Scooter
 	var optLockVersion int
 
-	if err := row.Scan(&scooter.ID, &location.Latitude, &location.Longitude, &scooter.IsAvailable, &optLockVersion); err != nil {
+	destinations := []any{&scooter.ID, &location.Latitude, &location.Longitude, &scooter.Status,
+		&scooter.BatteryLevel, &scooter.OdometerMeters, &scooter.FirmwareVersion, &scooter.LastSeenAt, &optLockVersion}
+	if err := row.Scan(append(destinations, extra...)...); err != nil {
 		return nil, nil, err
 	}
 
@@ -105,13 +244,39 @@ func scanRowIntoScooter(row *sql.Rows) (*types.Scooter, *int, error) {
 	return &scooter, &optLockVersion, nil
 }
 
+// tryAddingAvailabilityFilter keeps the availability filter working on top of statuses: every status but available is unavailable.
 func tryAddingAvailabilityFilter(query string, availability enums.Availability) string {
 	if availability == enums.Available {
-		return query + " AND is_available = true"
+		return query + " AND status = 'available'"
 	}
 	if availability == enums.Unavailable {
-		return query + " AND is_available = false"
+		return query + " AND status <> 'available'"
 	}
 
 	return query
 }
+
+// buildKeysetCondition selects rows strictly after the cursor in the requested ordering.
+// Sort values are cast back to FLOAT so that they compare equal to the stored columns.
+func buildKeysetCondition(encodedCursor string, sortBy enums.ScooterSortField, order enums.SortOrder) (string, []any, error) {
+	if encodedCursor == "" {
+		return "TRUE", []any{}, nil
+	}
+
+	cursor, err := decodeCursor(encodedCursor)
+	if err != nil {
+		return "", nil, err
+	}
+
+	comparison := ">"
+	if order == enums.Descending {
+		comparison = "<"
+	}
+
+	if sortBy == enums.SortById {
+		return fmt.Sprintf("id %s UUID_TO_BIN(?, false)", comparison), []any{cursor.ID.String()}, nil
+	}
+
+	condition := fmt.Sprintf("(%[1]s %[2]s CAST(? AS FLOAT) OR (%[1]s = CAST(? AS FLOAT) AND id %[2]s UUID_TO_BIN(?, false)))", sortBy, comparison)
+	return condition, []any{cursor.Value, cursor.Value, cursor.ID.String()}, nil
+}
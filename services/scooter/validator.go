@@ -5,12 +5,19 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
 )
 
 type ScooterValidator struct{}
 
 var Validator = validator.New()
 
+const (
+	maxScootersPageSize   = 100
+	maxSearchRadiusMeters = 50000
+)
+
 func NewScooterValidator() *ScooterValidator {
 	return &ScooterValidator{}
 }
@@ -28,6 +35,14 @@ func (s *ScooterValidator) ValidateCreateScooterRequest(request *types.CreateSco
 		return errors.New("invalid longitude")
 	}
 
+	if request.BatteryLevel != nil && (*request.BatteryLevel < 0 || *request.BatteryLevel > 100) {
+		return errors.New("invalid battery_level")
+	}
+
+	if request.Status != "" && !isSettableStatus(request.Status) {
+		return errors.New("invalid status")
+	}
+
 	return nil
 }
 
@@ -40,6 +55,14 @@ func (s *ScooterValidator) ValidateGetScootersQueryParameters(queryParams *types
 		return errors.New("invalid availability")
 	}
 
+	if queryParams.MinBattery < 0 || queryParams.MinBattery > 100 {
+		return errors.New("invalid min_battery")
+	}
+
+	if queryParams.IsRadiusSearch() {
+		return validateRadiusSearch(queryParams)
+	}
+
 	if queryParams.X1 < -180 || queryParams.X1 > 180 {
 		return errors.New("invalid X1")
 	}
@@ -67,6 +90,95 @@ func (s *ScooterValidator) ValidateGetScootersQueryParameters(queryParams *types
 	return nil
 }
 
+func (s *ScooterValidator) ValidateUpdateScooterRequest(request *types.UpdateScooterRequest) error {
+	if request.Location == nil && request.Status == nil && request.BatteryLevel == nil && request.FirmwareVersion == nil {
+		return errors.New("nothing to update")
+	}
+
+	if request.Status != nil && !isSettableStatus(*request.Status) {
+		return errors.New("invalid status")
+	}
+
+	if request.BatteryLevel != nil && (*request.BatteryLevel < 0 || *request.BatteryLevel > 100) {
+		return errors.New("invalid battery_level")
+	}
+
+	if request.Location != nil {
+		if request.Location.Latitude < -90 || request.Location.Latitude > 90 {
+			return errors.New("invalid latitude")
+		}
+
+		if request.Location.Longitude < -180 || request.Location.Longitude > 180 {
+			return errors.New("invalid longitude")
+		}
+	}
+
+	return nil
+}
+
+func (s *ScooterValidator) ValidateGetAllScootersQueryParameters(queryParams *types.GetAllScootersQueryParameters) error {
+	if !isValidAvailability(queryParams.Availability) {
+		return errors.New("invalid availability")
+	}
+
+	if queryParams.Status != "" && !utils.IsValidScooterStatus(enums.ScooterStatus(queryParams.Status)) {
+		return errors.New("invalid status")
+	}
+
+	sortBy := enums.ScooterSortField(queryParams.SortBy)
+	if sortBy != enums.SortById && sortBy != enums.SortByLatitude && sortBy != enums.SortByLongitude {
+		return errors.New("invalid sort_by")
+	}
+
+	order := enums.SortOrder(queryParams.Order)
+	if order != enums.Ascending && order != enums.Descending {
+		return errors.New("invalid order")
+	}
+
+	if queryParams.Limit <= 0 || queryParams.Limit > maxScootersPageSize {
+		return errors.New("invalid limit")
+	}
+
+	if queryParams.Cursor == "" {
+		return nil
+	}
+
+	cursor, err := decodeCursor(queryParams.Cursor)
+	if err != nil {
+		return err
+	}
+
+	if cursor.SortBy != sortBy || cursor.Order != order {
+		return errors.New("cursor does not match sort_by and order")
+	}
+
+	return nil
+}
+
+func validateRadiusSearch(queryParams *types.GetScootersQueryParameters) error {
+	if queryParams.X1 != 0 || queryParams.X2 != 0 || queryParams.Y1 != 0 || queryParams.Y2 != 0 {
+		return errors.New("area and radius search can not be combined")
+	}
+
+	if queryParams.Latitude == nil || *queryParams.Latitude < -90 || *queryParams.Latitude > 90 {
+		return errors.New("invalid lat")
+	}
+
+	if queryParams.Longitude == nil || *queryParams.Longitude < -180 || *queryParams.Longitude > 180 {
+		return errors.New("invalid lng")
+	}
+
+	if queryParams.RadiusMeters <= 0 || queryParams.RadiusMeters > maxSearchRadiusMeters {
+		return errors.New("invalid radius_m")
+	}
+
+	if queryParams.Limit < 0 || queryParams.Limit > maxScootersPageSize {
+		return errors.New("invalid limit")
+	}
+
+	return nil
+}
+
 func isValidAvailability(availability string) bool {
 	validAvailabilities := map[string]bool{
 		"available":   true,
@@ -76,3 +188,9 @@ func isValidAvailability(availability string) bool {
 	_, ok := validAvailabilities[availability]
 	return ok
 }
+
+// isSettableStatus tells whether operators may set the status directly. Trips and reservations manage their own statuses,
+// maintenance is entered through tickets and retiring has an endpoint of its own.
+func isSettableStatus(status enums.ScooterStatus) bool {
+	return status == enums.AvailableScooter || status == enums.ChargingScooter
+}
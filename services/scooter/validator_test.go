@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
 )
 
 func TestScooterValidator(t *testing.T) {
@@ -11,8 +12,8 @@ func TestScooterValidator(t *testing.T) {
 
 	t.Run("When validating create scooter request while given valid request body returns nil", func(t *testing.T) {
 		requestBody := types.CreateScooterRequest{
-			Location:    types.Location{Latitude: 54.12, Longitude: 25.34},
-			IsAvailable: true,
+			Location: types.Location{Latitude: 54.12, Longitude: 25.34},
+			Status:   enums.AvailableScooter,
 		}
 
 		result := validator.ValidateCreateScooterRequest(&requestBody)
@@ -23,8 +24,8 @@ func TestScooterValidator(t *testing.T) {
 
 	t.Run("When validating create scooter request while given invalid latitude returns error", func(t *testing.T) {
 		requestBody := types.CreateScooterRequest{
-			Location:    types.Location{Latitude: 1234.12, Longitude: 25.34},
-			IsAvailable: true,
+			Location: types.Location{Latitude: 1234.12, Longitude: 25.34},
+			Status:   enums.AvailableScooter,
 		}
 
 		result := validator.ValidateCreateScooterRequest(&requestBody)
@@ -35,8 +36,8 @@ func TestScooterValidator(t *testing.T) {
 
 	t.Run("When validating create scooter request while given invalid longitude returns error", func(t *testing.T) {
 		requestBody := types.CreateScooterRequest{
-			Location:    types.Location{Latitude: 13.12, Longitude: 3325.34},
-			IsAvailable: true,
+			Location: types.Location{Latitude: 13.12, Longitude: 3325.34},
+			Status:   enums.AvailableScooter,
 		}
 
 		result := validator.ValidateCreateScooterRequest(&requestBody)
@@ -60,6 +61,22 @@ func TestScooterValidator(t *testing.T) {
 		}
 	})
 
+	t.Run("When validating get scooters query while given invalid min battery returns error", func(t *testing.T) {
+		requestBody := types.GetScootersQueryParameters{
+			Availability: "available",
+			X1:           25.0,
+			X2:           26.0,
+			Y1:           54.0,
+			Y2:           55.0,
+			MinBattery:   101,
+		}
+
+		result := validator.ValidateGetScootersQueryParameters(&requestBody)
+		if result == nil || result.Error() != "invalid min_battery" {
+			t.Errorf("expected result to be invalid min_battery, got %v", result)
+		}
+	})
+
 	t.Run("When validating get scooters query while given invalid availablity returns error", func(t *testing.T) {
 		requestBody := types.GetScootersQueryParameters{
 			Availability: "nothing",
@@ -164,4 +181,250 @@ func TestScooterValidator(t *testing.T) {
 			t.Errorf("expected result to be y1 must be less than y2, got %s", result.Error())
 		}
 	})
+
+	t.Run("When validating update scooter request while given valid location returns nil", func(t *testing.T) {
+		requestBody := types.UpdateScooterRequest{
+			Location: &types.Location{Latitude: 54.12, Longitude: 25.34},
+		}
+
+		result := validator.ValidateUpdateScooterRequest(&requestBody)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating update scooter request while nothing is given returns error", func(t *testing.T) {
+		requestBody := types.UpdateScooterRequest{}
+
+		result := validator.ValidateUpdateScooterRequest(&requestBody)
+		if result.Error() != "nothing to update" {
+			t.Errorf("expected result to be nothing to update, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating update scooter request while given invalid battery level returns error", func(t *testing.T) {
+		batteryLevel := -1
+		requestBody := types.UpdateScooterRequest{
+			BatteryLevel: &batteryLevel,
+		}
+
+		result := validator.ValidateUpdateScooterRequest(&requestBody)
+		if result == nil || result.Error() != "invalid battery_level" {
+			t.Errorf("expected result to be invalid battery_level, got %v", result)
+		}
+	})
+
+	t.Run("When validating update scooter request while given invalid latitude returns error", func(t *testing.T) {
+		requestBody := types.UpdateScooterRequest{
+			Location: &types.Location{Latitude: 1234.12, Longitude: 25.34},
+		}
+
+		result := validator.ValidateUpdateScooterRequest(&requestBody)
+		if result.Error() != "invalid latitude" {
+			t.Errorf("expected result to be invalid latitude, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating update scooter request while given invalid longitude returns error", func(t *testing.T) {
+		requestBody := types.UpdateScooterRequest{
+			Location: &types.Location{Latitude: 13.12, Longitude: 3325.34},
+		}
+
+		result := validator.ValidateUpdateScooterRequest(&requestBody)
+		if result.Error() != "invalid longitude" {
+			t.Errorf("expected result to be invalid longitude, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create scooter request while given status that can not be set returns error", func(t *testing.T) {
+		requestBody := types.CreateScooterRequest{
+			Location: types.Location{Latitude: 54.12, Longitude: 25.34},
+			Status:   enums.MaintenanceScooter,
+		}
+
+		result := validator.ValidateCreateScooterRequest(&requestBody)
+		if result == nil || result.Error() != "invalid status" {
+			t.Errorf("expected result to be invalid status, got %v", result)
+		}
+	})
+
+	t.Run("When validating update scooter request while given status that can not be set returns error", func(t *testing.T) {
+		status := enums.InTripScooter
+		requestBody := types.UpdateScooterRequest{
+			Status: &status,
+		}
+
+		result := validator.ValidateUpdateScooterRequest(&requestBody)
+		if result == nil || result.Error() != "invalid status" {
+			t.Errorf("expected result to be invalid status, got %v", result)
+		}
+	})
+
+	t.Run("When validating get all scooters query while given invalid status returns error", func(t *testing.T) {
+		queryParams := types.GetAllScootersQueryParameters{
+			Availability: "all",
+			Status:       "broken",
+			SortBy:       "created_at",
+			Order:        "asc",
+			Limit:        10,
+		}
+
+		result := validator.ValidateGetAllScootersQueryParameters(&queryParams)
+		if result == nil || result.Error() != "invalid status" {
+			t.Errorf("expected result to be invalid status, got %v", result)
+		}
+	})
+
+	t.Run("When validating get scooters query while given valid radius search returns nil", func(t *testing.T) {
+		latitude, longitude := 54.68, 25.28
+		requestBody := types.GetScootersQueryParameters{
+			Availability: "available",
+			Latitude:     &latitude,
+			Longitude:    &longitude,
+			RadiusMeters: 500,
+			Limit:        10,
+		}
+
+		result := validator.ValidateGetScootersQueryParameters(&requestBody)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get scooters query while radius search is missing lng returns error", func(t *testing.T) {
+		latitude := 54.68
+		requestBody := types.GetScootersQueryParameters{
+			Availability: "available",
+			Latitude:     &latitude,
+			RadiusMeters: 500,
+		}
+
+		result := validator.ValidateGetScootersQueryParameters(&requestBody)
+		if result.Error() != "invalid lng" {
+			t.Errorf("expected result to be invalid lng, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get scooters query while given invalid radius returns error", func(t *testing.T) {
+		latitude, longitude := 54.68, 25.28
+		requestBody := types.GetScootersQueryParameters{
+			Availability: "available",
+			Latitude:     &latitude,
+			Longitude:    &longitude,
+			RadiusMeters: -5,
+		}
+
+		result := validator.ValidateGetScootersQueryParameters(&requestBody)
+		if result.Error() != "invalid radius_m" {
+			t.Errorf("expected result to be invalid radius_m, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get scooters query while given invalid limit returns error", func(t *testing.T) {
+		latitude, longitude := 54.68, 25.28
+		requestBody := types.GetScootersQueryParameters{
+			Availability: "available",
+			Latitude:     &latitude,
+			Longitude:    &longitude,
+			RadiusMeters: 500,
+			Limit:        1000,
+		}
+
+		result := validator.ValidateGetScootersQueryParameters(&requestBody)
+		if result.Error() != "invalid limit" {
+			t.Errorf("expected result to be invalid limit, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get scooters query while area and radius are combined returns error", func(t *testing.T) {
+		latitude, longitude := 54.68, 25.28
+		requestBody := types.GetScootersQueryParameters{
+			Availability: "available",
+			X1:           25.0,
+			X2:           26.0,
+			Y1:           54.0,
+			Y2:           55.0,
+			Latitude:     &latitude,
+			Longitude:    &longitude,
+			RadiusMeters: 500,
+		}
+
+		result := validator.ValidateGetScootersQueryParameters(&requestBody)
+		if result.Error() != "area and radius search can not be combined" {
+			t.Errorf("expected result to be area and radius search can not be combined, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get all scooters query while given valid params returns nil", func(t *testing.T) {
+		queryParams := types.GetAllScootersQueryParameters{
+			Availability: "all",
+			SortBy:       "latitude",
+			Order:        "desc",
+			Limit:        10,
+		}
+
+		result := validator.ValidateGetAllScootersQueryParameters(&queryParams)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get all scooters query while given invalid sort_by returns error", func(t *testing.T) {
+		queryParams := types.GetAllScootersQueryParameters{
+			Availability: "all",
+			SortBy:       "battery",
+			Order:        "asc",
+			Limit:        10,
+		}
+
+		result := validator.ValidateGetAllScootersQueryParameters(&queryParams)
+		if result.Error() != "invalid sort_by" {
+			t.Errorf("expected result to be invalid sort_by, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get all scooters query while given invalid order returns error", func(t *testing.T) {
+		queryParams := types.GetAllScootersQueryParameters{
+			Availability: "all",
+			SortBy:       "id",
+			Order:        "sideways",
+			Limit:        10,
+		}
+
+		result := validator.ValidateGetAllScootersQueryParameters(&queryParams)
+		if result.Error() != "invalid order" {
+			t.Errorf("expected result to be invalid order, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get all scooters query while given malformed cursor returns error", func(t *testing.T) {
+		queryParams := types.GetAllScootersQueryParameters{
+			Availability: "all",
+			SortBy:       "id",
+			Order:        "asc",
+			Limit:        10,
+			Cursor:       "not a cursor",
+		}
+
+		result := validator.ValidateGetAllScootersQueryParameters(&queryParams)
+		if result.Error() != "invalid cursor" {
+			t.Errorf("expected result to be invalid cursor, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get all scooters query while cursor was issued for another sorting returns error", func(t *testing.T) {
+		scooter := types.Scooter{Location: types.Location{Latitude: 54.12, Longitude: 25.34}}
+		queryParams := types.GetAllScootersQueryParameters{
+			Availability: "all",
+			SortBy:       "longitude",
+			Order:        "asc",
+			Limit:        10,
+			Cursor:       encodeCursor(&scooter, enums.SortByLatitude, enums.Ascending),
+		}
+
+		result := validator.ValidateGetAllScootersQueryParameters(&queryParams)
+		if result.Error() != "cursor does not match sort_by and order" {
+			t.Errorf("expected result to be cursor does not match sort_by and order, got %s", result.Error())
+		}
+	})
 }
@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+type TelemetryHandler struct {
+	repository          interfaces.TelemetryRepository
+	scootersRepository  interfaces.ScooterRepository
+	validator           interfaces.TelemetryValidator
+	lowBatteryThreshold int
+}
+
+func NewTelemetryHandler(
+	repository interfaces.TelemetryRepository,
+	scootersRepository interfaces.ScooterRepository,
+	validator interfaces.TelemetryValidator,
+	lowBatteryThreshold int) *TelemetryHandler {
+	return &TelemetryHandler{
+		repository:          repository,
+		scootersRepository:  scootersRepository,
+		validator:           validator,
+		lowBatteryThreshold: lowBatteryThreshold,
+	}
+}
+
+func (h *TelemetryHandler) RegisterEndpoints(routes interfaces.Routes) {
+	routes.Device().POST("/telemetry", h.recordTelemetry)
+}
+
+func (h *TelemetryHandler) recordTelemetry(c *gin.Context) {
+	scooterId, err := uuid.Parse(c.GetString(utils.ScooterIdKey))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "missing device credentials"})
+		return
+	}
+
+	var request types.TelemetryRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateTelemetryRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	pings := make([]types.TelemetryPing, 0, len(request.Pings))
+	for _, ping := range request.Pings {
+		pings = append(pings, types.TelemetryPing{
+			ScooterID:    scooterId,
+			Location:     ping.Location,
+			BatteryLevel: ping.BatteryLevel,
+			IsLocked:     ping.IsLocked,
+			RecordedAt:   ping.RecordedAt,
+		})
+	}
+
+	// Devices buffer pings while offline, so a batch is not guaranteed to be in order
+	sort.SliceStable(pings, func(i, j int) bool {
+		return pings[i].RecordedAt.Before(pings[j].RecordedAt)
+	})
+
+	storedScooter, scooterOptLockVersion, err := h.scootersRepository.GetScooterById(scooterId.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting scooter by id"})
+		return
+	}
+
+	updatedScooter, err := h.applyPings(storedScooter, pings)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error checking active trips"})
+		return
+	}
+
+	err = h.repository.RecordTelemetry(pings, updatedScooter, scooterOptLockVersion)
+	if errors.Is(err, scooter.ErrConcurrentUpdate) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "telemetry could not be recorded"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.TelemetryResponse{Accepted: len(pings), ScooterUpdated: updatedScooter != nil})
+}
+
+// applyPings moves the scooter to the latest of the pings ordered by recording time and takes the latest battery level
+// reported among them. It returns nil when the scooter must be left as it is: retired scooters, scooters in a trip,
+// whose location is reported by the rider, and scooters which were already seen after the pings were recorded.
+func (h *TelemetryHandler) applyPings(scooter *types.Scooter, pings []types.TelemetryPing) (*types.Scooter, error) {
+	latest := pings[len(pings)-1]
+	if scooter.Status == enums.RetiredScooter || (scooter.LastSeenAt != nil && !latest.RecordedAt.After(*scooter.LastSeenAt)) {
+		return nil, nil
+	}
+
+	isInActiveTrip, err := h.scootersRepository.IsInActiveTrip(scooter.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if isInActiveTrip {
+		return nil, nil
+	}
+
+	scooter.Location = latest.Location
+	lastSeenAt := latest.RecordedAt
+	scooter.LastSeenAt = &lastSeenAt
+
+	for _, ping := range pings {
+		if ping.BatteryLevel != nil {
+			scooter.BatteryLevel = *ping.BatteryLevel
+		}
+	}
+
+	// Only idle scooters are taken out of service, reserved ones are released by their reservation
+	if scooter.Status == enums.AvailableScooter {
+		scooter.Status = utils.IdleScooterStatus(scooter.BatteryLevel, h.lowBatteryThreshold)
+	}
+
+	return scooter, nil
+}
@@ -0,0 +1,249 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+func TestTelemetryHandler(t *testing.T) {
+	serveTelemetryRequest := func(handler *TelemetryHandler, scooterId string, requestBody types.TelemetryRequest) *httptest.ResponseRecorder {
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/device/telemetry", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/device/telemetry", func(c *gin.Context) {
+			c.Set(utils.ScooterIdKey, scooterId)
+			c.Next()
+		}, handler.recordTelemetry)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+		return responseRecoreder
+	}
+
+	now := time.Now()
+
+	t.Run("When recording telemetry while scooter is idle returns ok and moves scooter to latest ping", func(t *testing.T) {
+		telemetryRepository := &mockTelemetryRepository{}
+		handler := NewTelemetryHandler(telemetryRepository, &mockScooterRepository{}, &mockTelemetryValidator{}, 15)
+		batteryLevel := 70
+
+		// Pings arrive out of order, the latest one must win
+		responseRecoreder := serveTelemetryRequest(handler, "e3344268-d649-4c19-a20c-a0c64a5a6623", types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 54.69, Longitude: 25.29}, RecordedAt: now.Add(-time.Minute)},
+			{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, BatteryLevel: &batteryLevel, RecordedAt: now.Add(-2 * time.Minute)},
+		}})
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.TelemetryResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Accepted != 2 || !response.ScooterUpdated {
+			t.Errorf("expected 2 accepted pings and updated scooter, got %d and %t", response.Accepted, response.ScooterUpdated)
+		}
+
+		updatedScooter := telemetryRepository.updatedScooter
+		if updatedScooter == nil {
+			t.Fatal("expected scooter to be updated")
+		}
+
+		if updatedScooter.Location.Latitude != 54.69 {
+			t.Errorf("expected latitude to be 54.69, got %f", updatedScooter.Location.Latitude)
+		}
+
+		if updatedScooter.BatteryLevel != batteryLevel {
+			t.Errorf("expected battery level to be %d, got %d", batteryLevel, updatedScooter.BatteryLevel)
+		}
+
+		if updatedScooter.Status != enums.AvailableScooter {
+			t.Errorf("expected scooter to stay available")
+		}
+	})
+
+	t.Run("When recording telemetry while battery is low returns ok and leaves scooter charging", func(t *testing.T) {
+		telemetryRepository := &mockTelemetryRepository{}
+		handler := NewTelemetryHandler(telemetryRepository, &mockScooterRepository{}, &mockTelemetryValidator{}, 15)
+		batteryLevel := 10
+
+		responseRecoreder := serveTelemetryRequest(handler, "e3344268-d649-4c19-a20c-a0c64a5a6623", types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, BatteryLevel: &batteryLevel, RecordedAt: now.Add(-time.Minute)},
+		}})
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		if telemetryRepository.updatedScooter == nil || telemetryRepository.updatedScooter.Status != enums.ChargingScooter {
+			t.Errorf("expected scooter to be updated and charging")
+		}
+	})
+
+	t.Run("When recording telemetry while scooter is in an active trip returns ok without updating scooter", func(t *testing.T) {
+		telemetryRepository := &mockTelemetryRepository{}
+		handler := NewTelemetryHandler(telemetryRepository, &mockScooterRepository{}, &mockTelemetryValidator{}, 15)
+
+		responseRecoreder := serveTelemetryRequest(handler, "e3344268-d649-4c19-a20c-a0c64a5a1111", types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, RecordedAt: now.Add(-time.Minute)},
+		}})
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		if telemetryRepository.updatedScooter != nil {
+			t.Errorf("expected scooter not to be updated")
+		}
+
+		if telemetryRepository.recordedPings != 1 {
+			t.Errorf("expected 1 ping to be recorded, got %d", telemetryRepository.recordedPings)
+		}
+	})
+
+	t.Run("When recording telemetry while pings are older than last seen time returns ok without updating scooter", func(t *testing.T) {
+		telemetryRepository := &mockTelemetryRepository{}
+		handler := NewTelemetryHandler(telemetryRepository, &mockScooterRepository{}, &mockTelemetryValidator{}, 15)
+
+		responseRecoreder := serveTelemetryRequest(handler, "e3344268-d649-4c19-a20c-a0c64a5a6623", types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, RecordedAt: now.Add(-time.Hour)},
+		}})
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		if telemetryRepository.updatedScooter != nil {
+			t.Errorf("expected scooter not to be updated")
+		}
+	})
+
+	t.Run("When recording telemetry while scooter was updated concurrently returns conflict", func(t *testing.T) {
+		handler := NewTelemetryHandler(&mockTelemetryRepository{}, &mockScooterRepository{}, &mockTelemetryValidator{}, 15)
+
+		responseRecoreder := serveTelemetryRequest(handler, "e3344268-d649-4c19-a20c-a0c64a5a3333", types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, RecordedAt: now.Add(-time.Minute)},
+		}})
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When recording telemetry while request is invalid returns bad request", func(t *testing.T) {
+		handler := NewTelemetryHandler(&mockTelemetryRepository{}, &mockScooterRepository{}, &mockTelemetryValidator{}, 15)
+
+		responseRecoreder := serveTelemetryRequest(handler, "e3344268-d649-4c19-a20c-a0c64a5a6623", types.TelemetryRequest{Pings: []types.TelemetryPingRequest{}})
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When recording telemetry while device is not authenticated returns unauthorized", func(t *testing.T) {
+		handler := NewTelemetryHandler(&mockTelemetryRepository{}, &mockScooterRepository{}, &mockTelemetryValidator{}, 15)
+
+		responseRecoreder := serveTelemetryRequest(handler, "", types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, RecordedAt: now.Add(-time.Minute)},
+		}})
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+}
+
+type mockTelemetryValidator struct{}
+
+func (m *mockTelemetryValidator) ValidateTelemetryRequest(request *types.TelemetryRequest) error {
+	if len(request.Pings) == 0 {
+		return errors.New("invalid number of pings")
+	}
+
+	return nil
+}
+
+type mockTelemetryRepository struct {
+	updatedScooter *types.Scooter
+	recordedPings  int
+}
+
+func (m *mockTelemetryRepository) RecordTelemetry(pings []types.TelemetryPing, updatedScooter *types.Scooter, scooterOptLockVersion *int) error {
+	if updatedScooter != nil && updatedScooter.ID.String() == "e3344268-d649-4c19-a20c-a0c64a5a3333" {
+		return scooter.ErrConcurrentUpdate
+	}
+
+	m.updatedScooter = updatedScooter
+	m.recordedPings = len(pings)
+	return nil
+}
+
+type mockScooterRepository struct{}
+
+func (m *mockScooterRepository) GetScooterById(id string) (*types.Scooter, *int, error) {
+	idInUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil, errors.New("issue while getting scooter by id")
+	}
+
+	lastSeenAt := time.Now().Add(-10 * time.Minute)
+	return &types.Scooter{
+		ID:           idInUUID,
+		Location:     types.Location{Latitude: 54.6, Longitude: 25.2},
+		Status:       enums.AvailableScooter,
+		BatteryLevel: 80,
+		LastSeenAt:   &lastSeenAt,
+	}, new(int), nil
+}
+
+// GetAllScooters implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) GetAllScooters(queryParams types.GetAllScootersQueryParameters) ([]*types.Scooter, error) {
+	panic("unimplemented")
+}
+
+// GetScootersByArea implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) GetScootersByArea(queryParams types.GetScootersQueryParameters) ([]*types.Scooter, error) {
+	panic("unimplemented")
+}
+
+// GetScootersByRadius implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) GetScootersByRadius(queryParams types.GetScootersQueryParameters) ([]*types.NearbyScooter, error) {
+	panic("unimplemented")
+}
+
+// CreateScooter implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) CreateScooter(scooter types.Scooter) error {
+	panic("unimplemented")
+}
+
+// UpdateScooter implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) UpdateScooter(scooter types.Scooter, optLockVersion *int) error {
+	panic("unimplemented")
+}
+
+func (m *mockScooterRepository) IsInActiveTrip(id string) (bool, error) {
+	return id == "e3344268-d649-4c19-a20c-a0c64a5a1111", nil
+}
+
+// IsReserved implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) IsReserved(id string) (bool, error) {
+	panic("unimplemented")
+}
@@ -0,0 +1,49 @@
+package telemetry
+
+import (
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type InMemoryTelemetryRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryTelemetryRepository {
+	return &InMemoryTelemetryRepository{storage: storage}
+}
+
+func (r *InMemoryTelemetryRepository) RecordTelemetry(pings []types.TelemetryPing, updatedScooter *types.Scooter, scooterOptLockVersion *int) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if updatedScooter != nil {
+		record, ok := r.storage.Scooters[updatedScooter.ID.String()]
+		if !ok || record.OptLockVersion != *scooterOptLockVersion {
+			return scooter.ErrConcurrentUpdate
+		}
+
+		record.Value = *updatedScooter
+		record.OptLockVersion++
+	}
+
+	for _, ping := range pings {
+		if !r.isRecorded(ping) {
+			r.storage.Telemetry = append(r.storage.Telemetry, ping)
+		}
+	}
+
+	return nil
+}
+
+// isRecorded mimics the unique key on scooter and recording time, the caller must hold the lock.
+func (r *InMemoryTelemetryRepository) isRecorded(ping types.TelemetryPing) bool {
+	for _, recorded := range r.storage.Telemetry {
+		if recorded.ScooterID == ping.ScooterID && recorded.RecordedAt.Equal(ping.RecordedAt) {
+			return true
+		}
+	}
+
+	return false
+}
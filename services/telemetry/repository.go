@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"database/sql"
+
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type TelemetryRepository struct {
+	db *sql.DB
+}
+
+// Pings are unique per scooter and recording time, so that a batch retried by the device is not stored twice.
+var recordPingQuery = "INSERT IGNORE INTO scooter_telemetry (scooter_id, latitude, longitude, battery_level, is_locked, recorded_at) VALUES (UUID_TO_BIN(?, false), ?, ?, ?, ?, ?)"
+var updateScooterQuery = "UPDATE scooters SET latitude = ?, longitude = ?, status = ?, battery_level = ?, last_seen_at = ?, opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ?"
+
+func NewRepository(db *sql.DB) *TelemetryRepository {
+	return &TelemetryRepository{db: db}
+}
+
+func (r *TelemetryRepository) RecordTelemetry(pings []types.TelemetryPing, updatedScooter *types.Scooter, scooterOptLockVersion *int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, ping := range pings {
+		_, err = tx.Exec(recordPingQuery, ping.ScooterID.String(), ping.Location.Latitude, ping.Location.Longitude, ping.BatteryLevel, ping.IsLocked, ping.RecordedAt)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if updatedScooter != nil {
+		err = r.updateScooter(tx, updatedScooter, scooterOptLockVersion)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TelemetryRepository) updateScooter(tx *sql.Tx, updatedScooter *types.Scooter, optLockVersion *int) error {
+	result, err := tx.Exec(updateScooterQuery, updatedScooter.Location.Latitude, updatedScooter.Location.Longitude, updatedScooter.Status,
+		updatedScooter.BatteryLevel, updatedScooter.LastSeenAt, *optLockVersion, updatedScooter.ID.String(), *optLockVersion)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return scooter.ErrConcurrentUpdate
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package telemetry
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type TelemetryValidator struct{}
+
+var Validator = validator.New()
+
+const maxPingsPerBatch = 100
+
+// maxClockSkew is how far ahead of the server the clock of a device may run, so a whole batch is not lost to a slightly fast clock.
+const maxClockSkew = time.Minute
+
+func NewTelemetryValidator() *TelemetryValidator {
+	return &TelemetryValidator{}
+}
+
+func (v *TelemetryValidator) ValidateTelemetryRequest(request *types.TelemetryRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	if len(request.Pings) == 0 || len(request.Pings) > maxPingsPerBatch {
+		return errors.New("invalid number of pings")
+	}
+
+	for _, ping := range request.Pings {
+		if err := validatePing(&ping); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validatePing(ping *types.TelemetryPingRequest) error {
+	if err := Validator.Struct(ping); err != nil {
+		return err
+	}
+
+	if ping.Location.Latitude < -90 || ping.Location.Latitude > 90 {
+		return errors.New("invalid latitude")
+	}
+
+	if ping.Location.Longitude < -180 || ping.Location.Longitude > 180 {
+		return errors.New("invalid longitude")
+	}
+
+	if ping.BatteryLevel != nil && (*ping.BatteryLevel < 0 || *ping.BatteryLevel > 100) {
+		return errors.New("invalid battery_level")
+	}
+
+	if ping.RecordedAt.After(time.Now().Add(maxClockSkew)) {
+		return errors.New("invalid recorded_at")
+	}
+
+	return nil
+}
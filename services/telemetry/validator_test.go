@@ -0,0 +1,94 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+func TestTelemetryValidator(t *testing.T) {
+	validator := NewTelemetryValidator()
+	recordedAt := time.Now().Add(-time.Minute)
+
+	t.Run("When validating telemetry request while given valid pings returns nil", func(t *testing.T) {
+		batteryLevel := 55
+		request := types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, RecordedAt: recordedAt},
+			{Location: types.Location{Latitude: 54.69, Longitude: 25.29}, BatteryLevel: &batteryLevel, RecordedAt: recordedAt.Add(time.Second)},
+		}}
+
+		result := validator.ValidateTelemetryRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating telemetry request while no pings are given returns error", func(t *testing.T) {
+		request := types.TelemetryRequest{Pings: []types.TelemetryPingRequest{}}
+
+		result := validator.ValidateTelemetryRequest(&request)
+		if result == nil || result.Error() != "invalid number of pings" {
+			t.Errorf("expected result to be: invalid number of pings, got %v", result)
+		}
+	})
+
+	t.Run("When validating telemetry request while too many pings are given returns error", func(t *testing.T) {
+		pings := make([]types.TelemetryPingRequest, maxPingsPerBatch+1)
+		for i := range pings {
+			pings[i] = types.TelemetryPingRequest{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, RecordedAt: recordedAt}
+		}
+		request := types.TelemetryRequest{Pings: pings}
+
+		result := validator.ValidateTelemetryRequest(&request)
+		if result == nil || result.Error() != "invalid number of pings" {
+			t.Errorf("expected result to be: invalid number of pings, got %v", result)
+		}
+	})
+
+	t.Run("When validating telemetry request while latitude is out of range returns error", func(t *testing.T) {
+		request := types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 91, Longitude: 25.28}, RecordedAt: recordedAt},
+		}}
+
+		result := validator.ValidateTelemetryRequest(&request)
+		if result == nil || result.Error() != "invalid latitude" {
+			t.Errorf("expected result to be: invalid latitude, got %v", result)
+		}
+	})
+
+	t.Run("When validating telemetry request while battery level is out of range returns error", func(t *testing.T) {
+		batteryLevel := 101
+		request := types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, BatteryLevel: &batteryLevel, RecordedAt: recordedAt},
+		}}
+
+		result := validator.ValidateTelemetryRequest(&request)
+		if result == nil || result.Error() != "invalid battery_level" {
+			t.Errorf("expected result to be: invalid battery_level, got %v", result)
+		}
+	})
+
+	t.Run("When validating telemetry request while device clock is slightly ahead returns nil", func(t *testing.T) {
+		request := types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, RecordedAt: recordedAt},
+			{Location: types.Location{Latitude: 54.69, Longitude: 25.29}, RecordedAt: time.Now().Add(10 * time.Second)},
+		}}
+
+		result := validator.ValidateTelemetryRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating telemetry request while ping is recorded in the future returns error", func(t *testing.T) {
+		request := types.TelemetryRequest{Pings: []types.TelemetryPingRequest{
+			{Location: types.Location{Latitude: 54.68, Longitude: 25.28}, RecordedAt: time.Now().Add(time.Hour)},
+		}}
+
+		result := validator.ValidateTelemetryRequest(&request)
+		if result == nil || result.Error() != "invalid recorded_at" {
+			t.Errorf("expected result to be: invalid recorded_at, got %v", result)
+		}
+	})
+}
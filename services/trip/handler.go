@@ -2,41 +2,78 @@ package trip
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/services/reservation"
+	"github.com/nerijusro/scootinAboot/services/zone"
 	"github.com/nerijusro/scootinAboot/types"
 	"github.com/nerijusro/scootinAboot/types/enums"
 	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"github.com/nerijusro/scootinAboot/utils"
 )
 
+const defaultTripsPageSize = 20
+
+// pausedLocationToleranceMeters absorbs GPS drift of a parked scooter, moving further while paused would not be charged.
+const pausedLocationToleranceMeters = 50.0
+
+// maxEventDelay is how long the rider's app may take to deliver an event, e.g. while offline.
+// Events reported as older are moved forward, so a backdated end event can not shorten the charged time.
+const maxEventDelay = 5 * time.Minute
+
 type TripHandler struct {
-	validator          interfaces.TripValidator
-	tripsReposiotry    interfaces.TripRepository
-	scootersRepository interfaces.ScooterRepository
-	usersRepository    interfaces.ClientRepository
+	validator           interfaces.TripValidator
+	tripsReposiotry     interfaces.TripRepository
+	scootersRepository  interfaces.ScooterRepository
+	usersRepository     interfaces.ClientRepository
+	reservations        interfaces.ReservationRepository
+	geofence            interfaces.Geofence
+	fareCalculator      interfaces.FareCalculator
+	lowBatteryThreshold int
 }
 
 func NewTripHandler(
 	validator interfaces.TripValidator,
 	tripsRepository interfaces.TripRepository,
 	scootersRepository interfaces.ScooterRepository,
-	usersRepository interfaces.ClientRepository) *TripHandler {
-	return &TripHandler{validator: validator, tripsReposiotry: tripsRepository, scootersRepository: scootersRepository, usersRepository: usersRepository}
+	usersRepository interfaces.ClientRepository,
+	reservations interfaces.ReservationRepository,
+	geofence interfaces.Geofence,
+	fareCalculator interfaces.FareCalculator,
+	lowBatteryThreshold int) *TripHandler {
+	return &TripHandler{
+		validator:           validator,
+		tripsReposiotry:     tripsRepository,
+		scootersRepository:  scootersRepository,
+		usersRepository:     usersRepository,
+		reservations:        reservations,
+		geofence:            geofence,
+		fareCalculator:      fareCalculator,
+		lowBatteryThreshold: lowBatteryThreshold,
+	}
 }
 
-func (h *TripHandler) RegisterEndpoints(routerGroups map[string]*gin.RouterGroup) {
-	userAuthorized := routerGroups["client"]
-	userAuthorized.POST("/trips", h.startTrip)
+func (h *TripHandler) RegisterEndpoints(routes interfaces.Routes) {
+	routes.Admin(enums.ReadTrips).GET("/trips/:id", h.getAnyTrip)
+	routes.Admin(enums.WriteTrips).POST("/trips/:id/end", h.forceEndTrip)
+	routes.Admin(enums.IssueRefunds).POST("/trips/:id/cancel", h.cancelTrip)
+
+	routes.IdempotentClient().POST("/trips", h.startTrip)
+
+	userAuthorized := routes.Client()
+	userAuthorized.GET("/trips", h.getTrips)
+	userAuthorized.GET("/trips/:id", h.getTrip)
 	userAuthorized.PUT("/trips/:id", h.updateTrip)
 }
 
 func (h *TripHandler) startTrip(c *gin.Context) {
-	clientId := c.GetHeader("Client-Id")
-	_, err := uuid.Parse(clientId)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": err.Error()})
+	clientId := c.GetString(utils.ClientIdKey)
+	if clientId == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "missing client token"})
 		return
 	}
 
@@ -63,7 +100,13 @@ func (h *TripHandler) startTrip(c *gin.Context) {
 		return
 	}
 
-	if err := h.validateTripStart(scooter, user); err != nil {
+	reservation, err := h.getActiveReservation(clientId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting reservation"})
+		return
+	}
+
+	if err := h.validateTripStart(scooter, user, reservation); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error(), "message": "trip cannot be started due to parameter invalidity"})
 		return
 	}
@@ -75,14 +118,20 @@ func (h *TripHandler) startTrip(c *gin.Context) {
 	}
 
 	startTripEvent := types.TripEvent{
-		TripID:    trip.ID,
-		Type:      enums.StartTrip,
-		Location:  scooter.Location,
-		CreatedAt: startTripRequest.CreatedAt,
-		Sequence:  1,
+		TripID:     trip.ID,
+		Type:       enums.StartTrip,
+		Location:   scooter.Location,
+		CreatedAt:  startTripRequest.CreatedAt,
+		ReceivedAt: time.Now().UTC(),
+		Sequence:   1,
+	}
+
+	err = h.tripsReposiotry.StartTrip(trip, reservation, scooterOptLockVersion, userOptLockVersion, startTripEvent)
+	if errors.Is(err, ErrReservationNotActive) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
 	}
 
-	err = h.tripsReposiotry.StartTrip(trip, scooterOptLockVersion, userOptLockVersion, startTripEvent)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "trip could not be started"})
 		return
@@ -99,10 +148,9 @@ func (h *TripHandler) updateTrip(c *gin.Context) {
 		return
 	}
 
-	clientId := c.GetHeader("Client-Id")
-	_, err = uuid.Parse(clientId)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": err.Error()})
+	clientId := c.GetString(utils.ClientIdKey)
+	if clientId == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "missing client token"})
 		return
 	}
 
@@ -118,6 +166,11 @@ func (h *TripHandler) updateTrip(c *gin.Context) {
 	}
 
 	trip, err := h.tripsReposiotry.GetTripById(tripId)
+	if errors.Is(err, ErrTripNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trip by id"})
 		return
@@ -128,51 +181,498 @@ func (h *TripHandler) updateTrip(c *gin.Context) {
 		return
 	}
 
+	lastEvent, err := h.tripsReposiotry.GetLastTripEvent(tripId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting last trip event"})
+		return
+	}
+
+	// Retried requests are answered with the event that was already published
+	if request.Sequence == lastEvent.Sequence {
+		if !isRetryOf(&request, lastEvent) {
+			c.JSON(http.StatusConflict, gin.H{"Conflict": fmt.Sprintf("a different event was already published with sequence %d", request.Sequence)})
+			return
+		}
+
+		h.respondWithPublishedEvent(c, trip, lastEvent)
+		return
+	}
+
+	if request.Sequence < lastEvent.Sequence {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": fmt.Sprintf("sequence %d is behind the last published sequence %d", request.Sequence, lastEvent.Sequence)})
+		return
+	}
+
 	if trip.IsFinished {
 		c.JSON(http.StatusBadRequest, gin.H{"Bad request": "trip is already finished"})
 		return
 	}
 
-	_, scooterOptLockVersion, err := h.scootersRepository.GetScooterById(trip.ScooterId.String())
+	if request.Sequence > lastEvent.Sequence+1 {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": fmt.Sprintf("expected sequence %d, got %d", lastEvent.Sequence+1, request.Sequence)})
+		return
+	}
+
+	eventType, err := nextTripEventType(&request, lastEvent)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	receivedAt := time.Now().UTC()
+	createdAt := eventCreatedAt(request.CreatedAt, receivedAt)
+	if createdAt.Before(lastEvent.CreatedAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": "created_at can not be before the last event of the trip"})
+		return
+	}
+
+	scooter, scooterOptLockVersion, err := h.scootersRepository.GetScooterById(trip.ScooterId.String())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting scooter by id"})
 		return
 	}
 
 	tripEvent := types.TripEvent{
-		TripID:    trip.ID,
-		Location:  request.Location,
-		CreatedAt: request.CreatedAt,
-		Sequence:  request.Sequence,
+		TripID:     trip.ID,
+		Type:       eventType,
+		Location:   request.Location,
+		CreatedAt:  createdAt,
+		ReceivedAt: receivedAt,
+		Sequence:   request.Sequence,
 	}
+	applyTelemetry(scooter, lastEvent, tripEvent, request.BatteryLevel)
 
-	if !request.IsFinishing {
-		tripEvent.Type = enums.UpdateTrip
-		err = h.tripsReposiotry.UpdateTrip(trip, scooterOptLockVersion, tripEvent)
+	if eventType == enums.EndTrip {
+		events, err := h.tripsReposiotry.GetTripEvents(tripId)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "trip could not be updated"})
+			c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trip events"})
+			return
+		}
+
+		err = h.geofence.CheckParking(events[0].Location, tripEvent.Location)
+		if errors.Is(err, zone.ErrOutsideOperatingArea) || errors.Is(err, zone.ErrParkingNotAllowed) || errors.Is(err, zone.ErrParkingStationNeeded) || errors.Is(err, zone.ErrParkingStationFull) {
+			c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error(), "message": "trip cannot be ended at this location"})
 			return
 		}
-	} else {
-		_, userOptLockVersion, err := h.usersRepository.GetUserById(clientId)
+
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting user by id"})
+			c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error checking zones"})
 			return
 		}
 
-		tripEvent.Type = enums.EndTrip
-		err = h.tripsReposiotry.EndTrip(trip, scooterOptLockVersion, userOptLockVersion, tripEvent)
+		// The last leg is ridden like any other, so it is checked for violations as well
+		violations, err := h.geofence.CheckMove(lastEvent, tripEvent)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "trip could not be finished"})
+			c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error checking zones"})
 			return
 		}
+
+		h.finishTrip(c, trip, scooter, scooterOptLockVersion, events, tripEvent, violations)
+		return
+	}
+
+	violations, err := h.geofence.CheckMove(lastEvent, tripEvent)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error checking zones"})
+		return
+	}
+
+	err = h.tripsReposiotry.UpdateTrip(trip, scooter, scooterOptLockVersion, tripEvent, violations)
+	if errors.Is(err, ErrSequenceConflict) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "trip could not be updated"})
+		return
 	}
 
 	c.JSON(http.StatusOK, tripEvent)
 }
 
-func (h *TripHandler) validateTripStart(scooter *types.Scooter, user *types.MobileClient) error {
-	if !scooter.IsAvailable {
+// forceEndTrip lets support close a trip the rider is unable to end, the rider is charged as if they ended it.
+func (h *TripHandler) forceEndTrip(c *gin.Context) {
+	h.closeTrip(c, enums.ForceEndTrip)
+}
+
+// cancelTrip closes a trip without charging the rider anything.
+func (h *TripHandler) cancelTrip(c *gin.Context) {
+	h.closeTrip(c, enums.CancelTrip)
+}
+
+// closeTrip ends any rider's trip on behalf of an operator at the last known location of the scooter.
+func (h *TripHandler) closeTrip(c *gin.Context, eventType enums.TripEventType) {
+	tripId := c.Param("id")
+	_, err := uuid.Parse(tripId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	var request types.CloseTripRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateCloseTripRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	trip, err := h.tripsReposiotry.GetTripById(tripId)
+	if errors.Is(err, ErrTripNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trip by id"})
+		return
+	}
+
+	if trip.IsFinished {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": "trip is already finished"})
+		return
+	}
+
+	lastEvent, err := h.tripsReposiotry.GetLastTripEvent(tripId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting last trip event"})
+		return
+	}
+
+	scooter, scooterOptLockVersion, err := h.scootersRepository.GetScooterById(trip.ScooterId.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting scooter by id"})
+		return
+	}
+
+	events, err := h.tripsReposiotry.GetTripEvents(tripId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trip events"})
+		return
+	}
+
+	actor := utils.GetCallerKey(c)
+	now := time.Now().UTC()
+	tripEvent := types.TripEvent{
+		TripID:     trip.ID,
+		Type:       eventType,
+		Location:   lastEvent.Location,
+		CreatedAt:  now,
+		ReceivedAt: now,
+		Sequence:   lastEvent.Sequence + 1,
+		Actor:      &actor,
+		Reason:     &request.Reason,
+	}
+
+	h.finishTrip(c, trip, scooter, scooterOptLockVersion, events, tripEvent, nil)
+}
+
+// finishTrip publishes the event ending the trip together with its fare and releases the scooter and the rider.
+// Cancelled trips keep their fare breakdown, but are not charged. A scooter with a low battery is left charging.
+// Events are the ones published so far, ordered by sequence. Violations are the ones committed on the last leg.
+func (h *TripHandler) finishTrip(c *gin.Context, trip *types.Trip, scooter *types.Scooter, scooterOptLockVersion *int, events []*types.TripEvent, tripEvent types.TripEvent, violations []types.ZoneViolation) {
+	_, userOptLockVersion, err := h.usersRepository.GetUserById(trip.ClientId.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting user by id"})
+		return
+	}
+
+	fare := h.fareCalculator.CalculateFare(append(events, &tripEvent))
+	if tripEvent.Type == enums.CancelTrip {
+		fare.Amount = 0
+	}
+	trip.Fare = &fare.Amount
+	scooter.Status = utils.IdleScooterStatus(scooter.BatteryLevel, h.lowBatteryThreshold)
+
+	err = h.tripsReposiotry.EndTrip(trip, scooter, scooterOptLockVersion, userOptLockVersion, tripEvent, violations)
+	if errors.Is(err, ErrSequenceConflict) {
+		c.JSON(http.StatusConflict, gin.H{"Conflict": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "trip could not be finished"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.EndTripResponse{TripEvent: tripEvent, Fare: fare})
+}
+
+// isRetryOf tells whether the request would have published the event. Locations and times are compared with a tolerance,
+// since they lose precision when stored.
+func isRetryOf(request *types.TripUpdateRequest, event *types.TripEvent) bool {
+	var eventType enums.TripEventType
+	switch {
+	case request.IsFinishing:
+		eventType = enums.EndTrip
+	case request.IsPausing:
+		eventType = enums.PauseTrip
+	case request.IsResuming:
+		eventType = enums.ResumeTrip
+	default:
+		eventType = enums.UpdateTrip
+	}
+
+	createdAtDifference := eventCreatedAt(request.CreatedAt, event.ReceivedAt).Sub(event.CreatedAt).Abs()
+	return eventType == event.Type &&
+		utils.HaversineDistance(request.Location, event.Location) < 1 &&
+		createdAtDifference < time.Second
+}
+
+// eventCreatedAt trusts the time reported by the rider's app only as far back as maxEventDelay before the event was received.
+func eventCreatedAt(reportedAt time.Time, receivedAt time.Time) time.Time {
+	earliest := receivedAt.Add(-maxEventDelay)
+	if reportedAt.Before(earliest) {
+		return earliest
+	}
+
+	return reportedAt
+}
+
+// applyTelemetry moves the scooter to the event's location and adds the distance ridden since the last event to its odometer.
+// The battery level is only changed when the rider's app reported it.
+func applyTelemetry(scooter *types.Scooter, lastEvent *types.TripEvent, event types.TripEvent, batteryLevel *int) {
+	scooter.OdometerMeters += utils.HaversineDistance(lastEvent.Location, event.Location)
+	scooter.Location = event.Location
+	lastSeenAt := event.CreatedAt
+	scooter.LastSeenAt = &lastSeenAt
+
+	if batteryLevel != nil {
+		scooter.BatteryLevel = *batteryLevel
+	}
+}
+
+// nextTripEventType is the trip state machine, a paused trip does not move until it is resumed or finished.
+func nextTripEventType(request *types.TripUpdateRequest, lastEvent *types.TripEvent) (enums.TripEventType, error) {
+	isPaused := lastEvent.Type == enums.PauseTrip
+
+	if isPaused && (request.IsFinishing || request.IsResuming) &&
+		utils.HaversineDistance(lastEvent.Location, request.Location) > pausedLocationToleranceMeters {
+		return "", errors.New("scooter was moved while the trip was paused")
+	}
+
+	switch {
+	case request.IsFinishing:
+		return enums.EndTrip, nil
+	case request.IsPausing && isPaused:
+		return "", errors.New("trip is already paused")
+	case request.IsPausing:
+		return enums.PauseTrip, nil
+	case request.IsResuming && !isPaused:
+		return "", errors.New("trip is not paused")
+	case request.IsResuming:
+		return enums.ResumeTrip, nil
+	case isPaused:
+		return "", errors.New("trip is paused, location can not be updated until it is resumed")
+	default:
+		return enums.UpdateTrip, nil
+	}
+}
+
+func (h *TripHandler) respondWithPublishedEvent(c *gin.Context, trip *types.Trip, event *types.TripEvent) {
+	if !event.EndsTrip() {
+		c.JSON(http.StatusOK, event)
+		return
+	}
+
+	events, err := h.tripsReposiotry.GetTripEvents(trip.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trip events"})
+		return
+	}
+
+	// Breakdown is recalculated, but the amount that was persisted when the trip ended is what gets charged
+	fare := h.fareCalculator.CalculateFare(events)
+	if trip.Fare != nil {
+		fare.Amount = *trip.Fare
+	}
+
+	c.JSON(http.StatusOK, types.EndTripResponse{TripEvent: *event, Fare: fare})
+}
+
+func (h *TripHandler) getTrip(c *gin.Context) {
+	tripId := c.Param("id")
+	_, err := uuid.Parse(tripId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	clientId := c.GetString(utils.ClientIdKey)
+	if clientId == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "missing client token"})
+		return
+	}
+
+	trip, err := h.tripsReposiotry.GetTripById(tripId)
+	if errors.Is(err, ErrTripNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trip by id"})
+		return
+	}
+
+	if trip.ClientId.String() != clientId {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "user is not allowed to view this trip"})
+		return
+	}
+
+	h.respondWithTripHistory(c, trip)
+}
+
+func (h *TripHandler) getTrips(c *gin.Context) {
+	clientId := c.GetString(utils.ClientIdKey)
+	if clientId == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"Unauthorized request": "missing client token"})
+		return
+	}
+
+	var queryParameters types.GetTripsQueryParameters
+	if err := c.BindQuery(&queryParameters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateGetTripsQueryParameters(&queryParameters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	if queryParameters.Limit == 0 {
+		queryParameters.Limit = defaultTripsPageSize
+	}
+
+	trips, err := h.tripsReposiotry.GetClientTrips(clientId, queryParameters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trips"})
+		return
+	}
+
+	summaries := make([]*types.TripSummary, 0, len(trips))
+	for _, trip := range trips {
+		events, err := h.tripsReposiotry.GetTripEvents(trip.ID.String())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trip events"})
+			return
+		}
+
+		summaries = append(summaries, h.summarizeTrip(trip, events))
+	}
+
+	response := types.GetTripsResponse{
+		Trips:  summaries,
+		Limit:  queryParameters.Limit,
+		Offset: queryParameters.Offset,
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TripHandler) getAnyTrip(c *gin.Context) {
+	tripId := c.Param("id")
+	_, err := uuid.Parse(tripId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	trip, err := h.tripsReposiotry.GetTripById(tripId)
+	if errors.Is(err, ErrTripNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trip by id"})
+		return
+	}
+
+	h.respondWithTripHistory(c, trip)
+}
+
+func (h *TripHandler) respondWithTripHistory(c *gin.Context, trip *types.Trip) {
+	events, err := h.tripsReposiotry.GetTripEvents(trip.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trip events"})
+		return
+	}
+
+	violations, err := h.tripsReposiotry.GetTripViolations(trip.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error(), "message": "error getting trip violations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.GetTripResponse{Trip: trip, Events: events, Violations: violations})
+}
+
+// summarizeTrip derives the trip summary from its events, which must be ordered by sequence.
+// Duration and distance of an unfinished trip are counted up to its last event.
+func (h *TripHandler) summarizeTrip(trip *types.Trip, events []*types.TripEvent) *types.TripSummary {
+	summary := &types.TripSummary{
+		ID:         trip.ID,
+		ScooterId:  trip.ScooterId,
+		IsFinished: trip.IsFinished,
+	}
+
+	if len(events) == 0 {
+		return summary
+	}
+
+	firstEvent := events[0]
+	summary.StartedAt = firstEvent.CreatedAt
+	summary.StartLocation = firstEvent.Location
+
+	fare := h.fareCalculator.CalculateFare(events)
+	summary.DurationSeconds = fare.DurationSeconds
+	summary.DistanceMeters = fare.DistanceMeters
+
+	lastEvent := events[len(events)-1]
+	if lastEvent.EndsTrip() {
+		summary.EndedAt = &lastEvent.CreatedAt
+		summary.EndLocation = &lastEvent.Location
+	}
+
+	// Same as for retried end trip requests, the persisted amount is what the rider was charged
+	if trip.Fare != nil {
+		fare.Amount = *trip.Fare
+		summary.Fare = &fare
+	}
+
+	return summary
+}
+
+// getReservationForScooter returns the rider's reservation only if it holds the given scooter and has not expired yet.
+// getActiveReservation returns the rider's reservation which has not expired yet, whichever scooter it holds.
+// Starting any trip consumes it, so that a scooter held for the rider is not left reserved while they ride another one.
+func (h *TripHandler) getActiveReservation(clientId string) (*types.Reservation, error) {
+	active, err := h.reservations.GetActiveReservation(clientId)
+	if errors.Is(err, reservation.ErrReservationNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !active.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+
+	return active, nil
+}
+
+// validateTripStart lets the rider holding a reservation unlock the scooter, which is unavailable to everyone else.
+// validateTripStart only lets the holder of a reservation unlock the reserved scooter, other scooters must be available.
+func (h *TripHandler) validateTripStart(scooter *types.Scooter, user *types.MobileClient, reservation *types.Reservation) error {
+	isHeldForUser := reservation != nil && reservation.ScooterId == scooter.ID
+	if scooter.Status != enums.AvailableScooter && !(scooter.Status == enums.ReservedScooter && isHeldForUser) {
 		return errors.New("scooter is not available")
 	}
 
@@ -11,17 +11,26 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/services/reservation"
+	"github.com/nerijusro/scootinAboot/services/zone"
 	"github.com/nerijusro/scootinAboot/types"
 	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/utils"
 )
 
+// publishedAt is when the last event of the mocked trip with a published sequence 3 was created.
+var publishedAt = time.Date(2024, 4, 26, 17, 7, 40, 0, time.UTC)
+
 func TestTripHandler(t *testing.T) {
 	validator := &mockTripValidator{}
 	tripRepository := &mockTripRepository{}
 	scooterRepository := &mockScooterRepository{}
 	userRepository := &mockClientRepository{}
+	reservationRepository := &mockReservationRepository{}
+	geofence := &mockGeofence{}
+	fareCalculator := &mockFareCalculator{}
 
-	handler := NewTripHandler(validator, tripRepository, scooterRepository, userRepository)
+	handler := NewTripHandler(validator, tripRepository, scooterRepository, userRepository, reservationRepository, geofence, fareCalculator, 15)
 
 	t.Run("When starting trip while everything is valid returns ok", func(t *testing.T) {
 		requestBody := types.StartTripRequest{
@@ -38,7 +47,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := uuid.New().String()
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.POST("/client/trips", handler.startTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -74,7 +83,7 @@ func TestTripHandler(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.POST("/client/trips", handler.startTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -96,7 +105,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := uuid.New().String()
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.POST("/client/trips", handler.startTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -122,7 +131,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := uuid.New().String()
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.POST("/client/trips", handler.startTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -163,7 +172,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := uuid.New().String()
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.POST("/client/trips", handler.startTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -198,7 +207,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "bec6a2fb-896f-473e-2222-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.POST("/client/trips", handler.startTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -239,7 +248,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := uuid.New().String()
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.POST("/client/trips", handler.startTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -274,7 +283,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "bec6a2fb-896f-473e-a3d5-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.POST("/client/trips", handler.startTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -285,6 +294,158 @@ func TestTripHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("When starting trip while rider holds reservation of unavailable scooter returns created", func(t *testing.T) {
+		id := "03de5edd-e9d7-4c4e-2222-0ff9c07b6a37"
+		idUuid, err := uuid.Parse(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		requestBody := types.StartTripRequest{
+			CreatedAt: time.Now(),
+			ScooterID: idUuid,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/client/trips", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-3333-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.POST("/client/trips", handler.startTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When starting trip while reserved scooter went to maintenance returns bad request", func(t *testing.T) {
+		requestBody := types.StartTripRequest{
+			CreatedAt: time.Now(),
+			ScooterID: uuid.MustParse("03de5edd-e9d7-4c4e-3333-0ff9c07b6a37"),
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/client/trips", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-6666-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.POST("/client/trips", handler.startTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When starting trip on another scooter while rider holds reservation consumes the reservation", func(t *testing.T) {
+		requestBody := types.StartTripRequest{
+			CreatedAt: time.Now(),
+			ScooterID: uuid.New(),
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/client/trips", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-3333-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.POST("/client/trips", handler.startTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+
+		if tripRepository.savedReservation == nil {
+			t.Errorf("expected reservation to be consumed with the trip start")
+		}
+	})
+
+	t.Run("When starting trip while reservation of unavailable scooter has expired returns bad request", func(t *testing.T) {
+		id := "03de5edd-e9d7-4c4e-2222-0ff9c07b6a37"
+		idUuid, err := uuid.Parse(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		requestBody := types.StartTripRequest{
+			CreatedAt: time.Now(),
+			ScooterID: idUuid,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/client/trips", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-5555-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.POST("/client/trips", handler.startTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When starting trip while reservation is no longer active returns conflict", func(t *testing.T) {
+		id := "03de5edd-e9d7-4c4e-2222-0ff9c07b6a37"
+		idUuid, err := uuid.Parse(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		requestBody := types.StartTripRequest{
+			CreatedAt: time.Now(),
+			ScooterID: idUuid,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/client/trips", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-4444-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.POST("/client/trips", handler.startTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
 	t.Run("When updating trip while everything is valid returns ok", func(t *testing.T) {
 		requestBody := types.TripUpdateRequest{
 			Location:  types.Location{Latitude: 54.12, Longitude: 25.34},
@@ -301,7 +462,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -350,7 +511,7 @@ func TestTripHandler(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -372,7 +533,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "5266c8a2-1234-45ab-a26c-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -399,7 +560,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "5266c8a2-1234-45ab-a26c-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -419,7 +580,34 @@ func TestTripHandler(t *testing.T) {
 		}
 	})
 
-	t.Run("When updating trip while the trip is not found returns internal server error", func(t *testing.T) {
+	t.Run("When updating trip while the trip is not found returns not found", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:  types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt: time.Now(),
+			Sequence:  2,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-6666-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating trip while getting the trip fails returns internal server error", func(t *testing.T) {
 		requestBody := types.TripUpdateRequest{
 			Location:  types.Location{Latitude: 54.12, Longitude: 25.34},
 			CreatedAt: time.Now(),
@@ -435,7 +623,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "5266c8a2-1234-45ab-a26c-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -471,7 +659,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "5266c8a2-1234-45ab-3333-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -498,7 +686,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -534,7 +722,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -570,7 +758,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -598,7 +786,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "bec6a2fb-896f-473e-2222-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -626,7 +814,7 @@ func TestTripHandler(t *testing.T) {
 		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
 		request.Header.Set("client-id", clientId)
 
-		router := gin.Default()
+		router := newClientRouter()
 		router.PUT("/client/trips/:id", handler.updateTrip)
 
 		responseRecoreder := httptest.NewRecorder()
@@ -636,82 +824,1208 @@ func TestTripHandler(t *testing.T) {
 			t.Errorf("expected status code %d but got %d", http.StatusInternalServerError, responseRecoreder.Code)
 		}
 	})
-}
-
-type mockTripRepository struct{}
-
-func (m *mockTripRepository) GetTripById(id string) (*types.Trip, error) {
-	idUuid, err := uuid.Parse(id)
-	if err != nil {
-		return nil, err
-	}
 
-	validClientIdUuid, err := uuid.Parse("bec6a2fb-896f-473e-1111-a4208d033498")
-	if err != nil {
-		return nil, err
-	}
+	t.Run("When ending trip while everything is valid returns end event with fare", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:    types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt:   time.Now(),
+			IsFinishing: true,
+			Sequence:    2,
+		}
 
-	nonExistantClientIdUuid, err := uuid.Parse("bec6a2fb-896f-473e-2222-a4208d033498")
-	if err != nil {
-		return nil, err
-	}
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
 
-	validScooterIdUuid, err := uuid.Parse("03de5edd-e9d7-4c4e-2222-0ff9c07b6a37")
-	if err != nil {
-		return nil, err
-	}
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
 
-	nonExistantScooterIdUuid, err := uuid.Parse("03de5edd-e9d7-4c4e-1111-0ff9c07b6a37")
-	if err != nil {
-		return nil, err
-	}
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
 
-	if id == "5266c8a2-7a04-45ab-1111-2a6c9e73bb30" {
-		return nil, errors.New("error getting trip by id")
-	}
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
 
-	if id == "5266c8a2-7a04-45ab-2222-2a6c9e73bb30" {
-		return &types.Trip{ClientId: uuid.New()}, nil
-	}
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
 
-	if id == "5266c8a2-7a04-45ab-3333-2a6c9e73bb30" {
-		return &types.Trip{ClientId: validClientIdUuid, IsFinished: true}, nil
-	}
+		var response types.EndTripResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
 
-	if id == "5266c8a2-7a04-45ab-4444-2a6c9e73bb30" {
-		return &types.Trip{ID: idUuid, ClientId: validClientIdUuid, ScooterId: nonExistantScooterIdUuid}, nil
-	}
+		if response.Type != enums.EndTrip {
+			t.Errorf("expected trip type to be %s, got %s", enums.EndTrip, response.Type)
+		}
 
-	if id == "5266c8a2-7a04-45ab-5555-2a6c9e73bb30" {
-		return &types.Trip{ID: idUuid, ClientId: nonExistantClientIdUuid, ScooterId: validScooterIdUuid}, nil
-	}
+		if response.Fare.Amount != 250 || response.Fare.Currency != "EUR" {
+			t.Errorf("expected fare to be 250 EUR, got %d %s", response.Fare.Amount, response.Fare.Currency)
+		}
+	})
 
-	return &types.Trip{ID: idUuid, ClientId: validClientIdUuid, ScooterId: validScooterIdUuid}, nil
-}
+	t.Run("When ending trip while last leg enters a no ride zone records the violation", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:    types.Location{Latitude: 54.55, Longitude: 25.34},
+			CreatedAt:   time.Now(),
+			IsFinishing: true,
+			Sequence:    2,
+		}
 
-func (m *mockTripRepository) StartTrip(trip types.Trip, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent) error {
-	if trip.ClientId.String() == "bec6a2fb-896f-473e-a3d5-a4208d033498" {
-		return errors.New("trip can not be started")
-	}
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
 
-	return nil
-}
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
 
-func (m *mockTripRepository) UpdateTrip(trip *types.Trip, scooterOptLockVersion *int, event types.TripEvent) error {
-	if trip.ID.String() == "5266c8a2-7a04-45ab-7777-2a6c9e73bb30" {
-		return errors.New("error getting trip by id")
-	}
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
 
-	return nil
-}
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
 
-func (m *mockTripRepository) EndTrip(trip *types.Trip, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent) error {
-	if trip.ClientId.String() == "5266c8a2-7a04-45ab-7777-2a6c9e73bb30" {
-		return errors.New("error getting user by id")
-	}
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
 
-	return nil
-}
+		if len(tripRepository.savedViolations) != 1 || tripRepository.savedViolations[0].Type != enums.EnteredNoRideZone {
+			t.Errorf("expected a single %s violation to be recorded, got %v", enums.EnteredNoRideZone, tripRepository.savedViolations)
+		}
+	})
+
+	t.Run("When ending trip while created_at is before the last event returns bad request", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:    types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt:   time.Now().Add(-2 * time.Minute),
+			IsFinishing: true,
+			Sequence:    2,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When ending trip while created_at is older than the allowed delay ends it at the earliest allowed time", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:    types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt:   time.Now().Add(-time.Hour),
+			IsFinishing: true,
+			Sequence:    2,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-cccc-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Fatalf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.EndTripResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if time.Since(response.CreatedAt) > maxEventDelay+time.Minute {
+			t.Errorf("expected created_at to be moved forward, got %s", response.CreatedAt)
+		}
+	})
+
+	t.Run("When ending trip while reported battery level is low leaves scooter unavailable", func(t *testing.T) {
+		batteryLevel := 9
+		requestBody := types.TripUpdateRequest{
+			Location:     types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt:    time.Now(),
+			IsFinishing:  true,
+			Sequence:     2,
+			BatteryLevel: &batteryLevel,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		if tripRepository.savedScooter.BatteryLevel != 9 || tripRepository.savedScooter.Status != enums.ChargingScooter {
+			t.Errorf("expected scooter to be charging with battery level 9, got %s and %d", tripRepository.savedScooter.Status, tripRepository.savedScooter.BatteryLevel)
+		}
+	})
+
+	t.Run("When updating trip while battery level is reported saves it with the scooter's telemetry", func(t *testing.T) {
+		batteryLevel := 42
+		requestBody := types.TripUpdateRequest{
+			Location:     types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt:    time.Now(),
+			Sequence:     2,
+			BatteryLevel: &batteryLevel,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		scooter := tripRepository.savedScooter
+		if scooter.BatteryLevel != 42 || scooter.LastSeenAt == nil || scooter.OdometerMeters <= 0 {
+			t.Errorf("expected battery level 42, last seen time and odometer to be saved, got %v", scooter)
+		}
+
+		if scooter.Location.Latitude != 54.12 || scooter.Location.Longitude != 25.34 {
+			t.Errorf("expected scooter to be moved to 54.12, 25.34, got %v", scooter.Location)
+		}
+	})
+
+	t.Run("When ending trip while location is in no parking zone returns bad request", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:    types.Location{Latitude: 33.33, Longitude: 25.34},
+			CreatedAt:   time.Now(),
+			IsFinishing: true,
+			Sequence:    2,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When ending trip while parking station is full returns bad request", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:    types.Location{Latitude: 33.34, Longitude: 25.34},
+			CreatedAt:   time.Now(),
+			IsFinishing: true,
+			Sequence:    2,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating trip while zones can not be loaded returns internal server error", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:  types.Location{Latitude: 44.44, Longitude: 25.34},
+			CreatedAt: time.Now(),
+			Sequence:  2,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusInternalServerError {
+			t.Errorf("expected status code %d but got %d", http.StatusInternalServerError, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating trip while sequence was already published returns the original event", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:  types.Location{Latitude: 12.12, Longitude: 44.34},
+			CreatedAt: publishedAt,
+			Sequence:  3,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-8888-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.TripEvent
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Location.Latitude != 12.12 || response.Location.Longitude != 44.34 {
+			t.Errorf("expected original location 12.12, 44.34, got %f, %f", response.Location.Latitude, response.Location.Longitude)
+		}
+	})
+
+	t.Run("When updating trip while a different event was published with the same sequence returns conflict", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:  types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt: publishedAt,
+			Sequence:  3,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-8888-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating trip while sequence goes backwards returns conflict", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:  types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt: time.Now(),
+			Sequence:  2,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-8888-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating trip while sequence skips ahead returns conflict", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:  types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt: time.Now(),
+			Sequence:  5,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-8888-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+
+		var response map[string]interface{}
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response["Conflict"] != "expected sequence 4, got 5" {
+			t.Errorf("expected message to be: expected sequence 4, got 5, got %s", response["Conflict"])
+		}
+	})
+
+	t.Run("When updating trip while another request published the same sequence returns conflict", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:  types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt: time.Now(),
+			Sequence:  2,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-9999-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusConflict {
+			t.Errorf("expected status code %d but got %d", http.StatusConflict, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When pausing trip while trip is riding returns pause event", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:  types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt: time.Now(),
+			Sequence:  2,
+			IsPausing: true,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.TripEvent
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Type != enums.PauseTrip {
+			t.Errorf("expected trip type to be %s, got %s", enums.PauseTrip, response.Type)
+		}
+	})
+
+	t.Run("When pausing trip while trip is already paused returns bad request", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:  types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt: time.Now(),
+			Sequence:  2,
+			IsPausing: true,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-aaaa-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When updating trip location while trip is paused returns bad request", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:  types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt: time.Now(),
+			Sequence:  2,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-aaaa-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When resuming trip while trip is paused returns resume event", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:   types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt:  time.Now(),
+			Sequence:   2,
+			IsResuming: true,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-aaaa-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.TripEvent
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Type != enums.ResumeTrip {
+			t.Errorf("expected trip type to be %s, got %s", enums.ResumeTrip, response.Type)
+		}
+	})
+
+	t.Run("When resuming trip while scooter was moved during the pause returns bad request", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:   types.Location{Latitude: 54.69, Longitude: 25.28},
+			CreatedAt:  time.Now(),
+			Sequence:   2,
+			IsResuming: true,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-aaaa-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When ending trip while scooter was moved during the pause returns bad request", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:    types.Location{Latitude: 54.69, Longitude: 25.28},
+			CreatedAt:   time.Now(),
+			Sequence:    2,
+			IsFinishing: true,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-aaaa-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When resuming trip while trip is not paused returns bad request", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:   types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt:  time.Now(),
+			Sequence:   2,
+			IsResuming: true,
+		}
+
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPut, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.PUT("/client/trips/:id", handler.updateTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting trip while everything is valid returns trip with events", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.GET("/client/trips/:id", handler.getTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.GetTripResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Trip.ID.String() != "5266c8a2-7a04-45ab-a26c-2a6c9e73bb30" {
+			t.Errorf("expected trip id to be 5266c8a2-7a04-45ab-a26c-2a6c9e73bb30, got %s", response.Trip.ID.String())
+		}
+
+		if len(response.Events) != 1 || response.Events[0].Type != enums.StartTrip {
+			t.Errorf("expected a single %s event, got %v", enums.StartTrip, response.Events)
+		}
+
+		if len(response.Violations) != 1 || response.Violations[0].Type != enums.EnteredNoRideZone {
+			t.Errorf("expected a single %s violation, got %v", enums.EnteredNoRideZone, response.Violations)
+		}
+	})
+
+	t.Run("When getting trip while trip is not clients returns unauthorized", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "5266c8a2-1234-45ab-3333-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.GET("/client/trips/:id", handler.getTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting trip while trip is not found returns not found", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/trips/5266c8a2-7a04-45ab-6666-2a6c9e73bb30", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.GET("/client/trips/:id", handler.getTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting trip while repository fails returns internal server error", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/trips/5266c8a2-7a04-45ab-1111-2a6c9e73bb30", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientId := "bec6a2fb-896f-473e-1111-a4208d033498"
+		request.Header.Set("client-id", clientId)
+
+		router := newClientRouter()
+		router.GET("/client/trips/:id", handler.getTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusInternalServerError {
+			t.Errorf("expected status code %d but got %d", http.StatusInternalServerError, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting any trip as admin while client id is not set returns ok", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/admin/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := newClientRouter()
+		router.GET("/admin/trips/:id", handler.getAnyTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting trips while everything is valid returns summaries", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/trips", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request.Header.Set("client-id", "bec6a2fb-896f-473e-1111-a4208d033498")
+
+		router := newClientRouter()
+		router.GET("/client/trips", handler.getTrips)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Fatalf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.GetTripsResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Limit != defaultTripsPageSize || response.Offset != 0 {
+			t.Errorf("expected limit %d and offset 0, got %d and %d", defaultTripsPageSize, response.Limit, response.Offset)
+		}
+
+		if len(response.Trips) != 2 {
+			t.Fatalf("expected 2 trips, got %d", len(response.Trips))
+		}
+
+		activeTrip := response.Trips[0]
+		if activeTrip.EndedAt != nil || activeTrip.EndLocation != nil || activeTrip.Fare != nil {
+			t.Errorf("expected active trip to have no end and no fare, got %v", activeTrip)
+		}
+
+		finishedTrip := response.Trips[1]
+		if finishedTrip.EndedAt == nil || finishedTrip.EndLocation == nil || finishedTrip.EndLocation.Latitude != 54.69 {
+			t.Errorf("expected finished trip to end at latitude 54.69, got %v", finishedTrip.EndLocation)
+		}
+
+		if finishedTrip.StartLocation.Latitude != 54.68 {
+			t.Errorf("expected finished trip to start at latitude 54.68, got %f", finishedTrip.StartLocation.Latitude)
+		}
+
+		if finishedTrip.Fare == nil || finishedTrip.Fare.Amount != 420 {
+			t.Errorf("expected persisted fare of 420, got %v", finishedTrip.Fare)
+		}
+	})
+
+	t.Run("When getting trips while client id is not set returns unauthorized", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/trips", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := newClientRouter()
+		router.GET("/client/trips", handler.getTrips)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting trips while limit is invalid returns bad request", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/trips?limit=1000", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request.Header.Set("client-id", "bec6a2fb-896f-473e-1111-a4208d033498")
+
+		router := newClientRouter()
+		router.GET("/client/trips", handler.getTrips)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting trips while repository fails returns internal server error", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/client/trips", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request.Header.Set("client-id", "bec6a2fb-896f-473e-a3d5-a4208d033498")
+
+		router := newClientRouter()
+		router.GET("/client/trips", handler.getTrips)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusInternalServerError {
+			t.Errorf("expected status code %d but got %d", http.StatusInternalServerError, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When force ending trip as admin while trip belongs to another rider returns end event with actor and reason", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CloseTripRequest{Reason: "rider's phone died"})
+		request, err := http.NewRequest(http.MethodPost, "/admin/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30/end", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := newAdminRouter()
+		router.POST("/admin/trips/:id/end", handler.forceEndTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.EndTripResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Type != enums.ForceEndTrip {
+			t.Errorf("expected trip type to be %s, got %s", enums.ForceEndTrip, response.Type)
+		}
+
+		if response.Actor == nil || *response.Actor != "operator:4f1f5f4e-94b2-4d0e-9a57-0c2f6f0f1111" {
+			t.Errorf("expected actor to be operator:4f1f5f4e-94b2-4d0e-9a57-0c2f6f0f1111, got %v", response.Actor)
+		}
+
+		if response.Reason == nil || *response.Reason != "rider's phone died" {
+			t.Errorf("expected reason to be: rider's phone died, got %v", response.Reason)
+		}
+
+		if response.Fare.Amount == 0 {
+			t.Errorf("expected fare to be charged")
+		}
+	})
+
+	t.Run("When cancelling trip as admin while everything is valid returns cancel event without fare", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CloseTripRequest{Reason: "scooter broke down"})
+		request, err := http.NewRequest(http.MethodPost, "/admin/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30/cancel", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := newAdminRouter()
+		router.POST("/admin/trips/:id/cancel", handler.cancelTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.EndTripResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Type != enums.CancelTrip {
+			t.Errorf("expected trip type to be %s, got %s", enums.CancelTrip, response.Type)
+		}
+
+		if response.Fare.Amount != 0 {
+			t.Errorf("expected fare amount to be 0, got %d", response.Fare.Amount)
+		}
+	})
+
+	t.Run("When cancelling trip as admin while reason is missing returns bad request", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CloseTripRequest{Reason: ""})
+		request, err := http.NewRequest(http.MethodPost, "/admin/trips/5266c8a2-7a04-45ab-a26c-2a6c9e73bb30/cancel", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := newAdminRouter()
+		router.POST("/admin/trips/:id/cancel", handler.cancelTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When force ending trip as admin while trip is not found returns not found", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CloseTripRequest{Reason: "rider's phone died"})
+		request, err := http.NewRequest(http.MethodPost, "/admin/trips/5266c8a2-7a04-45ab-6666-2a6c9e73bb30/end", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := newAdminRouter()
+		router.POST("/admin/trips/:id/end", handler.forceEndTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When force ending trip as admin while getting the trip fails returns internal server error", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CloseTripRequest{Reason: "rider's phone died"})
+		request, err := http.NewRequest(http.MethodPost, "/admin/trips/5266c8a2-7a04-45ab-1111-2a6c9e73bb30/end", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := newAdminRouter()
+		router.POST("/admin/trips/:id/end", handler.forceEndTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusInternalServerError {
+			t.Errorf("expected status code %d but got %d", http.StatusInternalServerError, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When force ending trip as admin while trip is already finished returns bad request", func(t *testing.T) {
+		marshalledRequestBody, _ := json.Marshal(types.CloseTripRequest{Reason: "rider's phone died"})
+		request, err := http.NewRequest(http.MethodPost, "/admin/trips/5266c8a2-7a04-45ab-3333-2a6c9e73bb30/end", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := newAdminRouter()
+		router.POST("/admin/trips/:id/end", handler.forceEndTrip)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+}
+
+// newClientRouter stands in for AuthenticateClient, which binds requests to the rider of their token.
+func newClientRouter() *gin.Engine {
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		if clientId := c.GetHeader("client-id"); clientId != "" {
+			c.Set(utils.ClientIdKey, clientId)
+		}
+	})
+
+	return router
+}
+
+// newAdminRouter stands in for AuthenticateAdmin, which binds requests to the operator of their token.
+func newAdminRouter() *gin.Engine {
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		c.Set(utils.OperatorIdKey, "4f1f5f4e-94b2-4d0e-9a57-0c2f6f0f1111")
+	})
+
+	return router
+}
+
+type mockTripRepository struct {
+	savedScooter     *types.Scooter
+	savedViolations  []types.ZoneViolation
+	savedReservation *types.Reservation
+}
+
+func (m *mockTripRepository) GetTripById(id string) (*types.Trip, error) {
+	idUuid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	validClientIdUuid, err := uuid.Parse("bec6a2fb-896f-473e-1111-a4208d033498")
+	if err != nil {
+		return nil, err
+	}
+
+	nonExistantClientIdUuid, err := uuid.Parse("bec6a2fb-896f-473e-2222-a4208d033498")
+	if err != nil {
+		return nil, err
+	}
+
+	validScooterIdUuid, err := uuid.Parse("03de5edd-e9d7-4c4e-2222-0ff9c07b6a37")
+	if err != nil {
+		return nil, err
+	}
+
+	nonExistantScooterIdUuid, err := uuid.Parse("03de5edd-e9d7-4c4e-1111-0ff9c07b6a37")
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "5266c8a2-7a04-45ab-1111-2a6c9e73bb30" {
+		return nil, errors.New("error getting trip by id")
+	}
+
+	if id == "5266c8a2-7a04-45ab-6666-2a6c9e73bb30" {
+		return nil, ErrTripNotFound
+	}
+
+	if id == "5266c8a2-7a04-45ab-2222-2a6c9e73bb30" {
+		return &types.Trip{ClientId: uuid.New()}, nil
+	}
+
+	if id == "5266c8a2-7a04-45ab-3333-2a6c9e73bb30" {
+		return &types.Trip{ClientId: validClientIdUuid, IsFinished: true}, nil
+	}
+
+	if id == "5266c8a2-7a04-45ab-4444-2a6c9e73bb30" {
+		return &types.Trip{ID: idUuid, ClientId: validClientIdUuid, ScooterId: nonExistantScooterIdUuid}, nil
+	}
+
+	if id == "5266c8a2-7a04-45ab-5555-2a6c9e73bb30" {
+		return &types.Trip{ID: idUuid, ClientId: nonExistantClientIdUuid, ScooterId: validScooterIdUuid}, nil
+	}
+
+	return &types.Trip{ID: idUuid, ClientId: validClientIdUuid, ScooterId: validScooterIdUuid}, nil
+}
+
+func (m *mockTripRepository) StartTrip(trip types.Trip, reservation *types.Reservation, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent) error {
+	m.savedReservation = reservation
+	if trip.ClientId.String() == "bec6a2fb-896f-473e-a3d5-a4208d033498" {
+		return errors.New("trip can not be started")
+	}
+
+	if reservation != nil && trip.ClientId.String() == "bec6a2fb-896f-473e-4444-a4208d033498" {
+		return ErrReservationNotActive
+	}
+
+	return nil
+}
+
+func (m *mockTripRepository) UpdateTrip(trip *types.Trip, scooter *types.Scooter, scooterOptLockVersion *int, event types.TripEvent, violations []types.ZoneViolation) error {
+	m.savedScooter = scooter
+	if trip.ID.String() == "5266c8a2-7a04-45ab-7777-2a6c9e73bb30" {
+		return errors.New("error getting trip by id")
+	}
+
+	if trip.ID.String() == "5266c8a2-7a04-45ab-9999-2a6c9e73bb30" {
+		return ErrSequenceConflict
+	}
+
+	return nil
+}
+
+func (m *mockTripRepository) EndTrip(trip *types.Trip, scooter *types.Scooter, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent, violations []types.ZoneViolation) error {
+	m.savedScooter = scooter
+	m.savedViolations = violations
+	if trip.ClientId.String() == "5266c8a2-7a04-45ab-7777-2a6c9e73bb30" {
+		return errors.New("error getting user by id")
+	}
+
+	return nil
+}
+
+func (m *mockTripRepository) GetTripEvents(tripId string) ([]*types.TripEvent, error) {
+	idUuid, err := uuid.Parse(tripId)
+	if err != nil {
+		return nil, err
+	}
+
+	if tripId == "5266c8a2-7a04-45ab-bbbb-2a6c9e73bb30" {
+		startedAt := time.Now().Add(-time.Hour)
+		return []*types.TripEvent{
+			{TripID: idUuid, Type: enums.StartTrip, Location: types.Location{Latitude: 54.68, Longitude: 25.27}, CreatedAt: startedAt, Sequence: 1},
+			{TripID: idUuid, Type: enums.EndTrip, Location: types.Location{Latitude: 54.69, Longitude: 25.28}, CreatedAt: startedAt.Add(10 * time.Minute), Sequence: 2},
+		}, nil
+	}
+
+	return []*types.TripEvent{{TripID: idUuid, Type: enums.StartTrip, CreatedAt: time.Now(), Sequence: 1}}, nil
+}
+
+func (m *mockTripRepository) GetLastTripEvent(tripId string) (*types.TripEvent, error) {
+	idUuid, err := uuid.Parse(tripId)
+	if err != nil {
+		return nil, err
+	}
+
+	if tripId == "5266c8a2-7a04-45ab-8888-2a6c9e73bb30" {
+		return &types.TripEvent{TripID: idUuid, Type: enums.UpdateTrip, Location: types.Location{Latitude: 12.12, Longitude: 44.34}, CreatedAt: publishedAt, Sequence: 3}, nil
+	}
+
+	if tripId == "5266c8a2-7a04-45ab-cccc-2a6c9e73bb30" {
+		return &types.TripEvent{TripID: idUuid, Type: enums.StartTrip, CreatedAt: time.Now().Add(-2 * time.Hour), Sequence: 1}, nil
+	}
+
+	if tripId == "5266c8a2-7a04-45ab-aaaa-2a6c9e73bb30" {
+		return &types.TripEvent{TripID: idUuid, Type: enums.PauseTrip, Location: types.Location{Latitude: 54.12, Longitude: 25.34}, CreatedAt: time.Now().Add(-time.Minute), Sequence: 1}, nil
+	}
+
+	return &types.TripEvent{TripID: idUuid, Type: enums.StartTrip, CreatedAt: time.Now().Add(-time.Minute), Sequence: 1}, nil
+}
+
+func (m *mockTripRepository) GetClientTrips(clientId string, queryParams types.GetTripsQueryParameters) ([]*types.Trip, error) {
+	if clientId == "bec6a2fb-896f-473e-a3d5-a4208d033498" {
+		return nil, errors.New("error getting trips")
+	}
+
+	clientIdUuid, err := uuid.Parse(clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	fare := 420
+	return []*types.Trip{
+		{ID: uuid.MustParse("5266c8a2-7a04-45ab-a26c-2a6c9e73bb30"), ClientId: clientIdUuid},
+		{ID: uuid.MustParse("5266c8a2-7a04-45ab-bbbb-2a6c9e73bb30"), ClientId: clientIdUuid, IsFinished: true, Fare: &fare},
+	}, nil
+}
+
+func (m *mockTripRepository) GetTripViolations(tripId string) ([]*types.ZoneViolation, error) {
+	return []*types.ZoneViolation{{TripID: uuid.MustParse(tripId), Sequence: 1, Type: enums.EnteredNoRideZone}}, nil
+}
+
+// GetIdleTrips implements interfaces.TripRepository.
+func (m *mockTripRepository) GetIdleTrips(idleSince time.Time) ([]*types.Trip, error) {
+	panic("unimplemented")
+}
 
 type mockScooterRepository struct{}
 
@@ -721,10 +2035,24 @@ func (m *mockScooterRepository) GetScooterById(id string) (*types.Scooter, *int,
 	}
 
 	if id == "03de5edd-e9d7-4c4e-2222-0ff9c07b6a37" {
-		return &types.Scooter{IsAvailable: false}, new(int), nil
+		idUuid, err := uuid.Parse(id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &types.Scooter{ID: idUuid, Status: enums.ReservedScooter}, new(int), nil
+	}
+
+	if id == "03de5edd-e9d7-4c4e-3333-0ff9c07b6a37" {
+		idUuid, err := uuid.Parse(id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &types.Scooter{ID: idUuid, Status: enums.MaintenanceScooter}, new(int), nil
 	}
 
-	return &types.Scooter{IsAvailable: true}, new(int), nil
+	return &types.Scooter{ID: uuid.New(), Status: enums.AvailableScooter}, new(int), nil
 }
 
 // GetScootersByArea implements interfaces.ScooterRepository.
@@ -732,13 +2060,71 @@ func (m *mockScooterRepository) GetScootersByArea(queryParams types.GetScootersQ
 	panic("unimplemented")
 }
 
+// GetScootersByRadius implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) GetScootersByRadius(queryParams types.GetScootersQueryParameters) ([]*types.NearbyScooter, error) {
+	panic("unimplemented")
+}
+
 // CreateScooter implements interfaces.ScooterRepository.
 func (m *mockScooterRepository) CreateScooter(scooter types.Scooter) error {
 	panic("unimplemented")
 }
 
 // GetAllScooters implements interfaces.ScooterRepository.
-func (m *mockScooterRepository) GetAllScooters() ([]*types.Scooter, error) {
+func (m *mockScooterRepository) GetAllScooters(queryParams types.GetAllScootersQueryParameters) ([]*types.Scooter, error) {
+	panic("unimplemented")
+}
+
+// UpdateScooter implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) UpdateScooter(scooter types.Scooter, optLockVersion *int) error {
+	panic("unimplemented")
+}
+
+// IsInActiveTrip implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) IsInActiveTrip(id string) (bool, error) {
+	panic("unimplemented")
+}
+
+// IsReserved implements interfaces.ScooterRepository.
+func (m *mockScooterRepository) IsReserved(id string) (bool, error) {
+	panic("unimplemented")
+}
+
+type mockReservationRepository struct{}
+
+func (m *mockReservationRepository) GetActiveReservation(clientId string) (*types.Reservation, error) {
+	scooterIdUuid, err := uuid.Parse("03de5edd-e9d7-4c4e-2222-0ff9c07b6a37")
+	if err != nil {
+		return nil, err
+	}
+
+	if clientId == "bec6a2fb-896f-473e-3333-a4208d033498" || clientId == "bec6a2fb-896f-473e-4444-a4208d033498" {
+		return &types.Reservation{ID: uuid.New(), ScooterId: scooterIdUuid, Status: enums.ActiveReservation, ExpiresAt: time.Now().Add(time.Minute)}, nil
+	}
+
+	if clientId == "bec6a2fb-896f-473e-5555-a4208d033498" {
+		return &types.Reservation{ID: uuid.New(), ScooterId: scooterIdUuid, Status: enums.ActiveReservation, ExpiresAt: time.Now().Add(-time.Minute)}, nil
+	}
+
+	if clientId == "bec6a2fb-896f-473e-6666-a4208d033498" {
+		return &types.Reservation{ID: uuid.New(), ScooterId: uuid.MustParse("03de5edd-e9d7-4c4e-3333-0ff9c07b6a37"), Status: enums.ActiveReservation, ExpiresAt: time.Now().Add(time.Minute)}, nil
+	}
+
+	return nil, reservation.ErrReservationNotFound
+}
+
+// CreateReservation implements interfaces.ReservationRepository.
+func (m *mockReservationRepository) CreateReservation(reservation types.Reservation, scooterOptLockVersion *int, event types.ReservationEvent) error {
+	panic("unimplemented")
+}
+
+// GetExpiredReservations implements interfaces.ReservationRepository.
+func (m *mockReservationRepository) GetExpiredReservations(now time.Time) ([]*types.Reservation, error) {
+	panic("unimplemented")
+}
+
+// ExpireReservation implements interfaces.ReservationRepository.
+func (m *mockReservationRepository) ExpireReservation(reservation *types.Reservation, scooterOptLockVersion *int, event types.ReservationEvent) error {
 	panic("unimplemented")
 }
 
@@ -762,6 +2148,31 @@ func (m *mockClientRepository) GetUserById(id string) (*types.MobileClient, *int
 	return &types.MobileClient{ID: idUuid, IsEligibleToTravel: true}, new(int), nil
 }
 
+// GetUserByEmail implements interfaces.ClientRepository.
+func (m *mockClientRepository) GetUserByEmail(email string) (*types.MobileClient, error) {
+	panic("unimplemented")
+}
+
+// SetUserCredentials implements interfaces.ClientRepository.
+func (m *mockClientRepository) SetUserCredentials(client types.MobileClient) error {
+	panic("unimplemented")
+}
+
+// GetUsers implements interfaces.ClientRepository.
+func (m *mockClientRepository) GetUsers(queryParams types.GetUsersQueryParameters) ([]*types.MobileClient, error) {
+	panic("unimplemented")
+}
+
+// IsInActiveTrip implements interfaces.ClientRepository.
+func (m *mockClientRepository) IsInActiveTrip(id string) (bool, error) {
+	panic("unimplemented")
+}
+
+// UpdateUserEligibility implements interfaces.ClientRepository.
+func (m *mockClientRepository) UpdateUserEligibility(client types.MobileClient, optLockVersion *int) error {
+	panic("unimplemented")
+}
+
 type mockTripValidator struct{}
 
 func (m *mockTripValidator) ValidateStartTripRequest(request *types.StartTripRequest) error {
@@ -779,3 +2190,51 @@ func (m *mockTripValidator) ValidateTripUpdateRequest(request *types.TripUpdateR
 
 	return nil
 }
+
+func (m *mockTripValidator) ValidateCloseTripRequest(request *types.CloseTripRequest) error {
+	if request.Reason == "" {
+		return errors.New("invalid reason")
+	}
+
+	return nil
+}
+
+func (m *mockTripValidator) ValidateGetTripsQueryParameters(queryParams *types.GetTripsQueryParameters) error {
+	if queryParams.Limit > 100 {
+		return errors.New("invalid limit")
+	}
+
+	return nil
+}
+
+type mockGeofence struct{}
+
+func (m *mockGeofence) CheckMove(lastEvent *types.TripEvent, event types.TripEvent) ([]types.ZoneViolation, error) {
+	if event.Location.Latitude == 44.44 {
+		return nil, errors.New("error getting zones")
+	}
+
+	if event.Location.Latitude == 54.55 {
+		return []types.ZoneViolation{{TripID: event.TripID, Sequence: event.Sequence, Type: enums.EnteredNoRideZone, Location: event.Location}}, nil
+	}
+
+	return nil, nil
+}
+
+func (m *mockGeofence) CheckParking(startLocation types.Location, location types.Location) error {
+	if location.Latitude == 33.33 {
+		return zone.ErrParkingNotAllowed
+	}
+
+	if location.Latitude == 33.34 {
+		return zone.ErrParkingStationFull
+	}
+
+	return nil
+}
+
+type mockFareCalculator struct{}
+
+func (m *mockFareCalculator) CalculateFare(events []*types.TripEvent) types.Fare {
+	return types.Fare{Amount: 100 + 150*(len(events)-1), Currency: "EUR"}
+}
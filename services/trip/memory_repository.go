@@ -0,0 +1,307 @@
+package trip
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+type InMemoryTripRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryTripRepository {
+	return &InMemoryTripRepository{storage: storage}
+}
+
+func (r *InMemoryTripRepository) StartTrip(trip types.Trip, reservation *types.Reservation, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if _, ok := r.storage.Trips[trip.ID.String()]; ok {
+		return fmt.Errorf("trip with id %s already exists", trip.ID.String())
+	}
+
+	scooter, err := r.lockedScooter(trip.ScooterId.String(), scooterOptLockVersion)
+	if err != nil {
+		return err
+	}
+
+	user, err := r.lockedUser(trip.ClientId.String(), userOptLockVersion)
+	if err != nil {
+		return err
+	}
+
+	if err := r.lockedSequence(event); err != nil {
+		return err
+	}
+
+	if reservation != nil {
+		storedReservation, ok := r.storage.Reservations[reservation.ID.String()]
+		if !ok || storedReservation.Status != enums.ActiveReservation {
+			return ErrReservationNotActive
+		}
+
+		storedReservation.Status = enums.ConsumedReservation
+		r.storage.ReservationEvents = append(r.storage.ReservationEvents, types.ReservationEvent{
+			ReservationID: reservation.ID,
+			Type:          enums.ConsumeReservation,
+			CreatedAt:     event.CreatedAt,
+		})
+	}
+
+	if reservation != nil && reservation.ScooterId != trip.ScooterId {
+		reservedScooter, ok := r.storage.Scooters[reservation.ScooterId.String()]
+		if ok && reservedScooter.Value.Status == enums.ReservedScooter {
+			reservedScooter.Value.Status = enums.AvailableScooter
+			reservedScooter.OptLockVersion++
+		}
+	}
+
+	trip.IsFinished = false
+	r.storage.Trips[trip.ID.String()] = &trip
+
+	scooter.Value.Status = enums.InTripScooter
+	scooter.OptLockVersion++
+
+	user.Value.IsEligibleToTravel = false
+	user.OptLockVersion++
+
+	r.storage.Events = append(r.storage.Events, event)
+	return nil
+}
+
+func (r *InMemoryTripRepository) UpdateTrip(trip *types.Trip, updatedScooter *types.Scooter, scooterOptLockVersion *int, event types.TripEvent, violations []types.ZoneViolation) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	scooter, ok := r.storage.Scooters[trip.ScooterId.String()]
+	if !ok || scooter.OptLockVersion != *scooterOptLockVersion {
+		return errors.New("scooter was updated by another transaction")
+	}
+
+	if err := r.lockedSequence(event); err != nil {
+		return err
+	}
+
+	scooter.Value = *updatedScooter
+	scooter.OptLockVersion++
+
+	r.storage.Events = append(r.storage.Events, event)
+	r.storage.ZoneViolations = append(r.storage.ZoneViolations, violations...)
+	return nil
+}
+
+func (r *InMemoryTripRepository) EndTrip(trip *types.Trip, updatedScooter *types.Scooter, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent, violations []types.ZoneViolation) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	storedTrip, ok := r.storage.Trips[trip.ID.String()]
+	if !ok {
+		return fmt.Errorf("trip with id %s not found", trip.ID.String())
+	}
+
+	scooter, err := r.lockedScooter(trip.ScooterId.String(), scooterOptLockVersion)
+	if err != nil {
+		return err
+	}
+
+	user, err := r.lockedUser(trip.ClientId.String(), userOptLockVersion)
+	if err != nil {
+		return err
+	}
+
+	if err := r.lockedSequence(event); err != nil {
+		return err
+	}
+
+	storedTrip.IsFinished = true
+	storedTrip.Fare = trip.Fare
+
+	scooter.Value = *updatedScooter
+	scooter.OptLockVersion++
+
+	user.Value.IsEligibleToTravel = true
+	user.OptLockVersion++
+
+	r.storage.Events = append(r.storage.Events, event)
+	r.storage.ZoneViolations = append(r.storage.ZoneViolations, violations...)
+	return nil
+}
+
+func (r *InMemoryTripRepository) GetTripViolations(tripId string) ([]*types.ZoneViolation, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	violations := make([]*types.ZoneViolation, 0)
+	for _, storedViolation := range r.storage.ZoneViolations {
+		if storedViolation.TripID.String() == tripId {
+			violation := storedViolation
+			violations = append(violations, &violation)
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Sequence < violations[j].Sequence
+	})
+
+	return violations, nil
+}
+
+func (r *InMemoryTripRepository) GetTripById(id string) (*types.Trip, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	storedTrip, ok := r.storage.Trips[id]
+	if !ok {
+		return nil, ErrTripNotFound
+	}
+
+	trip := *storedTrip
+	if storedTrip.Fare != nil {
+		fare := *storedTrip.Fare
+		trip.Fare = &fare
+	}
+
+	return &trip, nil
+}
+
+func (r *InMemoryTripRepository) GetTripEvents(tripId string) ([]*types.TripEvent, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	events := make([]*types.TripEvent, 0)
+	for _, storedEvent := range r.storage.Events {
+		if storedEvent.TripID.String() == tripId {
+			event := storedEvent
+			events = append(events, &event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Sequence < events[j].Sequence
+	})
+
+	return events, nil
+}
+
+func (r *InMemoryTripRepository) GetLastTripEvent(tripId string) (*types.TripEvent, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	var lastEvent *types.TripEvent
+	for _, storedEvent := range r.storage.Events {
+		if storedEvent.TripID.String() == tripId && (lastEvent == nil || storedEvent.Sequence > lastEvent.Sequence) {
+			event := storedEvent
+			lastEvent = &event
+		}
+	}
+
+	if lastEvent == nil {
+		return nil, fmt.Errorf("trip with id %s has no events", tripId)
+	}
+
+	return lastEvent, nil
+}
+
+func (r *InMemoryTripRepository) GetIdleTrips(idleSince time.Time) ([]*types.Trip, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	lastEventTimes := make(map[uuid.UUID]time.Time)
+	for _, storedEvent := range r.storage.Events {
+		if storedEvent.ReceivedAt.After(lastEventTimes[storedEvent.TripID]) {
+			lastEventTimes[storedEvent.TripID] = storedEvent.ReceivedAt
+		}
+	}
+
+	trips := make([]*types.Trip, 0)
+	for _, storedTrip := range r.storage.Trips {
+		lastEventTime, ok := lastEventTimes[storedTrip.ID]
+		if storedTrip.IsFinished || !ok || !lastEventTime.Before(idleSince) {
+			continue
+		}
+
+		trip := *storedTrip
+		trips = append(trips, &trip)
+	}
+
+	return trips, nil
+}
+
+func (r *InMemoryTripRepository) GetClientTrips(clientId string, queryParams types.GetTripsQueryParameters) ([]*types.Trip, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	startedAt := make(map[uuid.UUID]time.Time)
+	for _, storedEvent := range r.storage.Events {
+		if storedEvent.Sequence == 1 {
+			startedAt[storedEvent.TripID] = storedEvent.ReceivedAt
+		}
+	}
+
+	trips := make([]*types.Trip, 0)
+	for _, storedTrip := range r.storage.Trips {
+		if storedTrip.ClientId.String() != clientId {
+			continue
+		}
+
+		trip := *storedTrip
+		if storedTrip.Fare != nil {
+			fare := *storedTrip.Fare
+			trip.Fare = &fare
+		}
+		trips = append(trips, &trip)
+	}
+
+	sort.Slice(trips, func(i, j int) bool {
+		if !startedAt[trips[i].ID].Equal(startedAt[trips[j].ID]) {
+			return startedAt[trips[i].ID].After(startedAt[trips[j].ID])
+		}
+		return trips[i].ID.String() > trips[j].ID.String()
+	})
+
+	if queryParams.Offset >= len(trips) {
+		return make([]*types.Trip, 0), nil
+	}
+
+	end := min(queryParams.Offset+queryParams.Limit, len(trips))
+	return trips[queryParams.Offset:end], nil
+}
+
+// lockedSequence mirrors the unique (trip_id, sequence) constraint, the caller must hold the write lock.
+func (r *InMemoryTripRepository) lockedSequence(event types.TripEvent) error {
+	for _, storedEvent := range r.storage.Events {
+		if storedEvent.TripID == event.TripID && storedEvent.Sequence == event.Sequence {
+			return ErrSequenceConflict
+		}
+	}
+
+	return nil
+}
+
+// lockedScooter returns the scooter record only if its version still matches, the caller must hold the write lock.
+func (r *InMemoryTripRepository) lockedScooter(id string, optLockVersion *int) (*db.VersionedRecord[types.Scooter], error) {
+	record, ok := r.storage.Scooters[id]
+	if !ok || record.OptLockVersion != *optLockVersion {
+		return nil, errors.New("row was updated by another transaction")
+	}
+
+	return record, nil
+}
+
+// lockedUser returns the user record only if its version still matches, the caller must hold the write lock.
+func (r *InMemoryTripRepository) lockedUser(id string, optLockVersion *int) (*db.VersionedRecord[types.MobileClient], error) {
+	record, ok := r.storage.Users[id]
+	if !ok || record.OptLockVersion != *optLockVersion {
+		return nil, errors.New("row was updated by another transaction")
+	}
+
+	return record, nil
+}
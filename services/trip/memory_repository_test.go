@@ -0,0 +1,227 @@
+package trip
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestInMemoryTripRepository(t *testing.T) {
+	setup := func() (*db.InMemoryStorage, *InMemoryTripRepository, types.Trip) {
+		storage := db.NewInMemoryStorage()
+		scooter := types.Scooter{ID: uuid.New(), Location: types.Location{Latitude: 54.68, Longitude: 25.27}, Status: enums.AvailableScooter}
+		user := types.MobileClient{ID: uuid.New(), FullName: "John Doe", IsEligibleToTravel: true}
+		storage.Scooters[scooter.ID.String()] = &db.VersionedRecord[types.Scooter]{Value: scooter}
+		storage.Users[user.ID.String()] = &db.VersionedRecord[types.MobileClient]{Value: user}
+
+		trip := types.Trip{ID: uuid.New(), ScooterId: scooter.ID, ClientId: user.ID}
+		return storage, NewInMemoryRepository(storage), trip
+	}
+
+	startEvent := func(trip types.Trip) types.TripEvent {
+		return types.TripEvent{TripID: trip.ID, Type: enums.StartTrip, CreatedAt: time.Now(), Sequence: 1}
+	}
+
+	t.Run("When starting trip while versions match locks scooter and user", func(t *testing.T) {
+		storage, repository, trip := setup()
+
+		err := repository.StartTrip(trip, nil, new(int), new(int), startEvent(trip))
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		scooter := storage.Scooters[trip.ScooterId.String()]
+		if scooter.Value.Status != enums.InTripScooter || scooter.OptLockVersion != 1 {
+			t.Errorf("expected scooter to be in trip with version 1, got %s and %d", scooter.Value.Status, scooter.OptLockVersion)
+		}
+
+		user := storage.Users[trip.ClientId.String()]
+		if user.Value.IsEligibleToTravel || user.OptLockVersion != 1 {
+			t.Errorf("expected user to be ineligible with version 1, got %t and %d", user.Value.IsEligibleToTravel, user.OptLockVersion)
+		}
+
+		if len(storage.Events) != 1 {
+			t.Errorf("expected 1 event, got %d", len(storage.Events))
+		}
+	})
+
+	t.Run("When starting trip while user version is stale returns error and changes nothing", func(t *testing.T) {
+		storage, repository, trip := setup()
+		staleVersion := 5
+
+		err := repository.StartTrip(trip, nil, new(int), &staleVersion, startEvent(trip))
+		if err == nil || err.Error() != "row was updated by another transaction" {
+			t.Fatalf("expected error: row was updated by another transaction, got %v", err)
+		}
+
+		if _, ok := storage.Trips[trip.ID.String()]; ok {
+			t.Errorf("expected trip not to be stored")
+		}
+
+		if storage.Scooters[trip.ScooterId.String()].Value.Status != enums.AvailableScooter {
+			t.Errorf("expected scooter to stay available")
+		}
+
+		if len(storage.Events) != 0 {
+			t.Errorf("expected no events, got %d", len(storage.Events))
+		}
+	})
+
+	t.Run("When starting trip while holding reservation consumes it", func(t *testing.T) {
+		storage, repository, trip := setup()
+		reservation := &types.Reservation{ID: uuid.New(), ScooterId: trip.ScooterId, ClientId: trip.ClientId, Status: enums.ActiveReservation}
+		storage.Reservations[reservation.ID.String()] = reservation
+
+		err := repository.StartTrip(trip, reservation, new(int), new(int), startEvent(trip))
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if storage.Reservations[reservation.ID.String()].Status != enums.ConsumedReservation {
+			t.Errorf("expected reservation to be consumed, got %s", storage.Reservations[reservation.ID.String()].Status)
+		}
+
+		if len(storage.ReservationEvents) != 1 || storage.ReservationEvents[0].Type != enums.ConsumeReservation {
+			t.Errorf("expected 1 consume reservation event, got %v", storage.ReservationEvents)
+		}
+	})
+
+	t.Run("When starting trip while holding reservation of another scooter consumes it and releases the scooter", func(t *testing.T) {
+		storage, repository, trip := setup()
+		reservedScooter := types.Scooter{ID: uuid.New(), Status: enums.ReservedScooter}
+		storage.Scooters[reservedScooter.ID.String()] = &db.VersionedRecord[types.Scooter]{Value: reservedScooter}
+		reservation := &types.Reservation{ID: uuid.New(), ScooterId: reservedScooter.ID, ClientId: trip.ClientId, Status: enums.ActiveReservation}
+		storage.Reservations[reservation.ID.String()] = reservation
+
+		err := repository.StartTrip(trip, reservation, new(int), new(int), startEvent(trip))
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if storage.Reservations[reservation.ID.String()].Status != enums.ConsumedReservation {
+			t.Errorf("expected reservation to be consumed, got %s", storage.Reservations[reservation.ID.String()].Status)
+		}
+
+		if storage.Scooters[reservedScooter.ID.String()].Value.Status != enums.AvailableScooter {
+			t.Errorf("expected reserved scooter to be available, got %s", storage.Scooters[reservedScooter.ID.String()].Value.Status)
+		}
+	})
+
+	t.Run("When starting trip while reservation has expired returns error and changes nothing", func(t *testing.T) {
+		storage, repository, trip := setup()
+		reservation := &types.Reservation{ID: uuid.New(), ScooterId: trip.ScooterId, ClientId: trip.ClientId, Status: enums.ExpiredReservation}
+		storage.Reservations[reservation.ID.String()] = reservation
+
+		err := repository.StartTrip(trip, reservation, new(int), new(int), startEvent(trip))
+		if !errors.Is(err, ErrReservationNotActive) {
+			t.Fatalf("expected error: %s, got %v", ErrReservationNotActive.Error(), err)
+		}
+
+		if _, ok := storage.Trips[trip.ID.String()]; ok {
+			t.Errorf("expected trip not to be stored")
+		}
+	})
+
+	t.Run("When updating trip while scooter version is stale returns error", func(t *testing.T) {
+		_, repository, trip := setup()
+		if err := repository.StartTrip(trip, nil, new(int), new(int), startEvent(trip)); err != nil {
+			t.Fatal(err)
+		}
+
+		event := types.TripEvent{TripID: trip.ID, Type: enums.UpdateTrip, CreatedAt: time.Now(), Sequence: 2}
+		err := repository.UpdateTrip(&trip, &types.Scooter{ID: trip.ScooterId}, new(int), event, nil)
+		if err == nil || err.Error() != "scooter was updated by another transaction" {
+			t.Errorf("expected error: scooter was updated by another transaction, got %v", err)
+		}
+	})
+
+	t.Run("When updating trip while violations were committed records them with the event", func(t *testing.T) {
+		storage, repository, trip := setup()
+		if err := repository.StartTrip(trip, nil, new(int), new(int), startEvent(trip)); err != nil {
+			t.Fatal(err)
+		}
+
+		version := 1
+		event := types.TripEvent{TripID: trip.ID, Type: enums.UpdateTrip, CreatedAt: time.Now(), Sequence: 2}
+		violations := []types.ZoneViolation{{TripID: trip.ID, Sequence: 2, Type: enums.LeftOperatingArea}}
+		scooter := types.Scooter{ID: trip.ScooterId, Location: types.Location{Latitude: 54.69, Longitude: 25.28}, BatteryLevel: 42, OdometerMeters: 1250}
+		if err := repository.UpdateTrip(&trip, &scooter, &version, event, violations); err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if len(storage.ZoneViolations) != 1 || storage.ZoneViolations[0].Type != enums.LeftOperatingArea {
+			t.Errorf("expected 1 %s violation, got %v", enums.LeftOperatingArea, storage.ZoneViolations)
+		}
+
+		storedScooter := storage.Scooters[trip.ScooterId.String()].Value
+		if storedScooter.BatteryLevel != 42 || storedScooter.OdometerMeters != 1250 || storedScooter.Location.Latitude != 54.69 {
+			t.Errorf("expected scooter telemetry to be saved, got %v", storedScooter)
+		}
+	})
+
+	t.Run("When ending trip while versions match releases scooter and user", func(t *testing.T) {
+		storage, repository, trip := setup()
+		if err := repository.StartTrip(trip, nil, new(int), new(int), startEvent(trip)); err != nil {
+			t.Fatal(err)
+		}
+
+		version := 1
+		event := types.TripEvent{TripID: trip.ID, Type: enums.EndTrip, CreatedAt: time.Now(), Sequence: 2}
+		scooter := types.Scooter{ID: trip.ScooterId, Status: enums.AvailableScooter, BatteryLevel: 80}
+		if err := repository.EndTrip(&trip, &scooter, &version, &version, event, nil); err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		storedTrip, err := repository.GetTripById(trip.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !storedTrip.IsFinished {
+			t.Errorf("expected trip to be finished")
+		}
+
+		if storage.Scooters[trip.ScooterId.String()].Value.Status != enums.AvailableScooter {
+			t.Errorf("expected scooter to be available")
+		}
+
+		if !storage.Users[trip.ClientId.String()].Value.IsEligibleToTravel {
+			t.Errorf("expected user to be eligible to travel")
+		}
+	})
+
+	t.Run("When getting client trips returns only their trips received most recently first", func(t *testing.T) {
+		storage, repository, trip := setup()
+		startedAt := time.Date(2024, 5, 8, 9, 0, 0, 0, time.UTC)
+		olderTrip := types.Trip{ID: uuid.New(), ScooterId: trip.ScooterId, ClientId: trip.ClientId}
+		newerTrip := types.Trip{ID: uuid.New(), ScooterId: trip.ScooterId, ClientId: trip.ClientId}
+		otherRidersTrip := types.Trip{ID: uuid.New(), ScooterId: trip.ScooterId, ClientId: uuid.New()}
+		for i, storedTrip := range []types.Trip{newerTrip, olderTrip, otherRidersTrip} {
+			storage.Trips[storedTrip.ID.String()] = &storedTrip
+			storage.Events = append(storage.Events, types.TripEvent{TripID: storedTrip.ID, Type: enums.StartTrip, CreatedAt: startedAt.Add(time.Duration(i) * time.Hour), ReceivedAt: startedAt.Add(-time.Duration(i) * time.Hour), Sequence: 1})
+		}
+
+		trips, err := repository.GetClientTrips(trip.ClientId.String(), types.GetTripsQueryParameters{Limit: 10})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if len(trips) != 2 || trips[0].ID != newerTrip.ID || trips[1].ID != olderTrip.ID {
+			t.Fatalf("expected newer trip followed by older trip, got %v", trips)
+		}
+
+		trips, err = repository.GetClientTrips(trip.ClientId.String(), types.GetTripsQueryParameters{Limit: 10, Offset: 1})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if len(trips) != 1 || trips[0].ID != olderTrip.ID {
+			t.Errorf("expected only older trip on the second page, got %v", trips)
+		}
+	})
+}
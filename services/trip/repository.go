@@ -3,24 +3,40 @@ package trip
 import (
 	"database/sql"
 	"errors"
-	"fmt"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
 )
 
 type TripRepository struct {
 	db *sql.DB
 }
 
-var publishEventQuery = "INSERT INTO events (trip_id, event_type, latitude, longitude, created_at, sequence) VALUES (UUID_TO_BIN(?, false), ?, ?, ?, ?, ?)"
-var updateScooterQuery = "UPDATE scooters SET is_available = ?, opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ?"
+var (
+	ErrTripNotFound         = errors.New("trip not found")
+	ErrSequenceConflict     = errors.New("event with the same sequence was already published for this trip")
+	ErrReservationNotActive = errors.New("reservation is no longer active")
+)
+
+const mySqlDuplicateEntryErrorNumber = 1062
+
+var publishEventQuery = "INSERT INTO events (trip_id, event_type, latitude, longitude, created_at, received_at, sequence, actor, reason) VALUES (UUID_TO_BIN(?, false), ?, ?, ?, ?, ?, ?, ?, ?)"
+var updateScooterQuery = "UPDATE scooters SET status = ?, opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ?"
+var saveScooterQuery = "UPDATE scooters SET latitude = ?, longitude = ?, status = ?, battery_level = ?, odometer_m = ?, last_seen_at = ?, opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ?"
 var updateUserQuery = "UPDATE users SET is_eligible_to_travel = ?, opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ?"
+var recordViolationQuery = "INSERT INTO zone_violations (trip_id, sequence, zone_id, violation_type, latitude, longitude, created_at) VALUES (UUID_TO_BIN(?, false), ?, UUID_TO_BIN(?, false), ?, ?, ?, ?)"
+var releaseReservedScooterQuery = "UPDATE scooters SET status = 'available', opt_lock_version = opt_lock_version + 1 WHERE id = UUID_TO_BIN(?, false) AND status = 'reserved'"
+var publishReservationEventQuery = "INSERT INTO reservation_events (reservation_id, event_type, created_at) VALUES (UUID_TO_BIN(?, false), ?, ?)"
 
 func NewRepository(db *sql.DB) *TripRepository {
 	return &TripRepository{db: db}
 }
 
-func (r *TripRepository) StartTrip(trip types.Trip, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent) error {
+// StartTrip consumes the reservation holding the scooter, if one is given.
+// StartTrip consumes the rider's reservation, if any. A scooter reserved other than the one unlocked is made available again.
+func (r *TripRepository) StartTrip(trip types.Trip, reservation *types.Reservation, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent) error {
 	createTripQuery := "INSERT INTO trips (id, user_id, scooter_id) VALUES (UUID_TO_BIN(?, false), UUID_TO_BIN(?, false), UUID_TO_BIN(?, false))"
 
 	tx, err := r.db.Begin()
@@ -34,7 +50,7 @@ func (r *TripRepository) StartTrip(trip types.Trip, scooterOptLockVersion *int,
 		return err
 	}
 
-	err = r.updateAvailablity(tx, updateScooterQuery, trip.ScooterId.String(), false, scooterOptLockVersion)
+	err = r.updateAvailablity(tx, updateScooterQuery, trip.ScooterId.String(), enums.InTripScooter, scooterOptLockVersion)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -46,7 +62,23 @@ func (r *TripRepository) StartTrip(trip types.Trip, scooterOptLockVersion *int,
 		return err
 	}
 
-	_, err = tx.Exec(publishEventQuery, event.TripID.String(), event.Type, event.Location.Latitude, event.Location.Longitude, event.CreatedAt, event.Sequence)
+	if reservation != nil {
+		err = r.consumeReservation(tx, reservation, event.CreatedAt)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if reservation != nil && reservation.ScooterId != trip.ScooterId {
+		_, err = tx.Exec(releaseReservedScooterQuery, reservation.ScooterId.String())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = r.publishEvent(tx, event)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -59,35 +91,31 @@ func (r *TripRepository) StartTrip(trip types.Trip, scooterOptLockVersion *int,
 	return nil
 }
 
-func (r *TripRepository) UpdateTrip(trip *types.Trip, scooterOptLockVersion *int, event types.TripEvent) error {
-	updateScootersLocationQuery := "UPDATE scooters SET latitude = ?, longitude = ?, opt_lock_version = ? + 1 WHERE id = UUID_TO_BIN(?, false) AND opt_lock_version = ?"
-
+// UpdateTrip saves the scooter's telemetry and records the zone violations committed on the way to the event's location together with the event.
+func (r *TripRepository) UpdateTrip(trip *types.Trip, scooter *types.Scooter, scooterOptLockVersion *int, event types.TripEvent, violations []types.ZoneViolation) error {
 	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
 
-	scooterUpdateResult, err := tx.Exec(updateScootersLocationQuery, event.Location.Latitude, event.Location.Longitude, *scooterOptLockVersion, trip.ScooterId.String(), *scooterOptLockVersion)
+	err = r.saveScooter(tx, scooter, scooterOptLockVersion)
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	rowsAffecter, err := scooterUpdateResult.RowsAffected()
+	err = r.publishEvent(tx, event)
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	if rowsAffecter == 0 {
-		tx.Rollback()
-		return errors.New("scooter was updated by another transaction")
-	}
-
-	_, err = tx.Exec(publishEventQuery, event.TripID.String(), event.Type, event.Location.Latitude, event.Location.Longitude, event.CreatedAt, event.Sequence)
-	if err != nil {
-		tx.Rollback()
-		return err
+	for _, violation := range violations {
+		err = r.recordViolation(tx, violation)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -97,21 +125,23 @@ func (r *TripRepository) UpdateTrip(trip *types.Trip, scooterOptLockVersion *int
 	return nil
 }
 
-func (r *TripRepository) EndTrip(trip *types.Trip, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent) error {
-	updateTripQuery := "UPDATE trips SET is_finished = true WHERE id = UUID_TO_BIN(?, false)"
+// EndTrip saves the scooter as given, so it stays unavailable when its battery is too low to be rented again.
+// EndTrip records the zone violations committed on the last leg of the trip together with the event ending it.
+func (r *TripRepository) EndTrip(trip *types.Trip, scooter *types.Scooter, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent, violations []types.ZoneViolation) error {
+	updateTripQuery := "UPDATE trips SET is_finished = true, fare = ? WHERE id = UUID_TO_BIN(?, false)"
 
 	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec(updateTripQuery, trip.ID.String())
+	_, err = tx.Exec(updateTripQuery, trip.Fare, trip.ID.String())
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	err = r.updateAvailablity(tx, updateScooterQuery, trip.ScooterId.String(), true, scooterOptLockVersion)
+	err = r.saveScooter(tx, scooter, scooterOptLockVersion)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -123,12 +153,20 @@ func (r *TripRepository) EndTrip(trip *types.Trip, scooterOptLockVersion *int, u
 		return err
 	}
 
-	_, err = tx.Exec(publishEventQuery, event.TripID.String(), event.Type, event.Location.Latitude, event.Location.Longitude, event.CreatedAt, event.Sequence)
+	err = r.publishEvent(tx, event)
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
 
+	for _, violation := range violations {
+		err = r.recordViolation(tx, violation)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
@@ -137,22 +175,195 @@ func (r *TripRepository) EndTrip(trip *types.Trip, scooterOptLockVersion *int, u
 }
 
 func (r *TripRepository) GetTripById(id string) (*types.Trip, error) {
-	row := r.db.QueryRow("SELECT * FROM trips WHERE id = UUID_TO_BIN(?, false)", id)
+	row := r.db.QueryRow("SELECT id, user_id, scooter_id, is_finished, fare FROM trips WHERE id = UUID_TO_BIN(?, false)", id)
 
 	var trip types.Trip
-	err := row.Scan(&trip.ID, &trip.ClientId, &trip.ScooterId, &trip.IsFinished)
+	err := row.Scan(&trip.ID, &trip.ClientId, &trip.ScooterId, &trip.IsFinished, &trip.Fare)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTripNotFound
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
 	if trip.ID.String() != id {
-		return nil, fmt.Errorf("trip with id %s not found", id)
+		return nil, ErrTripNotFound
 	}
 
 	return &trip, nil
 }
 
-func (r *TripRepository) updateAvailablity(tx *sql.Tx, query string, id string, newValue bool, optLockVersion *int) error {
+func (r *TripRepository) GetTripEvents(tripId string) ([]*types.TripEvent, error) {
+	rows, err := r.db.Query("SELECT trip_id, event_type, latitude, longitude, created_at, received_at, sequence, actor, reason FROM events WHERE trip_id = UUID_TO_BIN(?, false) ORDER BY sequence", tripId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*types.TripEvent, 0)
+	for rows.Next() {
+		var event types.TripEvent
+		err := rows.Scan(&event.TripID, &event.Type, &event.Location.Latitude, &event.Location.Longitude, &event.CreatedAt, &event.ReceivedAt, &event.Sequence, &event.Actor, &event.Reason)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+func (r *TripRepository) GetLastTripEvent(tripId string) (*types.TripEvent, error) {
+	row := r.db.QueryRow("SELECT trip_id, event_type, latitude, longitude, created_at, received_at, sequence, actor, reason FROM events WHERE trip_id = UUID_TO_BIN(?, false) ORDER BY sequence DESC LIMIT 1", tripId)
+
+	var event types.TripEvent
+	err := row.Scan(&event.TripID, &event.Type, &event.Location.Latitude, &event.Location.Longitude, &event.CreatedAt, &event.ReceivedAt, &event.Sequence, &event.Actor, &event.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// GetIdleTrips returns unfinished trips whose last event was received before idleSince.
+// The server's clock is used, since the one of the rider's phone can not be trusted to be right.
+func (r *TripRepository) GetIdleTrips(idleSince time.Time) ([]*types.Trip, error) {
+	query := "SELECT t.id, t.user_id, t.scooter_id, t.is_finished, t.fare FROM trips t JOIN events e ON e.trip_id = t.id " +
+		"WHERE t.is_finished = false GROUP BY t.id, t.user_id, t.scooter_id, t.is_finished, t.fare HAVING MAX(e.received_at) < ?"
+
+	rows, err := r.db.Query(query, idleSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trips := make([]*types.Trip, 0)
+	for rows.Next() {
+		var trip types.Trip
+		if err := rows.Scan(&trip.ID, &trip.ClientId, &trip.ScooterId, &trip.IsFinished, &trip.Fare); err != nil {
+			return nil, err
+		}
+
+		trips = append(trips, &trip)
+	}
+
+	return trips, rows.Err()
+}
+
+// GetTripViolations returns the zone rules broken during the trip in the order they were broken.
+func (r *TripRepository) GetTripViolations(tripId string) ([]*types.ZoneViolation, error) {
+	rows, err := r.db.Query("SELECT trip_id, sequence, zone_id, violation_type, latitude, longitude, created_at FROM zone_violations WHERE trip_id = UUID_TO_BIN(?, false) ORDER BY sequence, id", tripId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	violations := make([]*types.ZoneViolation, 0)
+	for rows.Next() {
+		var violation types.ZoneViolation
+		err := rows.Scan(&violation.TripID, &violation.Sequence, &violation.ZoneID, &violation.Type,
+			&violation.Location.Latitude, &violation.Location.Longitude, &violation.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		violations = append(violations, &violation)
+	}
+
+	return violations, rows.Err()
+}
+
+// GetClientTrips returns the rider's trips, the most recently started first.
+// Trips are ordered by when the server received their start, the rider's clock can not be trusted to keep pages stable.
+func (r *TripRepository) GetClientTrips(clientId string, queryParams types.GetTripsQueryParameters) ([]*types.Trip, error) {
+	query := "SELECT t.id, t.user_id, t.scooter_id, t.is_finished, t.fare FROM trips t JOIN events e ON e.trip_id = t.id AND e.sequence = 1 " +
+		"WHERE t.user_id = UUID_TO_BIN(?, false) ORDER BY e.received_at DESC, t.id DESC LIMIT ? OFFSET ?"
+
+	rows, err := r.db.Query(query, clientId, queryParams.Limit, queryParams.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trips := make([]*types.Trip, 0)
+	for rows.Next() {
+		var trip types.Trip
+		if err := rows.Scan(&trip.ID, &trip.ClientId, &trip.ScooterId, &trip.IsFinished, &trip.Fare); err != nil {
+			return nil, err
+		}
+
+		trips = append(trips, &trip)
+	}
+
+	return trips, rows.Err()
+}
+
+func (r *TripRepository) consumeReservation(tx *sql.Tx, reservation *types.Reservation, consumedAt time.Time) error {
+	consumeReservationQuery := "UPDATE reservations SET status = ? WHERE id = UUID_TO_BIN(?, false) AND status = ?"
+
+	result, err := tx.Exec(consumeReservationQuery, enums.ConsumedReservation, reservation.ID.String(), enums.ActiveReservation)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrReservationNotActive
+	}
+
+	_, err = tx.Exec(publishReservationEventQuery, reservation.ID.String(), enums.ConsumeReservation, consumedAt)
+	return err
+}
+
+func (r *TripRepository) publishEvent(tx *sql.Tx, event types.TripEvent) error {
+	_, err := tx.Exec(publishEventQuery, event.TripID.String(), event.Type, event.Location.Latitude, event.Location.Longitude, event.CreatedAt, event.ReceivedAt, event.Sequence, event.Actor, event.Reason)
+
+	var mySqlErr *mysql.MySQLError
+	if errors.As(err, &mySqlErr) && mySqlErr.Number == mySqlDuplicateEntryErrorNumber {
+		return ErrSequenceConflict
+	}
+
+	return err
+}
+
+func (r *TripRepository) recordViolation(tx *sql.Tx, violation types.ZoneViolation) error {
+	var zoneId *string
+	if violation.ZoneID != nil {
+		id := violation.ZoneID.String()
+		zoneId = &id
+	}
+
+	_, err := tx.Exec(recordViolationQuery, violation.TripID.String(), violation.Sequence, zoneId, violation.Type,
+		violation.Location.Latitude, violation.Location.Longitude, violation.CreatedAt)
+	return err
+}
+
+func (r *TripRepository) saveScooter(tx *sql.Tx, scooter *types.Scooter, optLockVersion *int) error {
+	result, err := tx.Exec(saveScooterQuery, scooter.Location.Latitude, scooter.Location.Longitude, scooter.Status,
+		scooter.BatteryLevel, scooter.OdometerMeters, scooter.LastSeenAt, *optLockVersion, scooter.ID.String(), *optLockVersion)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("scooter was updated by another transaction")
+	}
+
+	return nil
+}
+
+func (r *TripRepository) updateAvailablity(tx *sql.Tx, query string, id string, newValue any, optLockVersion *int) error {
 	rowUpdateResult, err := tx.Exec(query, newValue, *optLockVersion, id, *optLockVersion)
 	if err != nil {
 		tx.Rollback()
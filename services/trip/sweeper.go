@@ -0,0 +1,115 @@
+package trip
+
+import (
+	"log"
+	"time"
+
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+// sweeperActor identifies the sweeper as the author of the events it publishes, so they are told apart from the rider's.
+const (
+	sweeperActor  = "system:trip-sweeper"
+	sweeperReason = "trip was idle for too long"
+)
+
+// TripSweeper ends trips which did not receive any event for the idle period, e.g. because the rider's phone died,
+// so that their scooters and riders are not locked forever.
+type TripSweeper struct {
+	tripsRepository     interfaces.TripRepository
+	scootersRepository  interfaces.ScooterRepository
+	usersRepository     interfaces.ClientRepository
+	fareCalculator      interfaces.FareCalculator
+	lowBatteryThreshold int
+	idlePeriod          time.Duration
+	interval            time.Duration
+	now                 func() time.Time
+}
+
+func NewTripSweeper(
+	tripsRepository interfaces.TripRepository,
+	scootersRepository interfaces.ScooterRepository,
+	usersRepository interfaces.ClientRepository,
+	fareCalculator interfaces.FareCalculator,
+	lowBatteryThreshold int,
+	idlePeriod time.Duration,
+	interval time.Duration) *TripSweeper {
+	return &TripSweeper{
+		tripsRepository:     tripsRepository,
+		scootersRepository:  scootersRepository,
+		usersRepository:     usersRepository,
+		fareCalculator:      fareCalculator,
+		lowBatteryThreshold: lowBatteryThreshold,
+		idlePeriod:          idlePeriod,
+		interval:            interval,
+		now:                 time.Now,
+	}
+}
+
+func (s *TripSweeper) Run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.EndIdleTrips()
+	}
+}
+
+// EndIdleTrips gives up on a trip whenever it races with the rider, since the trip is then no longer idle.
+func (s *TripSweeper) EndIdleTrips() {
+	trips, err := s.tripsRepository.GetIdleTrips(s.now().Add(-s.idlePeriod))
+	if err != nil {
+		log.Println("Error getting idle trips:", err)
+		return
+	}
+
+	for _, trip := range trips {
+		if err := s.endIdleTrip(trip); err != nil {
+			log.Println("Error ending idle trip", trip.ID.String()+":", err)
+		}
+	}
+}
+
+// endIdleTrip ends the trip where and when the scooter was last heard from, so the rider is not charged for the idle period.
+func (s *TripSweeper) endIdleTrip(trip *types.Trip) error {
+	lastEvent, err := s.tripsRepository.GetLastTripEvent(trip.ID.String())
+	if err != nil {
+		return err
+	}
+
+	scooter, scooterOptLockVersion, err := s.scootersRepository.GetScooterById(trip.ScooterId.String())
+	if err != nil {
+		return err
+	}
+
+	_, userOptLockVersion, err := s.usersRepository.GetUserById(trip.ClientId.String())
+	if err != nil {
+		return err
+	}
+
+	events, err := s.tripsRepository.GetTripEvents(trip.ID.String())
+	if err != nil {
+		return err
+	}
+
+	actor, reason := sweeperActor, sweeperReason
+	endTripEvent := types.TripEvent{
+		TripID:     trip.ID,
+		Type:       enums.EndTrip,
+		Location:   lastEvent.Location,
+		CreatedAt:  lastEvent.CreatedAt,
+		ReceivedAt: s.now().UTC(),
+		Sequence:   lastEvent.Sequence + 1,
+		Actor:      &actor,
+		Reason:     &reason,
+	}
+
+	fare := s.fareCalculator.CalculateFare(append(events, &endTripEvent))
+	trip.Fare = &fare.Amount
+	scooter.Status = utils.IdleScooterStatus(scooter.BatteryLevel, s.lowBatteryThreshold)
+
+	return s.tripsRepository.EndTrip(trip, scooter, scooterOptLockVersion, userOptLockVersion, endTripEvent, nil)
+}
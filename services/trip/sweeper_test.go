@@ -0,0 +1,128 @@
+package trip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/services/client"
+	"github.com/nerijusro/scootinAboot/services/scooter"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestTripSweeper(t *testing.T) {
+	startedAt := time.Date(2024, 5, 8, 9, 0, 0, 0, time.UTC)
+
+	setup := func(batteryLevel int) (*db.InMemoryStorage, *TripSweeper, types.Trip) {
+		storage := db.NewInMemoryStorage()
+		tripScooter := types.Scooter{ID: uuid.New(), Location: types.Location{Latitude: 54.68, Longitude: 25.27}, Status: enums.AvailableScooter, BatteryLevel: batteryLevel}
+		user := types.MobileClient{ID: uuid.New(), FullName: "John Doe", IsEligibleToTravel: true}
+		storage.Scooters[tripScooter.ID.String()] = &db.VersionedRecord[types.Scooter]{Value: tripScooter}
+		storage.Users[user.ID.String()] = &db.VersionedRecord[types.MobileClient]{Value: user}
+
+		tripsRepository := NewInMemoryRepository(storage)
+		trip := types.Trip{ID: uuid.New(), ScooterId: tripScooter.ID, ClientId: user.ID}
+		startEvent := types.TripEvent{TripID: trip.ID, Type: enums.StartTrip, Location: tripScooter.Location, CreatedAt: startedAt, ReceivedAt: startedAt, Sequence: 1}
+		if err := tripsRepository.StartTrip(trip, nil, new(int), new(int), startEvent); err != nil {
+			t.Fatal(err)
+		}
+
+		updateEvent := types.TripEvent{TripID: trip.ID, Type: enums.UpdateTrip, Location: types.Location{Latitude: 54.69, Longitude: 25.28}, CreatedAt: startedAt.Add(5 * time.Minute), ReceivedAt: startedAt.Add(5 * time.Minute), Sequence: 2}
+		movedScooter := storage.Scooters[tripScooter.ID.String()].Value
+		movedScooter.Location = updateEvent.Location
+		if err := tripsRepository.UpdateTrip(&trip, &movedScooter, &storage.Scooters[tripScooter.ID.String()].OptLockVersion, updateEvent, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		sweeper := NewTripSweeper(
+			tripsRepository,
+			scooter.NewInMemoryRepository(storage),
+			client.NewInMemoryRepository(storage),
+			&mockFareCalculator{},
+			15,
+			time.Hour,
+			time.Minute)
+		return storage, sweeper, trip
+	}
+
+	t.Run("When ending idle trips while trip is idle for longer than idle period ends it at the last location", func(t *testing.T) {
+		storage, sweeper, trip := setup(80)
+		sweeper.now = func() time.Time { return startedAt.Add(2 * time.Hour) }
+
+		sweeper.EndIdleTrips()
+
+		if !storage.Trips[trip.ID.String()].IsFinished {
+			t.Fatalf("expected trip to be finished")
+		}
+
+		if storage.Trips[trip.ID.String()].Fare == nil {
+			t.Errorf("expected fare to be persisted")
+		}
+
+		lastEvent := storage.Events[len(storage.Events)-1]
+		if lastEvent.Type != enums.EndTrip || lastEvent.Sequence != 3 {
+			t.Errorf("expected end trip event with sequence 3, got %s with sequence %d", lastEvent.Type, lastEvent.Sequence)
+		}
+
+		if lastEvent.Actor == nil || *lastEvent.Actor != sweeperActor || lastEvent.Reason == nil {
+			t.Errorf("expected end trip event to be attributed to the trip sweeper, got %v", lastEvent.Actor)
+		}
+
+		if lastEvent.Location.Latitude != 54.69 || lastEvent.Location.Longitude != 25.28 {
+			t.Errorf("expected trip to end at the last known location, got %v", lastEvent.Location)
+		}
+
+		if storage.Scooters[trip.ScooterId.String()].Value.Status != enums.AvailableScooter {
+			t.Errorf("expected scooter to be available")
+		}
+
+		if !storage.Users[trip.ClientId.String()].Value.IsEligibleToTravel {
+			t.Errorf("expected user to be eligible to travel")
+		}
+	})
+
+	t.Run("When ending idle trips while trip received an event within idle period leaves it open", func(t *testing.T) {
+		storage, sweeper, trip := setup(80)
+		sweeper.now = func() time.Time { return startedAt.Add(30 * time.Minute) }
+
+		sweeper.EndIdleTrips()
+
+		if storage.Trips[trip.ID.String()].IsFinished {
+			t.Errorf("expected trip to stay open")
+		}
+
+		if storage.Scooters[trip.ScooterId.String()].Value.Status != enums.InTripScooter {
+			t.Errorf("expected scooter to stay in trip")
+		}
+	})
+
+	t.Run("When ending idle trips while rider's phone clock is behind leaves trip open", func(t *testing.T) {
+		storage, sweeper, trip := setup(80)
+		lateEvent := types.TripEvent{TripID: trip.ID, Type: enums.UpdateTrip, Location: types.Location{Latitude: 54.69, Longitude: 25.28}, CreatedAt: startedAt.Add(-90 * time.Minute), ReceivedAt: startedAt.Add(20 * time.Minute), Sequence: 3}
+		storage.Events = append(storage.Events, lateEvent)
+		sweeper.now = func() time.Time { return startedAt.Add(70 * time.Minute) }
+
+		sweeper.EndIdleTrips()
+
+		if storage.Trips[trip.ID.String()].IsFinished {
+			t.Errorf("expected trip to stay open")
+		}
+	})
+
+	t.Run("When ending idle trips while scooter battery is low leaves scooter charging", func(t *testing.T) {
+		storage, sweeper, trip := setup(10)
+		sweeper.now = func() time.Time { return startedAt.Add(2 * time.Hour) }
+
+		sweeper.EndIdleTrips()
+
+		if !storage.Trips[trip.ID.String()].IsFinished {
+			t.Fatalf("expected trip to be finished")
+		}
+
+		if storage.Scooters[trip.ScooterId.String()].Value.Status != enums.ChargingScooter {
+			t.Errorf("expected scooter to be charging")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package trip
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -10,6 +11,8 @@ import (
 
 type TripValidator struct{}
 
+const maxTripsPageSize = 100
+
 var Validator = validator.New()
 
 func NewTripValidator() *TripValidator {
@@ -49,5 +52,48 @@ func (s *TripValidator) ValidateTripUpdateRequest(request *types.TripUpdateReque
 		return errors.New("invalid sequence")
 	}
 
+	if request.BatteryLevel != nil && (*request.BatteryLevel < 0 || *request.BatteryLevel > 100) {
+		return errors.New("invalid battery_level")
+	}
+
+	if countTrue(request.IsFinishing, request.IsPausing, request.IsResuming) > 1 {
+		return errors.New("only one of is_finishing, is_pausing and is_resuming can be set")
+	}
+
 	return nil
 }
+
+func (s *TripValidator) ValidateCloseTripRequest(request *types.CloseTripRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(request.Reason) == "" || len(request.Reason) > 1024 {
+		return errors.New("invalid reason")
+	}
+
+	return nil
+}
+
+func (s *TripValidator) ValidateGetTripsQueryParameters(queryParams *types.GetTripsQueryParameters) error {
+	if queryParams.Limit < 0 || queryParams.Limit > maxTripsPageSize {
+		return errors.New("invalid limit")
+	}
+
+	if queryParams.Offset < 0 {
+		return errors.New("invalid offset")
+	}
+
+	return nil
+}
+
+func countTrue(values ...bool) int {
+	count := 0
+	for _, value := range values {
+		if value {
+			count++
+		}
+	}
+
+	return count
+}
@@ -98,4 +98,79 @@ func TestTripValidator(t *testing.T) {
 			t.Errorf("expected result to be: Key: 'TripUpdateRequest.Sequence' Error:Field validation for 'Sequence' failed on the 'required' tag, got %s", result.Error())
 		}
 	})
+
+	t.Run("When validating trip update request while given invalid battery level returns error", func(t *testing.T) {
+		batteryLevel := 101
+		requestBody := types.TripUpdateRequest{
+			Location:     types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt:    time.Now(),
+			Sequence:     2,
+			BatteryLevel: &batteryLevel,
+		}
+
+		result := validator.ValidateTripUpdateRequest(&requestBody)
+		if result == nil || result.Error() != "invalid battery_level" {
+			t.Errorf("expected result to be: invalid battery_level, got %v", result)
+		}
+	})
+
+	t.Run("When validating trip update request while pausing and finishing at once returns error", func(t *testing.T) {
+		requestBody := types.TripUpdateRequest{
+			Location:    types.Location{Latitude: 54.12, Longitude: 25.34},
+			CreatedAt:   time.Now(),
+			Sequence:    2,
+			IsFinishing: true,
+			IsPausing:   true,
+		}
+
+		result := validator.ValidateTripUpdateRequest(&requestBody)
+		if result == nil || result.Error() != "only one of is_finishing, is_pausing and is_resuming can be set" {
+			t.Errorf("expected result to be: only one of is_finishing, is_pausing and is_resuming can be set, got %v", result)
+		}
+	})
+
+	t.Run("When validating close trip request while given valid reason returns nil", func(t *testing.T) {
+		requestBody := types.CloseTripRequest{Reason: "rider's phone died"}
+
+		result := validator.ValidateCloseTripRequest(&requestBody)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating close trip request while reason is blank returns error", func(t *testing.T) {
+		requestBody := types.CloseTripRequest{Reason: "   "}
+
+		result := validator.ValidateCloseTripRequest(&requestBody)
+		if result == nil || result.Error() != "invalid reason" {
+			t.Errorf("expected result to be: invalid reason, got %v", result)
+		}
+	})
+
+	t.Run("When validating get trips query while given valid params returns nil", func(t *testing.T) {
+		queryParams := types.GetTripsQueryParameters{Limit: 50, Offset: 100}
+
+		result := validator.ValidateGetTripsQueryParameters(&queryParams)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating get trips query while given invalid limit returns error", func(t *testing.T) {
+		queryParams := types.GetTripsQueryParameters{Limit: 101}
+
+		result := validator.ValidateGetTripsQueryParameters(&queryParams)
+		if result == nil || result.Error() != "invalid limit" {
+			t.Errorf("expected result to be invalid limit, got %v", result)
+		}
+	})
+
+	t.Run("When validating get trips query while given negative offset returns error", func(t *testing.T) {
+		queryParams := types.GetTripsQueryParameters{Offset: -1}
+
+		result := validator.ValidateGetTripsQueryParameters(&queryParams)
+		if result == nil || result.Error() != "invalid offset" {
+			t.Errorf("expected result to be invalid offset, got %v", result)
+		}
+	})
 }
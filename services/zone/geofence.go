@@ -0,0 +1,210 @@
+package zone
+
+import (
+	"errors"
+	"math"
+
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+	"github.com/nerijusro/scootinAboot/utils"
+)
+
+var (
+	ErrOutsideOperatingArea = errors.New("location is outside of the operating area")
+	ErrParkingNotAllowed    = errors.New("parking is not allowed in this zone")
+	ErrParkingStationNeeded = errors.New("trips started in this zone must end at a parking station")
+	ErrParkingStationFull   = errors.New("parking station is full")
+)
+
+// Geofence checks trip locations against the zones. While no operating zone exists, the whole world is operating area.
+type Geofence struct {
+	zonesRepository    interfaces.ZoneRepository
+	stationsRepository interfaces.ParkingStationRepository
+	slowZoneSpeedLimit float64
+}
+
+func NewGeofence(
+	zonesRepository interfaces.ZoneRepository,
+	stationsRepository interfaces.ParkingStationRepository,
+	slowZoneSpeedLimitKmh int) *Geofence {
+	return &Geofence{
+		zonesRepository:    zonesRepository,
+		stationsRepository: stationsRepository,
+		slowZoneSpeedLimit: float64(slowZoneSpeedLimitKmh) * 1000 / 3600,
+	}
+}
+
+// CheckMove returns the violations committed by riding from the last event's location to the given event's location.
+// The rider is not stopped, since the scooter already is where it is, the violations are recorded instead.
+func (g *Geofence) CheckMove(lastEvent *types.TripEvent, event types.TripEvent) ([]types.ZoneViolation, error) {
+	zones, err := g.zonesRepository.GetZones()
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]types.ZoneViolation, 0)
+	if !isInOperatingArea(zones, event.Location) {
+		violations = append(violations, newViolation(event, nil, enums.LeftOperatingArea))
+	}
+
+	for _, zone := range zones {
+		if !utils.PolygonContains(zone.Geometry, event.Location) {
+			continue
+		}
+
+		switch {
+		case zone.Type == enums.NoRideZone:
+			violations = append(violations, newViolation(event, zone, enums.EnteredNoRideZone))
+		case zone.Type == enums.SlowZone && g.isSpeeding(lastEvent, event):
+			violations = append(violations, newViolation(event, zone, enums.SpeedingInSlowZone))
+		}
+	}
+
+	return violations, nil
+}
+
+// CheckParking rejects ending a trip outside of the operating area and in zones which may not be parked in.
+// Trips started in a station required zone must also end at a parking station which has room left, wherever that station is.
+func (g *Geofence) CheckParking(startLocation types.Location, location types.Location) error {
+	zones, err := g.zonesRepository.GetZones()
+	if err != nil {
+		return err
+	}
+
+	if !isInOperatingArea(zones, location) {
+		return ErrOutsideOperatingArea
+	}
+
+	for _, zone := range zones {
+		if (zone.Type == enums.NoParkingZone || zone.Type == enums.NoRideZone) && utils.PolygonContains(zone.Geometry, location) {
+			return ErrParkingNotAllowed
+		}
+	}
+
+	if !isInZoneOfType(zones, enums.StationRequiredZone, startLocation) {
+		return nil
+	}
+
+	stations, err := g.stationsRepository.GetParkingStations()
+	if err != nil {
+		return err
+	}
+
+	isAtFullStation := false
+	for _, station := range stations {
+		if !stationContains(station, location) {
+			continue
+		}
+
+		parkedScooters, err := g.countParkedScooters(station)
+		if err != nil {
+			return err
+		}
+
+		if parkedScooters < station.Capacity {
+			return nil
+		}
+		isAtFullStation = true
+	}
+
+	if isAtFullStation {
+		return ErrParkingStationFull
+	}
+
+	return ErrParkingStationNeeded
+}
+
+// countParkedScooters counts the scooters standing at the station, the scooter of the ending trip is still in it and is not counted.
+func (g *Geofence) countParkedScooters(station *types.ParkingStation) (int, error) {
+	minLatitude, maxLatitude, minLongitude, maxLongitude := stationBounds(station)
+	locations, err := g.stationsRepository.GetParkedScooterLocations(minLatitude, maxLatitude, minLongitude, maxLongitude)
+	if err != nil {
+		return 0, err
+	}
+
+	parkedScooters := 0
+	for _, location := range locations {
+		if stationContains(station, location) {
+			parkedScooters++
+		}
+	}
+
+	return parkedScooters, nil
+}
+
+// isSpeeding compares the average speed between the two events with the slow zone speed limit.
+func (g *Geofence) isSpeeding(lastEvent *types.TripEvent, event types.TripEvent) bool {
+	seconds := event.CreatedAt.Sub(lastEvent.CreatedAt).Seconds()
+	if seconds <= 0 {
+		return false
+	}
+
+	return utils.HaversineDistance(lastEvent.Location, event.Location)/seconds > g.slowZoneSpeedLimit
+}
+
+func isInOperatingArea(zones []*types.Zone, location types.Location) bool {
+	hasOperatingZones := false
+	for _, zone := range zones {
+		if zone.Type != enums.OperatingZone {
+			continue
+		}
+
+		if utils.PolygonContains(zone.Geometry, location) {
+			return true
+		}
+		hasOperatingZones = true
+	}
+
+	return !hasOperatingZones
+}
+
+func isInZoneOfType(zones []*types.Zone, zoneType enums.ZoneType, location types.Location) bool {
+	for _, zone := range zones {
+		if zone.Type == zoneType && utils.PolygonContains(zone.Geometry, location) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stationContains(station *types.ParkingStation, location types.Location) bool {
+	if station.Geometry != nil {
+		return utils.PolygonContains(*station.Geometry, location)
+	}
+
+	return utils.HaversineDistance(station.Center, location) <= station.RadiusMeters
+}
+
+// stationBounds returns the box enclosing the station, so only the scooters around it have to be looked at.
+func stationBounds(station *types.ParkingStation) (float64, float64, float64, float64) {
+	if station.Geometry == nil {
+		return utils.BoundingBox(station.Center, station.RadiusMeters)
+	}
+
+	minLatitude, maxLatitude := math.Inf(1), math.Inf(-1)
+	minLongitude, maxLongitude := math.Inf(1), math.Inf(-1)
+	for _, position := range station.Geometry.Coordinates[0] {
+		minLongitude, maxLongitude = math.Min(minLongitude, position[0]), math.Max(maxLongitude, position[0])
+		minLatitude, maxLatitude = math.Min(minLatitude, position[1]), math.Max(maxLatitude, position[1])
+	}
+
+	return minLatitude, maxLatitude, minLongitude, maxLongitude
+}
+
+func newViolation(event types.TripEvent, zone *types.Zone, violationType enums.ZoneViolationType) types.ZoneViolation {
+	violation := types.ZoneViolation{
+		TripID:    event.TripID,
+		Sequence:  event.Sequence,
+		Type:      violationType,
+		Location:  event.Location,
+		CreatedAt: event.CreatedAt,
+	}
+
+	if zone != nil {
+		violation.ZoneID = &zone.ID
+	}
+
+	return violation
+}
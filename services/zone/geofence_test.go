@@ -0,0 +1,276 @@
+package zone
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestGeofence(t *testing.T) {
+	createdAt := time.Date(2024, 5, 9, 9, 0, 0, 0, time.UTC)
+
+	setup := func(zones ...types.Zone) *Geofence {
+		storage := db.NewInMemoryStorage()
+		for _, zone := range zones {
+			zone.ID = uuid.New()
+			storage.Zones[zone.ID.String()] = &zone
+		}
+
+		stations := &mockParkingStationRepository{stations: []*types.ParkingStation{
+			{ID: uuid.New(), Name: "Cathedral", Center: types.Location{Latitude: 54.686, Longitude: 25.288}, RadiusMeters: 30, Capacity: 2},
+			{ID: uuid.New(), Name: "Town hall", Geometry: &townHallStation, Capacity: 10},
+		}}
+
+		return NewGeofence(NewInMemoryRepository(storage), stations, 15)
+	}
+
+	city := types.Zone{Name: "Vilnius", Type: enums.OperatingZone, Geometry: square(25.2, 54.6, 25.4, 54.8)}
+	eventAt := func(latitude float64, longitude float64, after time.Duration) types.TripEvent {
+		return types.TripEvent{TripID: uuid.New(), Type: enums.UpdateTrip, Location: types.Location{Latitude: latitude, Longitude: longitude}, CreatedAt: createdAt.Add(after), Sequence: 2}
+	}
+
+	t.Run("When checking move while no operating zone exists returns no violations", func(t *testing.T) {
+		geofence := setup()
+		lastEvent := eventAt(10, 10, 0)
+
+		violations, err := geofence.CheckMove(&lastEvent, eventAt(10.001, 10, time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(violations) != 0 {
+			t.Errorf("expected no violations, got %v", violations)
+		}
+	})
+
+	t.Run("When checking move while leaving operating area returns violation without zone", func(t *testing.T) {
+		geofence := setup(city)
+		lastEvent := eventAt(54.79, 25.3, 0)
+
+		violations, err := geofence.CheckMove(&lastEvent, eventAt(54.81, 25.3, 10*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(violations) != 1 || violations[0].Type != enums.LeftOperatingArea || violations[0].ZoneID != nil {
+			t.Errorf("expected a single %s violation without zone, got %v", enums.LeftOperatingArea, violations)
+		}
+	})
+
+	t.Run("When checking move while entering no ride zone returns violation with zone", func(t *testing.T) {
+		park := types.Zone{Name: "Vingis park", Type: enums.NoRideZone, Geometry: square(25.22, 54.67, 25.24, 54.69)}
+		geofence := setup(city, park)
+		lastEvent := eventAt(54.66, 25.23, 0)
+
+		violations, err := geofence.CheckMove(&lastEvent, eventAt(54.68, 25.23, 10*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(violations) != 1 || violations[0].Type != enums.EnteredNoRideZone || violations[0].ZoneID == nil {
+			t.Errorf("expected a single %s violation with zone, got %v", enums.EnteredNoRideZone, violations)
+		}
+	})
+
+	t.Run("When checking move while inside a hole of no ride zone returns no violations", func(t *testing.T) {
+		park := types.Zone{Name: "Vingis park", Type: enums.NoRideZone, Geometry: square(25.22, 54.67, 25.24, 54.69)}
+		park.Geometry.Coordinates = append(park.Geometry.Coordinates, square(25.225, 54.675, 25.235, 54.685).Coordinates[0])
+		geofence := setup(city, park)
+		lastEvent := eventAt(54.66, 25.23, 0)
+
+		violations, err := geofence.CheckMove(&lastEvent, eventAt(54.68, 25.23, 10*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(violations) != 0 {
+			t.Errorf("expected no violations, got %v", violations)
+		}
+	})
+
+	t.Run("When checking move while riding fast into slow zone returns violation", func(t *testing.T) {
+		oldTown := types.Zone{Name: "Old Town", Type: enums.SlowZone, Geometry: square(25.28, 54.67, 25.30, 54.69)}
+		geofence := setup(city, oldTown)
+		lastEvent := eventAt(54.66, 25.29, 0)
+
+		// About 2.2 km in 5 minutes is roughly 27 km/h
+		violations, err := geofence.CheckMove(&lastEvent, eventAt(54.68, 25.29, 5*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(violations) != 1 || violations[0].Type != enums.SpeedingInSlowZone {
+			t.Errorf("expected a single %s violation, got %v", enums.SpeedingInSlowZone, violations)
+		}
+	})
+
+	t.Run("When checking move while riding slowly into slow zone returns no violations", func(t *testing.T) {
+		oldTown := types.Zone{Name: "Old Town", Type: enums.SlowZone, Geometry: square(25.28, 54.67, 25.30, 54.69)}
+		geofence := setup(city, oldTown)
+		lastEvent := eventAt(54.66, 25.29, 0)
+
+		violations, err := geofence.CheckMove(&lastEvent, eventAt(54.68, 25.29, 15*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(violations) != 0 {
+			t.Errorf("expected no violations, got %v", violations)
+		}
+	})
+
+	t.Run("When checking parking while inside no parking zone returns error", func(t *testing.T) {
+		cathedralSquare := types.Zone{Name: "Cathedral square", Type: enums.NoParkingZone, Geometry: square(25.28, 54.68, 25.29, 54.69)}
+		geofence := setup(city, cathedralSquare)
+
+		location := types.Location{Latitude: 54.685, Longitude: 25.285}
+		err := geofence.CheckParking(location, location)
+		if !errors.Is(err, ErrParkingNotAllowed) {
+			t.Errorf("expected error: %s, got %v", ErrParkingNotAllowed.Error(), err)
+		}
+	})
+
+	t.Run("When checking parking while outside operating area returns error", func(t *testing.T) {
+		geofence := setup(city)
+
+		err := geofence.CheckParking(types.Location{Latitude: 54.7, Longitude: 25.3}, types.Location{Latitude: 54.9, Longitude: 25.3})
+		if !errors.Is(err, ErrOutsideOperatingArea) {
+			t.Errorf("expected error: %s, got %v", ErrOutsideOperatingArea.Error(), err)
+		}
+	})
+
+	t.Run("When checking parking while inside operating area returns nil", func(t *testing.T) {
+		geofence := setup(city)
+
+		location := types.Location{Latitude: 54.7, Longitude: 25.3}
+		err := geofence.CheckParking(location, location)
+		if err != nil {
+			t.Errorf("expected no error, got %s", err.Error())
+		}
+	})
+
+	oldTown := types.Zone{Name: "Old Town", Type: enums.StationRequiredZone, Geometry: square(25.28, 54.67, 25.30, 54.69)}
+
+	t.Run("When checking parking while trip started in station required zone and ends outside of stations returns error", func(t *testing.T) {
+		geofence := setup(city, oldTown)
+
+		err := geofence.CheckParking(types.Location{Latitude: 54.68, Longitude: 25.29}, types.Location{Latitude: 54.75, Longitude: 25.35})
+		if !errors.Is(err, ErrParkingStationNeeded) {
+			t.Errorf("expected error: %s, got %v", ErrParkingStationNeeded.Error(), err)
+		}
+	})
+
+	t.Run("When checking parking while trip started in station required zone and ends within station radius returns nil", func(t *testing.T) {
+		geofence := setup(city, oldTown)
+
+		err := geofence.CheckParking(types.Location{Latitude: 54.68, Longitude: 25.29}, types.Location{Latitude: 54.6861, Longitude: 25.2881})
+		if err != nil {
+			t.Errorf("expected no error, got %s", err.Error())
+		}
+	})
+
+	t.Run("When checking parking while trip started in station required zone and ends in full station returns error", func(t *testing.T) {
+		geofence := setup(city, oldTown)
+		geofence.stationsRepository.(*mockParkingStationRepository).parkedScooters = []types.Location{
+			{Latitude: 54.686, Longitude: 25.288},
+			{Latitude: 54.6861, Longitude: 25.2882},
+		}
+
+		err := geofence.CheckParking(types.Location{Latitude: 54.68, Longitude: 25.29}, types.Location{Latitude: 54.6861, Longitude: 25.2881})
+		if !errors.Is(err, ErrParkingStationFull) {
+			t.Errorf("expected error: %s, got %v", ErrParkingStationFull.Error(), err)
+		}
+	})
+
+	t.Run("When checking parking while trip started in station required zone and scooters stand just outside of station returns nil", func(t *testing.T) {
+		geofence := setup(city, oldTown)
+		geofence.stationsRepository.(*mockParkingStationRepository).parkedScooters = []types.Location{
+			{Latitude: 54.686, Longitude: 25.288},
+			{Latitude: 54.6862, Longitude: 25.2884},
+		}
+
+		err := geofence.CheckParking(types.Location{Latitude: 54.68, Longitude: 25.29}, types.Location{Latitude: 54.6861, Longitude: 25.2881})
+		if err != nil {
+			t.Errorf("expected no error, got %s", err.Error())
+		}
+	})
+
+	t.Run("When checking parking while trip started in station required zone and ends in polygon station returns nil", func(t *testing.T) {
+		geofence := setup(city, oldTown)
+
+		err := geofence.CheckParking(types.Location{Latitude: 54.68, Longitude: 25.29}, types.Location{Latitude: 54.6775, Longitude: 25.2875})
+		if err != nil {
+			t.Errorf("expected no error, got %s", err.Error())
+		}
+	})
+
+	t.Run("When checking parking while trip started outside of station required zone returns nil", func(t *testing.T) {
+		geofence := setup(city, oldTown)
+
+		err := geofence.CheckParking(types.Location{Latitude: 54.75, Longitude: 25.35}, types.Location{Latitude: 54.76, Longitude: 25.35})
+		if err != nil {
+			t.Errorf("expected no error, got %s", err.Error())
+		}
+	})
+}
+
+var townHallStation = square(25.287, 54.677, 25.288, 54.678)
+
+type mockParkingStationRepository struct {
+	stations       []*types.ParkingStation
+	parkedScooters []types.Location
+}
+
+// CreateParkingStation implements interfaces.ParkingStationRepository.
+func (m *mockParkingStationRepository) CreateParkingStation(station types.ParkingStation) error {
+	panic("unimplemented")
+}
+
+func (m *mockParkingStationRepository) GetParkingStations() ([]*types.ParkingStation, error) {
+	return m.stations, nil
+}
+
+// GetParkingStationById implements interfaces.ParkingStationRepository.
+func (m *mockParkingStationRepository) GetParkingStationById(id string) (*types.ParkingStation, error) {
+	panic("unimplemented")
+}
+
+// GetNearbyParkingStations implements interfaces.ParkingStationRepository.
+func (m *mockParkingStationRepository) GetNearbyParkingStations(queryParams types.GetParkingStationsQueryParameters) ([]*types.NearbyParkingStation, error) {
+	panic("unimplemented")
+}
+
+// DeleteParkingStation implements interfaces.ParkingStationRepository.
+func (m *mockParkingStationRepository) DeleteParkingStation(id string) error {
+	panic("unimplemented")
+}
+
+func (m *mockParkingStationRepository) GetParkedScooterLocations(minLatitude float64, maxLatitude float64, minLongitude float64, maxLongitude float64) ([]types.Location, error) {
+	locations := make([]types.Location, 0)
+	for _, location := range m.parkedScooters {
+		if location.Latitude >= minLatitude && location.Latitude <= maxLatitude && location.Longitude >= minLongitude && location.Longitude <= maxLongitude {
+			locations = append(locations, location)
+		}
+	}
+
+	return locations, nil
+}
+
+// square returns a polygon between the given corners, positions are [longitude, latitude] as in GeoJSON.
+func square(minLongitude float64, minLatitude float64, maxLongitude float64, maxLatitude float64) types.GeoJSONPolygon {
+	return types.GeoJSONPolygon{
+		Type: "Polygon",
+		Coordinates: [][][]float64{{
+			{minLongitude, minLatitude},
+			{maxLongitude, minLatitude},
+			{maxLongitude, maxLatitude},
+			{minLongitude, maxLatitude},
+			{minLongitude, minLatitude},
+		}},
+	}
+}
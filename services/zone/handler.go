@@ -0,0 +1,110 @@
+package zone
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+)
+
+type ZoneHandler struct {
+	repository interfaces.ZoneRepository
+	validator  interfaces.ZoneValidator
+}
+
+func NewZoneHandler(repository interfaces.ZoneRepository, validator interfaces.ZoneValidator) *ZoneHandler {
+	return &ZoneHandler{repository: repository, validator: validator}
+}
+
+func (h *ZoneHandler) RegisterEndpoints(routes interfaces.Routes) {
+	routes.Admin(enums.WriteZones).POST("/zones", h.createZone)
+	routes.Admin(enums.ReadZones).GET("/zones", h.getZones)
+	routes.Admin(enums.WriteZones).DELETE("/zones/:id", h.deleteZone)
+	routes.Admin(enums.ReadTrips).GET("/trips/:id/violations", h.getTripViolations)
+}
+
+func (h *ZoneHandler) createZone(c *gin.Context) {
+	var request types.CreateZoneRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request body": err.Error()})
+		return
+	}
+
+	if err := h.validator.ValidateCreateZoneRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	zone := types.Zone{
+		ID:        uuid.New(),
+		Name:      request.Name,
+		Type:      request.Type,
+		Geometry:  request.Geometry,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if err := h.repository.CreateZone(zone); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, zone)
+}
+
+func (h *ZoneHandler) getZones(c *gin.Context) {
+	zones, err := h.repository.GetZones()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.GetZonesResponse{Zones: zones})
+}
+
+func (h *ZoneHandler) deleteZone(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	zone, err := h.repository.GetZoneById(id.String())
+	if errors.Is(err, ErrZoneNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"Not found": err.Error()})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	// Violations keep referring to the deleted zone, so that past trips can still be explained
+	if err := h.repository.DeleteZone(id.String()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, zone)
+}
+
+func (h *ZoneHandler) getTripViolations(c *gin.Context) {
+	tripId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Bad request": err.Error()})
+		return
+	}
+
+	violations, err := h.repository.GetTripViolations(tripId.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Internal Server Error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.GetZoneViolationsResponse{Violations: violations})
+}
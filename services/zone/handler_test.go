@@ -0,0 +1,197 @@
+package zone
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestZoneHandler(t *testing.T) {
+	repository := &mockZoneRepository{}
+	validator := &mockZoneValidator{}
+	handler := NewZoneHandler(repository, validator)
+
+	t.Run("When creating zone while given valid request returns created zone", func(t *testing.T) {
+		requestBody := types.CreateZoneRequest{Name: "Old Town", Type: enums.NoParkingZone, Geometry: square(25.28, 54.67, 25.29, 54.68)}
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/admin/zones", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/zones", handler.createZone)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusCreated {
+			t.Errorf("expected status code %d but got %d", http.StatusCreated, responseRecoreder.Code)
+		}
+
+		var response types.Zone
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if response.ID == uuid.Nil || response.Type != enums.NoParkingZone {
+			t.Errorf("expected a %s zone with an id, got %v", enums.NoParkingZone, response)
+		}
+
+		if repository.created.ID != response.ID {
+			t.Errorf("expected zone %s to be stored, got %s", response.ID, repository.created.ID)
+		}
+	})
+
+	t.Run("When creating zone while request is invalid returns bad request", func(t *testing.T) {
+		requestBody := types.CreateZoneRequest{Name: "Old Town", Type: "parking_lot", Geometry: square(25.28, 54.67, 25.29, 54.68)}
+		marshalledRequestBody, _ := json.Marshal(requestBody)
+		request, err := http.NewRequest(http.MethodPost, "/admin/zones", bytes.NewBuffer(marshalledRequestBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.POST("/admin/zones", handler.createZone)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When deleting zone while zone exists returns deleted zone", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodDelete, "/admin/zones/"+zoneId.String(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.DELETE("/admin/zones/:id", handler.deleteZone)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		if repository.deletedId != zoneId.String() {
+			t.Errorf("expected zone %s to be deleted, got %s", zoneId.String(), repository.deletedId)
+		}
+	})
+
+	t.Run("When deleting zone while zone does not exist returns not found", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodDelete, "/admin/zones/"+uuid.New().String(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.DELETE("/admin/zones/:id", handler.deleteZone)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusNotFound {
+			t.Errorf("expected status code %d but got %d", http.StatusNotFound, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting trip violations while trip id is invalid returns bad request", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/admin/trips/invalid/violations", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/admin/trips/:id/violations", handler.getTripViolations)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusBadRequest {
+			t.Errorf("expected status code %d but got %d", http.StatusBadRequest, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When getting trip violations while everything is valid returns violations", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, "/admin/trips/"+uuid.New().String()+"/violations", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := gin.Default()
+		router.GET("/admin/trips/:id/violations", handler.getTripViolations)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		var response types.GetZoneViolationsResponse
+		if err := json.NewDecoder(responseRecoreder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(response.Violations) != 1 || response.Violations[0].Type != enums.EnteredNoRideZone {
+			t.Errorf("expected a single %s violation, got %v", enums.EnteredNoRideZone, response.Violations)
+		}
+	})
+}
+
+var zoneId = uuid.MustParse("8a1d2c3b-4e5f-4a6b-9c7d-0e1f2a3b1111")
+
+type mockZoneRepository struct {
+	created   types.Zone
+	deletedId string
+}
+
+func (m *mockZoneRepository) CreateZone(zone types.Zone) error {
+	m.created = zone
+	return nil
+}
+
+// GetZones implements interfaces.ZoneRepository.
+func (m *mockZoneRepository) GetZones() ([]*types.Zone, error) {
+	panic("unimplemented")
+}
+
+func (m *mockZoneRepository) GetZoneById(id string) (*types.Zone, error) {
+	if id != zoneId.String() {
+		return nil, ErrZoneNotFound
+	}
+
+	return &types.Zone{ID: zoneId, Name: "Old Town", Type: enums.NoParkingZone}, nil
+}
+
+func (m *mockZoneRepository) DeleteZone(id string) error {
+	m.deletedId = id
+	return nil
+}
+
+func (m *mockZoneRepository) GetTripViolations(tripId string) ([]*types.ZoneViolation, error) {
+	return []*types.ZoneViolation{{TripID: uuid.MustParse(tripId), Sequence: 2, ZoneID: &zoneId, Type: enums.EnteredNoRideZone}}, nil
+}
+
+type mockZoneValidator struct{}
+
+func (m *mockZoneValidator) ValidateCreateZoneRequest(request *types.CreateZoneRequest) error {
+	if request.Type != enums.OperatingZone && request.Type != enums.NoParkingZone && request.Type != enums.SlowZone && request.Type != enums.NoRideZone {
+		return errors.New("invalid zone_type")
+	}
+
+	return nil
+}
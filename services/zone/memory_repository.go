@@ -0,0 +1,89 @@
+package zone
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nerijusro/scootinAboot/db"
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type InMemoryZoneRepository struct {
+	storage *db.InMemoryStorage
+}
+
+func NewInMemoryRepository(storage *db.InMemoryStorage) *InMemoryZoneRepository {
+	return &InMemoryZoneRepository{storage: storage}
+}
+
+func (r *InMemoryZoneRepository) CreateZone(zone types.Zone) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	if _, ok := r.storage.Zones[zone.ID.String()]; ok {
+		return fmt.Errorf("zone with id %s already exists", zone.ID.String())
+	}
+
+	r.storage.Zones[zone.ID.String()] = &zone
+	return nil
+}
+
+func (r *InMemoryZoneRepository) GetZones() ([]*types.Zone, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	zones := make([]*types.Zone, 0, len(r.storage.Zones))
+	for _, zone := range r.storage.Zones {
+		result := *zone
+		zones = append(zones, &result)
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		if !zones[i].CreatedAt.Equal(zones[j].CreatedAt) {
+			return zones[i].CreatedAt.Before(zones[j].CreatedAt)
+		}
+		return zones[i].ID.String() < zones[j].ID.String()
+	})
+
+	return zones, nil
+}
+
+func (r *InMemoryZoneRepository) GetZoneById(id string) (*types.Zone, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	zone, ok := r.storage.Zones[id]
+	if !ok {
+		return nil, ErrZoneNotFound
+	}
+
+	result := *zone
+	return &result, nil
+}
+
+func (r *InMemoryZoneRepository) DeleteZone(id string) error {
+	r.storage.Lock()
+	defer r.storage.Unlock()
+
+	delete(r.storage.Zones, id)
+	return nil
+}
+
+func (r *InMemoryZoneRepository) GetTripViolations(tripId string) ([]*types.ZoneViolation, error) {
+	r.storage.RLock()
+	defer r.storage.RUnlock()
+
+	violations := make([]*types.ZoneViolation, 0)
+	for _, storedViolation := range r.storage.ZoneViolations {
+		if storedViolation.TripID.String() == tripId {
+			violation := storedViolation
+			violations = append(violations, &violation)
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Sequence < violations[j].Sequence
+	})
+
+	return violations, nil
+}
@@ -0,0 +1,124 @@
+package zone
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+type ZoneRepository struct {
+	db *sql.DB
+}
+
+var ErrZoneNotFound = errors.New("zone not found")
+
+const zoneColumns = "id, name, zone_type, geometry, created_at"
+
+func NewRepository(db *sql.DB) *ZoneRepository {
+	return &ZoneRepository{db: db}
+}
+
+func (r *ZoneRepository) CreateZone(zone types.Zone) error {
+	geometry, err := json.Marshal(zone.Geometry)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec("INSERT INTO zones (id, name, zone_type, geometry, created_at) VALUES (UUID_TO_BIN(?, false), ?, ?, ?, ?)",
+		zone.ID.String(), zone.Name, zone.Type, geometry, zone.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ZoneRepository) GetZones() ([]*types.Zone, error) {
+	rows, err := r.db.Query("SELECT " + zoneColumns + " FROM zones ORDER BY created_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := make([]*types.Zone, 0)
+	for rows.Next() {
+		zone, err := scanRowIntoZone(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		zones = append(zones, zone)
+	}
+
+	return zones, rows.Err()
+}
+
+func (r *ZoneRepository) GetZoneById(id string) (*types.Zone, error) {
+	rows, err := r.db.Query("SELECT "+zoneColumns+" FROM zones WHERE id = UUID_TO_BIN(?, false)", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var zone *types.Zone
+	for rows.Next() {
+		zone, err = scanRowIntoZone(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if zone == nil {
+		return nil, ErrZoneNotFound
+	}
+
+	return zone, rows.Err()
+}
+
+func (r *ZoneRepository) DeleteZone(id string) error {
+	_, err := r.db.Exec("DELETE FROM zones WHERE id = UUID_TO_BIN(?, false)", id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ZoneRepository) GetTripViolations(tripId string) ([]*types.ZoneViolation, error) {
+	rows, err := r.db.Query("SELECT trip_id, sequence, zone_id, violation_type, latitude, longitude, created_at FROM zone_violations WHERE trip_id = UUID_TO_BIN(?, false) ORDER BY sequence, id", tripId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	violations := make([]*types.ZoneViolation, 0)
+	for rows.Next() {
+		var violation types.ZoneViolation
+		err := rows.Scan(&violation.TripID, &violation.Sequence, &violation.ZoneID, &violation.Type,
+			&violation.Location.Latitude, &violation.Location.Longitude, &violation.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		violations = append(violations, &violation)
+	}
+
+	return violations, rows.Err()
+}
+
+func scanRowIntoZone(row *sql.Rows) (*types.Zone, error) {
+	var zone types.Zone
+	var geometry []byte
+
+	if err := row.Scan(&zone.ID, &zone.Name, &zone.Type, &geometry, &zone.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(geometry, &zone.Geometry); err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
@@ -0,0 +1,73 @@
+package zone
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+type ZoneValidator struct{}
+
+var Validator = validator.New()
+
+func NewZoneValidator() *ZoneValidator {
+	return &ZoneValidator{}
+}
+
+func (v *ZoneValidator) ValidateCreateZoneRequest(request *types.CreateZoneRequest) error {
+	if err := Validator.Struct(request); err != nil {
+		return err
+	}
+
+	if len(request.Name) > 255 {
+		return errors.New("name must not be longer than 255 characters")
+	}
+
+	switch request.Type {
+	case enums.OperatingZone, enums.NoParkingZone, enums.SlowZone, enums.NoRideZone, enums.StationRequiredZone:
+	default:
+		return errors.New("invalid zone_type")
+	}
+
+	return ValidatePolygon(request.Geometry)
+}
+
+// ValidatePolygon follows the GeoJSON specification: every ring is closed and has at least four positions.
+func ValidatePolygon(polygon types.GeoJSONPolygon) error {
+	if polygon.Type != "Polygon" {
+		return errors.New("geometry must be a GeoJSON Polygon")
+	}
+
+	if len(polygon.Coordinates) == 0 {
+		return errors.New("geometry must have at least one ring")
+	}
+
+	for _, ring := range polygon.Coordinates {
+		if len(ring) < 4 {
+			return errors.New("every ring must have at least four positions")
+		}
+
+		for _, position := range ring {
+			if len(position) < 2 {
+				return errors.New("every position must have a longitude and a latitude")
+			}
+
+			if position[0] < -180 || position[0] > 180 {
+				return errors.New("invalid longitude")
+			}
+
+			if position[1] < -90 || position[1] > 90 {
+				return errors.New("invalid latitude")
+			}
+		}
+
+		first, last := ring[0], ring[len(ring)-1]
+		if first[0] != last[0] || first[1] != last[1] {
+			return errors.New("every ring must end at its first position")
+		}
+	}
+
+	return nil
+}
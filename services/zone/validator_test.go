@@ -0,0 +1,61 @@
+package zone
+
+import (
+	"testing"
+
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestZoneValidator(t *testing.T) {
+	validator := NewZoneValidator()
+
+	t.Run("When validating create zone request while given valid request returns nil", func(t *testing.T) {
+		request := types.CreateZoneRequest{Name: "Vilnius", Type: enums.OperatingZone, Geometry: square(25.2, 54.6, 25.4, 54.8)}
+
+		result := validator.ValidateCreateZoneRequest(&request)
+		if result != nil {
+			t.Errorf("expected result to be nil, got %s", result.Error())
+		}
+	})
+
+	t.Run("When validating create zone request while zone type is unknown returns error", func(t *testing.T) {
+		request := types.CreateZoneRequest{Name: "Vilnius", Type: "parking_lot", Geometry: square(25.2, 54.6, 25.4, 54.8)}
+
+		result := validator.ValidateCreateZoneRequest(&request)
+		if result == nil || result.Error() != "invalid zone_type" {
+			t.Errorf("expected result to be: invalid zone_type, got %v", result)
+		}
+	})
+
+	t.Run("When validating create zone request while geometry is not a polygon returns error", func(t *testing.T) {
+		geometry := square(25.2, 54.6, 25.4, 54.8)
+		geometry.Type = "LineString"
+		request := types.CreateZoneRequest{Name: "Vilnius", Type: enums.OperatingZone, Geometry: geometry}
+
+		result := validator.ValidateCreateZoneRequest(&request)
+		if result == nil || result.Error() != "geometry must be a GeoJSON Polygon" {
+			t.Errorf("expected result to be: geometry must be a GeoJSON Polygon, got %v", result)
+		}
+	})
+
+	t.Run("When validating create zone request while ring is not closed returns error", func(t *testing.T) {
+		geometry := square(25.2, 54.6, 25.4, 54.8)
+		geometry.Coordinates[0][4] = []float64{25.3, 54.7}
+		request := types.CreateZoneRequest{Name: "Vilnius", Type: enums.OperatingZone, Geometry: geometry}
+
+		result := validator.ValidateCreateZoneRequest(&request)
+		if result == nil || result.Error() != "every ring must end at its first position" {
+			t.Errorf("expected result to be: every ring must end at its first position, got %v", result)
+		}
+	})
+
+	t.Run("When validating create zone request while longitude is out of range returns error", func(t *testing.T) {
+		request := types.CreateZoneRequest{Name: "Vilnius", Type: enums.OperatingZone, Geometry: square(179, 54.6, 181, 54.8)}
+
+		result := validator.ValidateCreateZoneRequest(&request)
+		if result == nil || result.Error() != "invalid longitude" {
+			t.Errorf("expected result to be: invalid longitude, got %v", result)
+		}
+	})
+}
@@ -8,10 +8,120 @@ const (
 	All         Availability = "all"
 )
 
+type ScooterStatus string
+
+const (
+	AvailableScooter   ScooterStatus = "available"
+	InTripScooter      ScooterStatus = "in_trip"
+	ReservedScooter    ScooterStatus = "reserved"
+	MaintenanceScooter ScooterStatus = "maintenance"
+	ChargingScooter    ScooterStatus = "charging"
+	RetiredScooter     ScooterStatus = "retired"
+)
+
+type TicketStatus string
+
+const (
+	OpenTicket     TicketStatus = "open"
+	ResolvedTicket TicketStatus = "resolved"
+)
+
+type ScooterSortField string
+
+const (
+	SortById        ScooterSortField = "id"
+	SortByLatitude  ScooterSortField = "latitude"
+	SortByLongitude ScooterSortField = "longitude"
+)
+
+type SortOrder string
+
+const (
+	Ascending  SortOrder = "asc"
+	Descending SortOrder = "desc"
+)
+
 type TripEventType string
 
 const (
-	StartTrip  TripEventType = "start_trip_event"
-	UpdateTrip TripEventType = "update_trip_event"
-	EndTrip    TripEventType = "end_trip_event"
+	StartTrip    TripEventType = "start_trip_event"
+	UpdateTrip   TripEventType = "update_trip_event"
+	PauseTrip    TripEventType = "pause_trip_event"
+	ResumeTrip   TripEventType = "resume_trip_event"
+	EndTrip      TripEventType = "end_trip_event"
+	ForceEndTrip TripEventType = "force_end_trip_event"
+	CancelTrip   TripEventType = "cancel_trip_event"
+)
+
+type ReservationStatus string
+
+const (
+	ActiveReservation   ReservationStatus = "active"
+	ConsumedReservation ReservationStatus = "consumed"
+	ExpiredReservation  ReservationStatus = "expired"
+)
+
+type ReservationEventType string
+
+const (
+	CreateReservation  ReservationEventType = "create_reservation_event"
+	ConsumeReservation ReservationEventType = "consume_reservation_event"
+	ExpireReservation  ReservationEventType = "expire_reservation_event"
+)
+
+type ZoneType string
+
+const (
+	OperatingZone       ZoneType = "operating"
+	NoParkingZone       ZoneType = "no_parking"
+	SlowZone            ZoneType = "slow"
+	NoRideZone          ZoneType = "no_ride"
+	StationRequiredZone ZoneType = "station_required"
+)
+
+type ZoneViolationType string
+
+const (
+	LeftOperatingArea  ZoneViolationType = "left_operating_area"
+	EnteredNoRideZone  ZoneViolationType = "entered_no_ride_zone"
+	SpeedingInSlowZone ZoneViolationType = "speeding_in_slow_zone"
+)
+
+type ApiKeyScope string
+
+const (
+	AdminScope  ApiKeyScope = "admin"
+	ClientScope ApiKeyScope = "client"
+)
+
+type Role string
+
+const (
+	AdminRole    Role = "admin"
+	SupportRole  Role = "support"
+	FleetOpsRole Role = "fleet_ops"
+	FinanceRole  Role = "finance"
+)
+
+type Permission string
+
+const (
+	ReadUsers       Permission = "users:read"
+	WriteUsers      Permission = "users:write"
+	ReadTrips       Permission = "trips:read"
+	WriteTrips      Permission = "trips:write"
+	IssueRefunds    Permission = "refunds:write"
+	ReadScooters    Permission = "scooters:read"
+	WriteScooters   Permission = "scooters:write"
+	ReadZones       Permission = "zones:read"
+	WriteZones      Permission = "zones:write"
+	ManageOperators Permission = "operators:write"
+	ManageApiKeys   Permission = "api_keys:write"
+)
+
+type StorageType string
+
+const (
+	MySqlStorage    StorageType = "mysql"
+	InMemoryStorage StorageType = "memory"
 )
@@ -1,17 +1,81 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
 )
 
 type EndpointHandler interface {
-	RegisterEndpoints(routerGroups map[string]*gin.RouterGroup)
+	RegisterEndpoints(routes Routes)
+}
+
+// Routes gives handlers the groups to register their endpoints on.
+// Every admin endpoint has to name the permission it requires.
+type Routes interface {
+	Public() gin.IRoutes
+	Client() gin.IRoutes
+	IdempotentClient() gin.IRoutes
+	Admin(permission enums.Permission) gin.IRoutes
+	Device() gin.IRoutes
 }
 
 type AuthService interface {
 	AuthenticateAdmin(c *gin.Context)
 	AuthenticateClient(c *gin.Context)
+	AuthenticateDevice(c *gin.Context)
+	RequirePermission(permission enums.Permission) gin.HandlerFunc
+}
+
+type RateLimiter interface {
+	Limit(c *gin.Context)
+}
+
+type IdempotencyService interface {
+	Handle(c *gin.Context)
+}
+
+// BackgroundJob runs until the process exits.
+type BackgroundJob interface {
+	Run()
+}
+
+type IdempotencyRepository interface {
+	CreateIdempotencyRecord(record types.IdempotencyRecord) error
+	GetIdempotencyRecord(caller string, key string) (*types.IdempotencyRecord, error)
+	CompleteIdempotencyRecord(record types.IdempotencyRecord) error
+	DeleteIdempotencyRecord(caller string, key string) error
+	DeleteExpiredIdempotencyRecords(createdBefore time.Time) error
+}
+
+type TokenService interface {
+	IssueToken(claims types.TokenClaims) (*types.AuthToken, error)
+	ParseToken(token string) (*types.TokenClaims, error)
+}
+
+type OperatorRepository interface {
+	CreateOperator(operator types.Operator) error
+	GetOperatorByUsername(username string) (*types.Operator, error)
+}
+
+type OperatorValidator interface {
+	ValidateLoginRequest(request *types.LoginRequest) error
+	ValidateCreateOperatorRequest(request *types.CreateOperatorRequest) error
+}
+
+type ApiKeyRepository interface {
+	CreateApiKey(apiKey types.ApiKey) error
+	GetApiKeys() ([]*types.ApiKey, error)
+	GetApiKeyById(id string) (*types.ApiKey, error)
+	GetApiKeyByHash(keyHash string) (*types.ApiKey, error)
+	RevokeApiKey(id string) error
+}
+
+type ApiKeyValidator interface {
+	ValidateCreateApiKeyRequest(request *types.CreateApiKeyRequest) error
 }
 
 type AuthProvider interface {
@@ -21,29 +85,138 @@ type AuthProvider interface {
 
 type ScooterRepository interface {
 	GetScooterById(id string) (*types.Scooter, *int, error)
-	GetAllScooters() ([]*types.Scooter, error)
+	GetAllScooters(queryParams types.GetAllScootersQueryParameters) ([]*types.Scooter, error)
 	GetScootersByArea(queryParams types.GetScootersQueryParameters) ([]*types.Scooter, error)
+	GetScootersByRadius(queryParams types.GetScootersQueryParameters) ([]*types.NearbyScooter, error)
 	CreateScooter(scooter types.Scooter) error
+	UpdateScooter(scooter types.Scooter, optLockVersion *int) error
+	IsInActiveTrip(id string) (bool, error)
+	IsReserved(id string) (bool, error)
+}
+
+// DeviceSecretRepository stores hashes of the secrets scooters authenticate their telemetry with.
+type DeviceSecretRepository interface {
+	GetDeviceSecretHash(scooterId string) (string, error)
+	SetDeviceSecretHash(scooterId string, secretHash string) error
+}
+
+// TelemetryRepository saves the pings together with the scooter, the scooter is left untouched when nil.
+type TelemetryRepository interface {
+	RecordTelemetry(pings []types.TelemetryPing, scooter *types.Scooter, scooterOptLockVersion *int) error
+}
+
+type TelemetryValidator interface {
+	ValidateTelemetryRequest(request *types.TelemetryRequest) error
+}
+
+type ReservationRepository interface {
+	CreateReservation(reservation types.Reservation, scooterOptLockVersion *int, event types.ReservationEvent) error
+	GetActiveReservation(clientId string) (*types.Reservation, error)
+	GetExpiredReservations(now time.Time) ([]*types.Reservation, error)
+	ExpireReservation(reservation *types.Reservation, scooterOptLockVersion *int, event types.ReservationEvent) error
+}
+
+type ReservationValidator interface {
+	ValidateCreateReservationRequest(request *types.CreateReservationRequest) error
 }
 
 type ClientRepository interface {
 	CreateUser(client types.MobileClient) error
 	GetUserById(id string) (*types.MobileClient, *int, error)
+	GetUserByEmail(email string) (*types.MobileClient, error)
+	SetUserCredentials(client types.MobileClient) error
+	GetUsers(queryParams types.GetUsersQueryParameters) ([]*types.MobileClient, error)
+	IsInActiveTrip(id string) (bool, error)
+	UpdateUserEligibility(client types.MobileClient, optLockVersion *int) error
 }
 
 type TripRepository interface {
 	GetTripById(id string) (*types.Trip, error)
-	StartTrip(trip types.Trip, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent) error
-	UpdateTrip(trip *types.Trip, scooterOptLockVersion *int, event types.TripEvent) error
-	EndTrip(trip *types.Trip, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent) error
+	GetTripEvents(tripId string) ([]*types.TripEvent, error)
+	GetLastTripEvent(tripId string) (*types.TripEvent, error)
+	GetIdleTrips(idleSince time.Time) ([]*types.Trip, error)
+	GetClientTrips(clientId string, queryParams types.GetTripsQueryParameters) ([]*types.Trip, error)
+	GetTripViolations(tripId string) ([]*types.ZoneViolation, error)
+	StartTrip(trip types.Trip, reservation *types.Reservation, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent) error
+	UpdateTrip(trip *types.Trip, scooter *types.Scooter, scooterOptLockVersion *int, event types.TripEvent, violations []types.ZoneViolation) error
+	EndTrip(trip *types.Trip, scooter *types.Scooter, scooterOptLockVersion *int, userOptLockVersion *int, event types.TripEvent, violations []types.ZoneViolation) error
+}
+
+type ZoneRepository interface {
+	CreateZone(zone types.Zone) error
+	GetZones() ([]*types.Zone, error)
+	GetZoneById(id string) (*types.Zone, error)
+	DeleteZone(id string) error
+	GetTripViolations(tripId string) ([]*types.ZoneViolation, error)
+}
+
+type ZoneValidator interface {
+	ValidateCreateZoneRequest(request *types.CreateZoneRequest) error
+}
+
+type Geofence interface {
+	CheckMove(lastEvent *types.TripEvent, event types.TripEvent) ([]types.ZoneViolation, error)
+	CheckParking(startLocation types.Location, location types.Location) error
+}
+
+type ParkingStationRepository interface {
+	CreateParkingStation(station types.ParkingStation) error
+	GetParkingStations() ([]*types.ParkingStation, error)
+	GetParkingStationById(id string) (*types.ParkingStation, error)
+	GetNearbyParkingStations(queryParams types.GetParkingStationsQueryParameters) ([]*types.NearbyParkingStation, error)
+	DeleteParkingStation(id string) error
+	GetParkedScooterLocations(minLatitude float64, maxLatitude float64, minLongitude float64, maxLongitude float64) ([]types.Location, error)
+}
+
+type ParkingStationValidator interface {
+	ValidateCreateParkingStationRequest(request *types.CreateParkingStationRequest) error
+	ValidateGetParkingStationsQueryParameters(queryParams *types.GetParkingStationsQueryParameters) error
+}
+
+// MaintenanceTicketRepository changes tickets together with the status of their scooter, which is guarded by its lock version.
+type MaintenanceTicketRepository interface {
+	OpenTicket(ticket types.MaintenanceTicket, scooter *types.Scooter, scooterOptLockVersion *int) error
+	GetTicketById(id string) (*types.MaintenanceTicket, error)
+	GetTicketsByScooterId(scooterId string) ([]*types.MaintenanceTicket, error)
+	ResolveTicket(ticket *types.MaintenanceTicket, scooter *types.Scooter, scooterOptLockVersion *int) error
+}
+
+type MaintenanceTicketValidator interface {
+	ValidateCreateTicketRequest(request *types.CreateTicketRequest) error
+	ValidateResolveTicketRequest(request *types.ResolveTicketRequest) error
+}
+
+type ProjectionRepository interface {
+	GetTrips() ([]*types.Trip, error)
+	GetEvents() ([]*types.TripEvent, error)
+	GetSuspendedUserIds() ([]uuid.UUID, error)
+	GetLockedScooterStatuses() (map[uuid.UUID]enums.ScooterStatus, error)
+	GetStoredState() (*types.Projection, error)
+	SaveProjection(projection *types.Projection) error
+}
+
+type FareCalculator interface {
+	CalculateFare(events []*types.TripEvent) types.Fare
+}
+
+type ClientValidator interface {
+	ValidateCreateUserRequest(request *types.CreateUserRequest) error
+	ValidateLoginRequest(request *types.UserCredentialsRequest) error
+	ValidateUserCredentialsRequest(request *types.UserCredentialsRequest) error
+	ValidateGetUsersQueryParameters(queryParams *types.GetUsersQueryParameters) error
+	ValidateUpdateUserRequest(request *types.UpdateUserRequest) error
 }
 
 type ScooterValidator interface {
 	ValidateCreateScooterRequest(request *types.CreateScooterRequest) error
 	ValidateGetScootersQueryParameters(queryParams *types.GetScootersQueryParameters) error
+	ValidateGetAllScootersQueryParameters(queryParams *types.GetAllScootersQueryParameters) error
+	ValidateUpdateScooterRequest(request *types.UpdateScooterRequest) error
 }
 
 type TripValidator interface {
 	ValidateStartTripRequest(request *types.StartTripRequest) error
 	ValidateTripUpdateRequest(request *types.TripUpdateRequest) error
+	ValidateCloseTripRequest(request *types.CloseTripRequest) error
+	ValidateGetTripsQueryParameters(queryParams *types.GetTripsQueryParameters) error
 }
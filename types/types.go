@@ -8,10 +8,68 @@ import (
 )
 
 // Entities
+// Scooter carries the telemetry last reported by the device. LastSeenAt is not set until the scooter reports for the first time.
 type Scooter struct {
-	ID          uuid.UUID `json:"id"`
-	Location    Location  `json:"location"`
-	IsAvailable bool      `json:"is_available"`
+	ID              uuid.UUID           `json:"id"`
+	Location        Location            `json:"location"`
+	Status          enums.ScooterStatus `json:"status"`
+	BatteryLevel    int                 `json:"battery_level"`
+	OdometerMeters  float64             `json:"odometer_m"`
+	FirmwareVersion string              `json:"firmware_version"`
+	LastSeenAt      *time.Time          `json:"last_seen_at"`
+}
+
+// MaintenanceTicket keeps its scooter in maintenance while it is open. Resolution fields are only set once it is resolved.
+type MaintenanceTicket struct {
+	ID          uuid.UUID          `json:"id"`
+	ScooterID   uuid.UUID          `json:"scooter_id"`
+	Status      enums.TicketStatus `json:"status"`
+	Description string             `json:"description"`
+	OpenedBy    string             `json:"opened_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Resolution  *string            `json:"resolution,omitempty"`
+	ResolvedBy  *string            `json:"resolved_by,omitempty"`
+	ResolvedAt  *time.Time         `json:"resolved_at,omitempty"`
+}
+
+type Operator struct {
+	ID           uuid.UUID  `json:"id"`
+	Username     string     `json:"username"`
+	Role         enums.Role `json:"role"`
+	PasswordHash string     `json:"-"`
+}
+
+// TokenClaims identify the bearer of a token. Role is only set for operators.
+type TokenClaims struct {
+	Subject         uuid.UUID
+	Role            enums.Role
+	AuthenticatedAt time.Time
+}
+
+type ApiKey struct {
+	ID        uuid.UUID         `json:"id"`
+	Name      string            `json:"name"`
+	Scope     enums.ApiKeyScope `json:"scope"`
+	KeyHash   string            `json:"-"`
+	ExpiresAt *time.Time        `json:"expires_at"`
+	IsRevoked bool              `json:"is_revoked"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// IsActive reports whether the key may still be used for authentication at the given time.
+func (k *ApiKey) IsActive(now time.Time) bool {
+	return !k.IsRevoked && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// IdempotencyRecord is the outcome of the first request made with an idempotency key.
+// StatusCode is 0 while that request is still being handled.
+type IdempotencyRecord struct {
+	Caller       string
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	CreatedAt    time.Time
 }
 
 type Location struct {
@@ -22,7 +80,10 @@ type Location struct {
 type MobileClient struct {
 	ID                 uuid.UUID `json:"id"`
 	FullName           string    `json:"full_name"`
+	Email              *string   `json:"email,omitempty"`
+	PasswordHash       string    `json:"-"`
 	IsEligibleToTravel bool      `json:"is_eligible_to_travel"`
+	SuspensionReason   *string   `json:"suspension_reason,omitempty"`
 }
 
 type Trip struct {
@@ -30,24 +91,153 @@ type Trip struct {
 	ScooterId  uuid.UUID `json:"scooter"`
 	ClientId   uuid.UUID `json:"client_id"`
 	IsFinished bool      `json:"is_finished"`
+	Fare       *int      `json:"fare,omitempty"`
 }
 
+// Reservation holds a scooter for a rider until it is consumed by starting a trip or expires.
+type Reservation struct {
+	ID        uuid.UUID               `json:"id"`
+	ScooterId uuid.UUID               `json:"scooter_id"`
+	ClientId  uuid.UUID               `json:"client_id"`
+	Status    enums.ReservationStatus `json:"status"`
+	ExpiresAt time.Time               `json:"expires_at"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+type ReservationEvent struct {
+	ReservationID uuid.UUID                  `json:"reservation_id"`
+	Type          enums.ReservationEventType `json:"event_type"`
+	CreatedAt     time.Time                  `json:"created_at"`
+}
+
+// Zone is an area of the city with its own riding rules, see enums.ZoneType.
+type Zone struct {
+	ID        uuid.UUID      `json:"id"`
+	Name      string         `json:"name"`
+	Type      enums.ZoneType `json:"zone_type"`
+	Geometry  GeoJSONPolygon `json:"geometry"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// GeoJSONPolygon is a GeoJSON Polygon geometry. Positions are [longitude, latitude] pairs,
+// the first ring is the outer boundary and every following ring is a hole.
+type GeoJSONPolygon struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+// ZoneViolation is recorded together with the trip event published at the location of the violation.
+// ZoneID is not set for leaving the operating area, since the scooter is in no zone at all.
+type ZoneViolation struct {
+	TripID    uuid.UUID               `json:"trip_id"`
+	Sequence  int                     `json:"sequence"`
+	ZoneID    *uuid.UUID              `json:"zone_id,omitempty"`
+	Type      enums.ZoneViolationType `json:"violation_type"`
+	Location  Location                `json:"location"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// ParkingStation is an area designated for parking, either a circle around Center or a polygon.
+// Center of a polygon station is the average of its boundary positions and is only used to find nearby stations.
+type ParkingStation struct {
+	ID           uuid.UUID       `json:"id"`
+	Name         string          `json:"name"`
+	Center       Location        `json:"center"`
+	RadiusMeters float64         `json:"radius_m,omitempty"`
+	Geometry     *GeoJSONPolygon `json:"geometry,omitempty"`
+	Capacity     int             `json:"capacity"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+type Fare struct {
+	Amount          int     `json:"amount"`
+	Currency        string  `json:"currency"`
+	DurationSeconds int     `json:"duration_seconds"`
+	PausedSeconds   int     `json:"paused_seconds"`
+	DistanceMeters  float64 `json:"distance_meters"`
+}
+
+// TripEvent carries Actor and Reason only when the trip was closed by an operator or the trip sweeper instead of the rider.
+// CreatedAt is reported by the rider's app, while ReceivedAt is taken from the server's clock when the event arrives.
 type TripEvent struct {
-	TripID    uuid.UUID           `json:"trip_id"`
-	Type      enums.TripEventType `json:"event_type"`
-	Location  Location            `json:"location"`
-	CreatedAt time.Time           `json:"created_at"`
-	Sequence  int                 `json:"sequence"`
+	TripID     uuid.UUID           `json:"trip_id"`
+	Type       enums.TripEventType `json:"event_type"`
+	Location   Location            `json:"location"`
+	CreatedAt  time.Time           `json:"created_at"`
+	ReceivedAt time.Time           `json:"-"`
+	Sequence   int                 `json:"sequence"`
+	Actor      *string             `json:"actor,omitempty"`
+	Reason     *string             `json:"reason,omitempty"`
+}
+
+// EndsTrip reports whether no further events can follow this one.
+func (e *TripEvent) EndsTrip() bool {
+	return e.Type == enums.EndTrip || e.Type == enums.ForceEndTrip || e.Type == enums.CancelTrip
+}
+
+// TelemetryPing is a reading reported by the scooter itself. Battery level and lock state are only set when the device reported them.
+type TelemetryPing struct {
+	ScooterID    uuid.UUID `json:"scooter_id"`
+	Location     Location  `json:"location"`
+	BatteryLevel *int      `json:"battery_level,omitempty"`
+	IsLocked     *bool     `json:"is_locked,omitempty"`
+	RecordedAt   time.Time `json:"recorded_at"`
 }
 
 // Requests
 type CreateScooterRequest struct {
-	Location    Location `json:"location" validate:"required"`
-	IsAvailable bool     `json:"is_available"`
+	Location        Location            `json:"location" validate:"required"`
+	Status          enums.ScooterStatus `json:"status"`
+	BatteryLevel    *int                `json:"battery_level"`
+	FirmwareVersion string              `json:"firmware_version"`
+}
+
+type UpdateScooterRequest struct {
+	Location        *Location            `json:"location"`
+	Status          *enums.ScooterStatus `json:"status"`
+	BatteryLevel    *int                 `json:"battery_level"`
+	FirmwareVersion *string              `json:"firmware_version"`
+}
+
+type CreateTicketRequest struct {
+	Description string `json:"description" validate:"required"`
+}
+
+type ResolveTicketRequest struct {
+	Resolution string `json:"resolution" validate:"required"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type CreateOperatorRequest struct {
+	Username string     `json:"username" validate:"required"`
+	Password string     `json:"password" validate:"required"`
+	Role     enums.Role `json:"role" validate:"required"`
+}
+
+type CreateApiKeyRequest struct {
+	Name      string            `json:"name" validate:"required"`
+	Scope     enums.ApiKeyScope `json:"scope" validate:"required"`
+	ExpiresAt *time.Time        `json:"expires_at"`
 }
 
 type CreateUserRequest struct {
 	FullName string `json:"full_name"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type UserCredentialsRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type UpdateUserRequest struct {
+	IsEligibleToTravel *bool  `json:"is_eligible_to_travel" validate:"required"`
+	Reason             string `json:"reason"`
 }
 
 type StartTripRequest struct {
@@ -55,20 +245,112 @@ type StartTripRequest struct {
 	CreatedAt time.Time `json:"created_at" validate:"required"`
 }
 
+type CloseTripRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+type CreateReservationRequest struct {
+	ScooterID uuid.UUID `json:"scooter_id" validate:"required"`
+}
+
 type TripUpdateRequest struct {
-	Location    Location  `json:"location" validate:"required"`
-	CreatedAt   time.Time `json:"created_at" validate:"required"`
-	IsFinishing bool      `json:"is_finishing"`
-	Sequence    int       `json:"sequence" validate:"required"`
+	Location     Location  `json:"location" validate:"required"`
+	CreatedAt    time.Time `json:"created_at" validate:"required"`
+	IsFinishing  bool      `json:"is_finishing"`
+	IsPausing    bool      `json:"is_pausing"`
+	IsResuming   bool      `json:"is_resuming"`
+	Sequence     int       `json:"sequence" validate:"required"`
+	BatteryLevel *int      `json:"battery_level"`
+}
+
+type CreateZoneRequest struct {
+	Name     string         `json:"name" validate:"required"`
+	Type     enums.ZoneType `json:"zone_type" validate:"required"`
+	Geometry GeoJSONPolygon `json:"geometry"`
+}
+
+// CreateParkingStationRequest describes a station either by Center and RadiusMeters or by Geometry.
+type CreateParkingStationRequest struct {
+	Name         string          `json:"name" validate:"required"`
+	Center       *Location       `json:"center"`
+	RadiusMeters float64         `json:"radius_m"`
+	Geometry     *GeoJSONPolygon `json:"geometry"`
+	Capacity     int             `json:"capacity"`
+}
+
+type TelemetryRequest struct {
+	Pings []TelemetryPingRequest `json:"pings" validate:"required"`
+}
+
+type TelemetryPingRequest struct {
+	Location     Location  `json:"location" validate:"required"`
+	BatteryLevel *int      `json:"battery_level"`
+	IsLocked     *bool     `json:"is_locked"`
+	RecordedAt   time.Time `json:"recorded_at" validate:"required"`
 }
 
 // Query parameters
 type GetScootersQueryParameters struct {
-	Availability string  `form:"availability" validate:"required"`
-	X1           float64 `form:"x1" validate:"required"`
-	X2           float64 `form:"x2" validate:"required"`
-	Y1           float64 `form:"y1" validate:"required"`
-	Y2           float64 `form:"y2" validate:"required"`
+	Availability string   `form:"availability" validate:"required"`
+	X1           float64  `form:"x1" validate:"required_without=Latitude"`
+	X2           float64  `form:"x2" validate:"required_without=Latitude"`
+	Y1           float64  `form:"y1" validate:"required_without=Latitude"`
+	Y2           float64  `form:"y2" validate:"required_without=Latitude"`
+	Latitude     *float64 `form:"lat"`
+	Longitude    *float64 `form:"lng"`
+	RadiusMeters float64  `form:"radius_m"`
+	Limit        int      `form:"limit"`
+	MinBattery   int      `form:"min_battery"`
+}
+
+// IsRadiusSearch tells whether scooters are searched around a point instead of in a rectangle.
+func (q *GetScootersQueryParameters) IsRadiusSearch() bool {
+	return q.Latitude != nil || q.Longitude != nil || q.RadiusMeters != 0
+}
+
+type GetAllScootersQueryParameters struct {
+	Availability string `form:"availability"`
+	Status       string `form:"status"`
+	SortBy       string `form:"sort_by"`
+	Order        string `form:"order"`
+	Limit        int    `form:"limit"`
+	Cursor       string `form:"cursor"`
+}
+
+type GetUsersQueryParameters struct {
+	FullName string `form:"full_name"`
+	Limit    int    `form:"limit"`
+	Offset   int    `form:"offset"`
+}
+
+type GetTripsQueryParameters struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+type GetParkingStationsQueryParameters struct {
+	Latitude     *float64 `form:"lat" validate:"required"`
+	Longitude    *float64 `form:"lng" validate:"required"`
+	RadiusMeters float64  `form:"radius_m"`
+	Limit        int      `form:"limit"`
+}
+
+// Projections
+// Projection holds the state rebuilt from trip events. Scooter locations are only known for scooters in an active trip,
+// since scooters are also moved by telemetry and operators outside of trips.
+type Projection struct {
+	Trips            map[uuid.UUID]*Trip
+	Scooters         map[uuid.UUID]*Scooter
+	ScooterLocations map[uuid.UUID]Location
+	Users            map[uuid.UUID]*MobileClient
+}
+
+type ProjectionDrift struct {
+	Entity    string    `json:"entity"`
+	ID        uuid.UUID `json:"id"`
+	Field     string    `json:"field"`
+	Stored    string    `json:"stored"`
+	Projected string    `json:"projected"`
 }
 
 // Responses
@@ -76,6 +358,115 @@ type AuthResponse struct {
 	StaticApiKey string
 }
 
+type AuthToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type CreateApiKeyResponse struct {
+	ApiKey
+	Key string `json:"key"`
+}
+
+type GetApiKeysResponse struct {
+	ApiKeys []*ApiKey `json:"api_keys"`
+}
+
+// DeviceSecretResponse is the only time the secret is shown, just its hash is stored.
+type DeviceSecretResponse struct {
+	ScooterID uuid.UUID `json:"scooter_id"`
+	Secret    string    `json:"secret"`
+}
+
+// TelemetryResponse tells whether the scooter's current state was updated from the pings, which only happens outside of trips.
+type TelemetryResponse struct {
+	Accepted       int  `json:"accepted"`
+	ScooterUpdated bool `json:"scooter_updated"`
+}
+
+type CreateUserResponse struct {
+	MobileClient
+	AuthToken
+}
+
 type GetScootersResponse struct {
-	Scooters []*Scooter `json:"scooters"`
+	Scooters   []*Scooter `json:"scooters"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+type NearbyScooter struct {
+	Scooter
+	DistanceMeters float64 `json:"distance_m"`
+}
+
+type GetNearbyScootersResponse struct {
+	Scooters []*NearbyScooter `json:"scooters"`
+}
+
+type EndTripResponse struct {
+	TripEvent
+	Fare Fare `json:"fare"`
+}
+
+type GetUsersResponse struct {
+	Users  []*MobileClient `json:"users"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+// TripSummary describes a rider's trip without its events. End time and location are only set once the trip is finished.
+type TripSummary struct {
+	ID              uuid.UUID  `json:"id"`
+	ScooterId       uuid.UUID  `json:"scooter"`
+	IsFinished      bool       `json:"is_finished"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	StartLocation   Location   `json:"start_location"`
+	EndLocation     *Location  `json:"end_location,omitempty"`
+	DurationSeconds int        `json:"duration_seconds"`
+	DistanceMeters  float64    `json:"distance_meters"`
+	Fare            *Fare      `json:"fare,omitempty"`
+}
+
+type GetTripsResponse struct {
+	Trips  []*TripSummary `json:"trips"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+type GetZonesResponse struct {
+	Zones []*Zone `json:"zones"`
+}
+
+type GetZoneViolationsResponse struct {
+	Violations []*ZoneViolation `json:"violations"`
+}
+
+// MaintenanceTicketResponse tells which status the scooter was left in after the ticket was opened or resolved.
+type MaintenanceTicketResponse struct {
+	Ticket        *MaintenanceTicket  `json:"ticket"`
+	ScooterStatus enums.ScooterStatus `json:"scooter_status"`
+}
+
+type GetMaintenanceTicketsResponse struct {
+	Tickets []*MaintenanceTicket `json:"tickets"`
+}
+
+type GetParkingStationsResponse struct {
+	ParkingStations []*ParkingStation `json:"parking_stations"`
+}
+
+type NearbyParkingStation struct {
+	ParkingStation
+	DistanceMeters float64 `json:"distance_m"`
+}
+
+type GetNearbyParkingStationsResponse struct {
+	ParkingStations []*NearbyParkingStation `json:"parking_stations"`
+}
+
+type GetTripResponse struct {
+	Trip       *Trip            `json:"trip"`
+	Events     []*TripEvent     `json:"events"`
+	Violations []*ZoneViolation `json:"violations"`
 }
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const apiKeyLength = 32
+
+// GenerateApiKey returns a random key. Only its hash is ever stored, so it can not be shown again.
+func GenerateApiKey() (string, error) {
+	key := make([]byte, apiKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+// HashApiKey does not need to be slow like password hashing, generated keys have enough entropy on their own.
+func HashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -1,40 +1,148 @@
 package utils
 
 import (
+	"crypto/subtle"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+)
+
+// ClientIdKey, OperatorIdKey, ApiKeyIdKey and ScooterIdKey are the gin context keys under which authenticated callers are stored,
+// RoleKey holds the role admin requests are authorized with and AuthenticatedAtKey the time the rider logged in at.
+const (
+	ClientIdKey        = "clientId"
+	OperatorIdKey      = "operatorId"
+	ApiKeyIdKey        = "apiKeyId"
+	ScooterIdKey       = "scooterId"
+	RoleKey            = "role"
+	AuthenticatedAtKey = "authenticatedAt"
 )
 
 type AuthorizationService struct {
-	adminApiKey string
-	userApiKey  string
+	adminApiKey     string
+	userApiKey      string
+	isBootstrapMode bool
+	clientTokens    interfaces.TokenService
+	adminTokens     interfaces.TokenService
+	apiKeys         interfaces.ApiKeyRepository
+	deviceSecrets   interfaces.DeviceSecretRepository
 }
 
-func NewAuthService(adminApiKey string, userApiKey string) *AuthorizationService {
-	return &AuthorizationService{adminApiKey: adminApiKey, userApiKey: userApiKey}
+func NewAuthService(
+	adminApiKey string,
+	userApiKey string,
+	isBootstrapMode bool,
+	clientTokens interfaces.TokenService,
+	adminTokens interfaces.TokenService,
+	apiKeys interfaces.ApiKeyRepository,
+	deviceSecrets interfaces.DeviceSecretRepository) *AuthorizationService {
+	return &AuthorizationService{
+		adminApiKey:     adminApiKey,
+		userApiKey:      userApiKey,
+		isBootstrapMode: isBootstrapMode,
+		clientTokens:    clientTokens,
+		adminTokens:     adminTokens,
+		apiKeys:         apiKeys,
+		deviceSecrets:   deviceSecrets,
+	}
 }
 
+// AuthenticateClient lets through requests made with a managed API key of any scope.
+// The static keys are only accepted in bootstrap mode, like on admin endpoints.
+// Requests which also carry a rider token are bound to that rider, the token must be valid if present.
 func (s *AuthorizationService) AuthenticateClient(c *gin.Context) {
 	apiKey := c.GetHeader("X-API-Key")
-	if apiKey != s.adminApiKey && apiKey != s.userApiKey {
+	if !s.isBootstrapUserKey(apiKey) && !s.isBootstrapAdminKey(apiKey) && !s.authenticateApiKey(c, apiKey, enums.ClientScope, enums.AdminScope) {
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
 
+	if c.GetHeader("Authorization") != "" {
+		token, ok := getBearerToken(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		claims, err := s.clientTokens.ParseToken(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Set(ClientIdKey, claims.Subject.String())
+		c.Set(AuthenticatedAtKey, claims.AuthenticatedAt)
+	}
+
 	c.Next()
 }
 
+// AuthenticateAdmin requires an operator token or a managed API key with admin scope.
+// The static admin key is only accepted in bootstrap mode, which exists to create the first operators.
+// Keys act with the admin role, operators with the role their token was issued for.
 func (s *AuthorizationService) AuthenticateAdmin(c *gin.Context) {
 	apiKey := c.GetHeader("X-API-Key")
-	if apiKey != s.adminApiKey {
+	if s.isBootstrapAdminKey(apiKey) || s.authenticateApiKey(c, apiKey, enums.AdminScope) {
+		c.Set(RoleKey, string(enums.AdminRole))
+		c.Next()
+		return
+	}
+
+	token, ok := getBearerToken(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	claims, err := s.adminTokens.ParseToken(token)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Set(OperatorIdKey, claims.Subject.String())
+	c.Set(RoleKey, string(claims.Role))
+	c.Next()
+}
+
+// AuthenticateDevice requires the id of the scooter and the secret issued for it. The secret is compared by its hash,
+// so that a rotated secret stops working at once.
+func (s *AuthorizationService) AuthenticateDevice(c *gin.Context) {
+	scooterId, err := uuid.Parse(c.GetHeader("X-Scooter-Id"))
+	secret := c.GetHeader("X-Device-Secret")
+	if err != nil || secret == "" {
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
 
+	secretHash, err := s.deviceSecrets.GetDeviceSecretHash(scooterId.String())
+	if err != nil || subtle.ConstantTimeCompare([]byte(secretHash), []byte(HashApiKey(secret))) != 1 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	c.Set(ScooterIdKey, scooterId.String())
 	c.Next()
 }
 
+// RequirePermission must be chained after AuthenticateAdmin, it rejects callers whose role lacks the permission.
+func (s *AuthorizationService) RequirePermission(permission enums.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(enums.Role(c.GetString(RoleKey)), permission) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
 func (s *AuthorizationService) GetAdminApiKey() string {
 	return s.adminApiKey
 }
@@ -42,3 +150,58 @@ func (s *AuthorizationService) GetAdminApiKey() string {
 func (s *AuthorizationService) GetUserApiKey() string {
 	return s.userApiKey
 }
+
+func (s *AuthorizationService) isBootstrapAdminKey(apiKey string) bool {
+	return s.isBootstrapMode && apiKey == s.adminApiKey
+}
+
+func (s *AuthorizationService) isBootstrapUserKey(apiKey string) bool {
+	return s.isBootstrapMode && apiKey == s.userApiKey
+}
+
+// authenticateApiKey looks the key up by its hash, so that rotated or revoked keys stop working
+// without restarting the service. The caller is identified by the key id on success.
+func (s *AuthorizationService) authenticateApiKey(c *gin.Context, apiKey string, scopes ...enums.ApiKeyScope) bool {
+	if apiKey == "" {
+		return false
+	}
+
+	managedKey, err := s.apiKeys.GetApiKeyByHash(HashApiKey(apiKey))
+	if err != nil || !managedKey.IsActive(time.Now()) || !slices.Contains(scopes, managedKey.Scope) {
+		return false
+	}
+
+	c.Set(ApiKeyIdKey, managedKey.ID.String())
+	return true
+}
+
+func getBearerToken(c *gin.Context) (string, bool) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	return token, ok && token != ""
+}
+
+// GetCallerKey identifies the most specific caller of an authenticated request, so that riders sharing an API key
+// are told apart. Requests made with a static key are identified by the key, anonymous ones by IP address.
+func GetCallerKey(c *gin.Context) string {
+	if clientId := c.GetString(ClientIdKey); clientId != "" {
+		return "rider:" + clientId
+	}
+
+	if operatorId := c.GetString(OperatorIdKey); operatorId != "" {
+		return "operator:" + operatorId
+	}
+
+	if apiKeyId := c.GetString(ApiKeyIdKey); apiKeyId != "" {
+		return "api-key:" + apiKeyId
+	}
+
+	if scooterId := c.GetString(ScooterIdKey); scooterId != "" {
+		return "scooter:" + scooterId
+	}
+
+	if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+		return "static-key:" + HashApiKey(apiKey)
+	}
+
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,324 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestAuthorizationService(t *testing.T) {
+	clientTokens := NewTokenService("client-secret", time.Hour, "client")
+	adminTokens := NewTokenService("admin-secret", time.Hour, "admin")
+	operatorId := uuid.MustParse("0b6f3c1e-5c2a-4a8e-1111-6d1f0c7e9a21")
+	apiKeys := &mockApiKeyRepository{}
+	deviceSecrets := &mockDeviceSecretRepository{}
+
+	serveAdminRequest := func(authService *AuthorizationService, headers map[string]string) *httptest.ResponseRecorder {
+		router := gin.Default()
+		router.GET("/admin/scooters", authService.AuthenticateAdmin, func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"operatorId": c.GetString(OperatorIdKey)})
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/admin/scooters", nil)
+		for key, value := range headers {
+			request.Header.Set(key, value)
+		}
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+		return responseRecoreder
+	}
+
+	servePermissionRequest := func(authService *AuthorizationService, permission enums.Permission, token string) *httptest.ResponseRecorder {
+		router := gin.Default()
+		router.GET("/admin/users", authService.AuthenticateAdmin, authService.RequirePermission(permission), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+		return responseRecoreder
+	}
+
+	serveDeviceRequest := func(authService *AuthorizationService, headers map[string]string) *httptest.ResponseRecorder {
+		router := gin.Default()
+		router.POST("/device/telemetry", authService.AuthenticateDevice, func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"scooterId": c.GetString(ScooterIdKey)})
+		})
+
+		request := httptest.NewRequest(http.MethodPost, "/device/telemetry", nil)
+		for key, value := range headers {
+			request.Header.Set(key, value)
+		}
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+		return responseRecoreder
+	}
+
+	t.Run("When authenticating admin while operator token is valid returns ok", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+		token, _ := adminTokens.IssueToken(types.TokenClaims{Subject: operatorId, Role: enums.AdminRole})
+
+		responseRecoreder := serveAdminRequest(authService, map[string]string{"Authorization": "Bearer " + token.Token})
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating admin while rider token is given returns unauthorized", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+		token, _ := clientTokens.IssueToken(types.TokenClaims{Subject: operatorId})
+
+		responseRecoreder := serveAdminRequest(authService, map[string]string{"Authorization": "Bearer " + token.Token})
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating admin while static key is used outside bootstrap mode returns unauthorized", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		responseRecoreder := serveAdminRequest(authService, map[string]string{"X-API-Key": "admin-key"})
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating admin while static key is used in bootstrap mode returns ok", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", true, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		responseRecoreder := serveAdminRequest(authService, map[string]string{"X-API-Key": "admin-key"})
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+	t.Run("When authenticating admin while managed admin key is active returns ok", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		responseRecoreder := serveAdminRequest(authService, map[string]string{"X-API-Key": "managed-admin-key"})
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating admin while managed key has client scope returns unauthorized", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		responseRecoreder := serveAdminRequest(authService, map[string]string{"X-API-Key": "managed-client-key"})
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating admin while managed key is revoked returns unauthorized", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		responseRecoreder := serveAdminRequest(authService, map[string]string{"X-API-Key": "revoked-admin-key"})
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating admin while managed key is expired returns unauthorized", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		responseRecoreder := serveAdminRequest(authService, map[string]string{"X-API-Key": "expired-admin-key"})
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When requiring permission while operator role grants it returns ok", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+		token, _ := adminTokens.IssueToken(types.TokenClaims{Subject: operatorId, Role: enums.SupportRole})
+
+		responseRecoreder := servePermissionRequest(authService, enums.ReadTrips, token.Token)
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When requiring permission while operator role lacks it returns forbidden", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+		token, _ := adminTokens.IssueToken(types.TokenClaims{Subject: operatorId, Role: enums.FleetOpsRole})
+
+		responseRecoreder := servePermissionRequest(authService, enums.ReadUsers, token.Token)
+		if responseRecoreder.Code != http.StatusForbidden {
+			t.Errorf("expected status code %d but got %d", http.StatusForbidden, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When requiring permission while token has no role returns forbidden", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+		token, _ := adminTokens.IssueToken(types.TokenClaims{Subject: operatorId})
+
+		responseRecoreder := servePermissionRequest(authService, enums.ReadScooters, token.Token)
+		if responseRecoreder.Code != http.StatusForbidden {
+			t.Errorf("expected status code %d but got %d", http.StatusForbidden, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating client while managed client key is active returns ok", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		router := gin.Default()
+		router.GET("/client/scooters", authService.AuthenticateClient, func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"apiKeyId": c.GetString(ApiKeyIdKey)})
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/client/scooters", nil)
+		request.Header.Set("X-API-Key", "managed-client-key")
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating client while static key is used outside bootstrap mode returns unauthorized", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		router := gin.Default()
+		router.GET("/client/scooters", authService.AuthenticateClient, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/client/scooters", nil)
+		request.Header.Set("X-API-Key", "user-key")
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating client while static key is used in bootstrap mode returns ok", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", true, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		router := gin.Default()
+		router.GET("/client/scooters", authService.AuthenticateClient, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/client/scooters", nil)
+		request.Header.Set("X-API-Key", "user-key")
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating device while secret matches returns ok", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		responseRecoreder := serveDeviceRequest(authService, map[string]string{
+			"X-Scooter-Id":    deviceScooterId,
+			"X-Device-Secret": "device-secret",
+		})
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+
+		if !strings.Contains(responseRecoreder.Body.String(), deviceScooterId) {
+			t.Errorf("expected scooter id to be set, got %s", responseRecoreder.Body.String())
+		}
+	})
+
+	t.Run("When authenticating device while secret does not match returns unauthorized", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		responseRecoreder := serveDeviceRequest(authService, map[string]string{
+			"X-Scooter-Id":    deviceScooterId,
+			"X-Device-Secret": "wrong-secret",
+		})
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When authenticating device while scooter id is missing returns unauthorized", func(t *testing.T) {
+		authService := NewAuthService("admin-key", "user-key", false, clientTokens, adminTokens, apiKeys, deviceSecrets)
+
+		responseRecoreder := serveDeviceRequest(authService, map[string]string{"X-Device-Secret": "device-secret"})
+		if responseRecoreder.Code != http.StatusUnauthorized {
+			t.Errorf("expected status code %d but got %d", http.StatusUnauthorized, responseRecoreder.Code)
+		}
+	})
+}
+
+type mockApiKeyRepository struct{}
+
+// CreateApiKey implements interfaces.ApiKeyRepository.
+func (m *mockApiKeyRepository) CreateApiKey(apiKey types.ApiKey) error {
+	panic("unimplemented")
+}
+
+// GetApiKeys implements interfaces.ApiKeyRepository.
+func (m *mockApiKeyRepository) GetApiKeys() ([]*types.ApiKey, error) {
+	panic("unimplemented")
+}
+
+// GetApiKeyById implements interfaces.ApiKeyRepository.
+func (m *mockApiKeyRepository) GetApiKeyById(id string) (*types.ApiKey, error) {
+	panic("unimplemented")
+}
+
+func (m *mockApiKeyRepository) GetApiKeyByHash(keyHash string) (*types.ApiKey, error) {
+	expiredAt := time.Now().Add(-time.Minute)
+	apiKeys := []types.ApiKey{
+		{Name: "managed-admin-key", Scope: enums.AdminScope},
+		{Name: "managed-client-key", Scope: enums.ClientScope},
+		{Name: "revoked-admin-key", Scope: enums.AdminScope, IsRevoked: true},
+		{Name: "expired-admin-key", Scope: enums.AdminScope, ExpiresAt: &expiredAt},
+	}
+
+	for _, apiKey := range apiKeys {
+		if HashApiKey(apiKey.Name) == keyHash {
+			apiKey.ID = uuid.New()
+			return &apiKey, nil
+		}
+	}
+
+	return nil, errors.New("api key not found")
+}
+
+// RevokeApiKey implements interfaces.ApiKeyRepository.
+func (m *mockApiKeyRepository) RevokeApiKey(id string) error {
+	panic("unimplemented")
+}
+
+const deviceScooterId = "e3344268-d649-4c19-a20c-a0c64a5a6623"
+
+type mockDeviceSecretRepository struct{}
+
+func (m *mockDeviceSecretRepository) GetDeviceSecretHash(scooterId string) (string, error) {
+	if scooterId == deviceScooterId {
+		return HashApiKey("device-secret"), nil
+	}
+
+	return "", errors.New("no device secret was issued for the scooter")
+}
+
+// SetDeviceSecretHash implements interfaces.DeviceSecretRepository.
+func (m *mockDeviceSecretRepository) SetDeviceSecretHash(scooterId string, secretHash string) error {
+	panic("unimplemented")
+}
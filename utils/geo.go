@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"math"
+
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+const EarthRadiusMeters = 6371000.0
+
+// HaversineDistance returns the great-circle distance between two locations in meters.
+func HaversineDistance(from types.Location, to types.Location) float64 {
+	fromLatitude := toRadians(from.Latitude)
+	toLatitude := toRadians(to.Latitude)
+	latitudeDelta := toRadians(to.Latitude - from.Latitude)
+	longitudeDelta := toRadians(to.Longitude - from.Longitude)
+
+	a := math.Sin(latitudeDelta/2)*math.Sin(latitudeDelta/2) +
+		math.Cos(fromLatitude)*math.Cos(toLatitude)*math.Sin(longitudeDelta/2)*math.Sin(longitudeDelta/2)
+
+	return 2 * EarthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox returns the latitude and longitude ranges enclosing every location within the radius of the center,
+// so that they can be filtered by index before the exact distance is calculated.
+// Longitude is not limited when the box would reach a pole or cross the antimeridian.
+func BoundingBox(center types.Location, radiusMeters float64) (minLatitude, maxLatitude, minLongitude, maxLongitude float64) {
+	latitudeDelta := toDegrees(radiusMeters / EarthRadiusMeters)
+	minLatitude, maxLatitude = center.Latitude-latitudeDelta, center.Latitude+latitudeDelta
+	if minLatitude <= -90 || maxLatitude >= 90 {
+		return math.Max(minLatitude, -90), math.Min(maxLatitude, 90), -180, 180
+	}
+
+	longitudeDelta := toDegrees(math.Asin(math.Sin(radiusMeters/EarthRadiusMeters) / math.Cos(toRadians(center.Latitude))))
+	minLongitude, maxLongitude = center.Longitude-longitudeDelta, center.Longitude+longitudeDelta
+	if minLongitude < -180 || maxLongitude > 180 {
+		return minLatitude, maxLatitude, -180, 180
+	}
+
+	return minLatitude, maxLatitude, minLongitude, maxLongitude
+}
+
+// PolygonContains tells whether the location lies inside the outer ring of the polygon and outside all of its holes.
+func PolygonContains(polygon types.GeoJSONPolygon, location types.Location) bool {
+	if len(polygon.Coordinates) == 0 || !ringContains(polygon.Coordinates[0], location) {
+		return false
+	}
+
+	for _, hole := range polygon.Coordinates[1:] {
+		if ringContains(hole, location) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ringContains casts a ray from the location along its latitude and counts the ring edges it crosses.
+// Coordinates are treated as if they were on a plane, which is precise enough for city sized zones.
+func ringContains(ring [][]float64, location types.Location) bool {
+	contains := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		longitudeI, latitudeI := ring[i][0], ring[i][1]
+		longitudeJ, latitudeJ := ring[j][0], ring[j][1]
+
+		if (latitudeI > location.Latitude) != (latitudeJ > location.Latitude) &&
+			location.Longitude < (longitudeJ-longitudeI)*(location.Latitude-latitudeI)/(latitudeJ-latitudeI)+longitudeI {
+			contains = !contains
+		}
+	}
+
+	return contains
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func toDegrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
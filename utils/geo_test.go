@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/nerijusro/scootinAboot/types"
+)
+
+func TestBoundingBox(t *testing.T) {
+	t.Run("When getting bounding box returns ranges enclosing locations at the radius", func(t *testing.T) {
+		center := types.Location{Latitude: 54.68, Longitude: 25.27}
+		minLatitude, maxLatitude, minLongitude, maxLongitude := BoundingBox(center, 1000)
+
+		north := types.Location{Latitude: maxLatitude, Longitude: center.Longitude}
+		east := types.Location{Latitude: center.Latitude, Longitude: maxLongitude}
+		if distance := HaversineDistance(center, north); distance < 999 || distance > 1001 {
+			t.Errorf("expected north edge to be 1000 meters away, got %f", distance)
+		}
+
+		if distance := HaversineDistance(center, east); distance < 1000 {
+			t.Errorf("expected east edge to be at least 1000 meters away, got %f", distance)
+		}
+
+		if minLatitude >= center.Latitude || minLongitude >= center.Longitude {
+			t.Errorf("expected box to be centered, got latitude %f and longitude %f", minLatitude, minLongitude)
+		}
+	})
+
+	t.Run("When getting bounding box while it crosses the antimeridian does not limit longitude", func(t *testing.T) {
+		_, _, minLongitude, maxLongitude := BoundingBox(types.Location{Latitude: 0, Longitude: 179.999}, 1000)
+		if minLongitude != -180 || maxLongitude != 180 {
+			t.Errorf("expected longitude not to be limited, got %f to %f", minLongitude, maxLongitude)
+		}
+	})
+
+	t.Run("When getting bounding box while it reaches a pole does not limit longitude", func(t *testing.T) {
+		_, maxLatitude, minLongitude, maxLongitude := BoundingBox(types.Location{Latitude: 89.999, Longitude: 25.27}, 1000)
+		if maxLatitude != 90 || minLongitude != -180 || maxLongitude != 180 {
+			t.Errorf("expected box to reach the pole, got latitude up to %f and longitude %f to %f", maxLatitude, minLongitude, maxLongitude)
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"slices"
+
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+// rolePermissions lists what every operator role may do. Admins may do anything,
+// so they are not listed and new permissions are granted to them automatically.
+var rolePermissions = map[enums.Role][]enums.Permission{
+	enums.SupportRole:  {enums.ReadUsers, enums.ReadTrips, enums.WriteTrips, enums.ReadZones},
+	enums.FleetOpsRole: {enums.ReadScooters, enums.WriteScooters, enums.ReadZones, enums.WriteZones},
+	enums.FinanceRole:  {enums.ReadTrips, enums.IssueRefunds},
+}
+
+func IsValidRole(role enums.Role) bool {
+	_, ok := rolePermissions[role]
+	return ok || role == enums.AdminRole
+}
+
+func HasPermission(role enums.Role, permission enums.Permission) bool {
+	if role == enums.AdminRole {
+		return true
+	}
+
+	return slices.Contains(rolePermissions[role], permission)
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// idleBucketsCleanupInterval is how often buckets which have refilled completely are dropped,
+// they behave exactly like a new bucket, so keeping them would only grow the memory.
+const idleBucketsCleanupInterval = time.Minute
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// RateLimiter is a token bucket limiter, every caller gets a bucket of burst tokens refilled at ratePerMinute.
+// Buckets are kept in memory, so every instance of the service limits separately.
+type RateLimiter struct {
+	ratePerSecond float64
+	burst         float64
+	mu            sync.Mutex
+	buckets       map[string]*tokenBucket
+	cleanedUpAt   time.Time
+	now           func() time.Time
+}
+
+func NewRateLimiter(ratePerMinute int, burst int) *RateLimiter {
+	return &RateLimiter{
+		ratePerSecond: float64(ratePerMinute) / 60,
+		burst:         float64(burst),
+		buckets:       make(map[string]*tokenBucket),
+		cleanedUpAt:   time.Now(),
+		now:           time.Now,
+	}
+}
+
+// Limit must be chained after authentication, so that riders and API keys get their own buckets.
+func (l *RateLimiter) Limit(c *gin.Context) {
+	retryAfter, ok := l.take(GetCallerKey(c))
+	if !ok {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too Many Requests"})
+		return
+	}
+
+	c.Next()
+}
+
+func (l *RateLimiter) take(key string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.cleanedUpAt) >= idleBucketsCleanupInterval {
+		l.removeFullBuckets(now)
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, updatedAt: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = l.refill(bucket, now)
+	bucket.updatedAt = now
+
+	if bucket.tokens < 1 {
+		missingTokens := 1 - bucket.tokens
+		return time.Duration(missingTokens / l.ratePerSecond * float64(time.Second)), false
+	}
+
+	bucket.tokens--
+	return 0, true
+}
+
+func (l *RateLimiter) refill(bucket *tokenBucket, now time.Time) float64 {
+	return math.Min(l.burst, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.ratePerSecond)
+}
+
+func (l *RateLimiter) removeFullBuckets(now time.Time) {
+	for key, bucket := range l.buckets {
+		if l.refill(bucket, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+
+	l.cleanedUpAt = now
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimiter(t *testing.T) {
+	serveRequest := func(rateLimiter *RateLimiter, clientId string) *httptest.ResponseRecorder {
+		router := gin.Default()
+		router.PUT("/client/trips/:id", func(c *gin.Context) {
+			c.Set(ClientIdKey, clientId)
+		}, rateLimiter.Limit, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		request := httptest.NewRequest(http.MethodPut, "/client/trips/1", nil)
+		request.Header.Set("X-API-Key", "user-key")
+
+		responseRecoreder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecoreder, request)
+		return responseRecoreder
+	}
+
+	t.Run("When limiting while burst is used up returns too many requests with retry after", func(t *testing.T) {
+		rateLimiter := NewRateLimiter(30, 2)
+		now := time.Now()
+		rateLimiter.now = func() time.Time { return now }
+
+		serveRequest(rateLimiter, "rider-1")
+		serveRequest(rateLimiter, "rider-1")
+
+		responseRecoreder := serveRequest(rateLimiter, "rider-1")
+		if responseRecoreder.Code != http.StatusTooManyRequests {
+			t.Errorf("expected status code %d but got %d", http.StatusTooManyRequests, responseRecoreder.Code)
+		}
+
+		if responseRecoreder.Header().Get("Retry-After") != "2" {
+			t.Errorf("expected retry after to be 2, got %s", responseRecoreder.Header().Get("Retry-After"))
+		}
+	})
+
+	t.Run("When limiting while other rider used up their burst returns ok", func(t *testing.T) {
+		rateLimiter := NewRateLimiter(30, 1)
+		now := time.Now()
+		rateLimiter.now = func() time.Time { return now }
+
+		serveRequest(rateLimiter, "rider-1")
+		serveRequest(rateLimiter, "rider-1")
+
+		responseRecoreder := serveRequest(rateLimiter, "rider-2")
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When limiting while bucket refilled returns ok", func(t *testing.T) {
+		rateLimiter := NewRateLimiter(30, 1)
+		now := time.Now()
+		rateLimiter.now = func() time.Time { return now }
+
+		serveRequest(rateLimiter, "rider-1")
+		now = now.Add(2 * time.Second)
+
+		responseRecoreder := serveRequest(rateLimiter, "rider-1")
+		if responseRecoreder.Code != http.StatusOK {
+			t.Errorf("expected status code %d but got %d", http.StatusOK, responseRecoreder.Code)
+		}
+	})
+
+	t.Run("When limiting while rider token is missing limits per api key", func(t *testing.T) {
+		rateLimiter := NewRateLimiter(30, 1)
+		now := time.Now()
+		rateLimiter.now = func() time.Time { return now }
+
+		serveRequest(rateLimiter, "")
+
+		responseRecoreder := serveRequest(rateLimiter, "")
+		if responseRecoreder.Code != http.StatusTooManyRequests {
+			t.Errorf("expected status code %d but got %d", http.StatusTooManyRequests, responseRecoreder.Code)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nerijusro/scootinAboot/types/enums"
+	"github.com/nerijusro/scootinAboot/types/interfaces"
+)
+
+// PublicRoutes, ClientRoutes, AdminRoutes and DeviceRoutes name the route groups, rate limiters are registered under the same names.
+const (
+	PublicRoutes = "public"
+	ClientRoutes = "client"
+	AdminRoutes  = "admin"
+	DeviceRoutes = "device"
+)
+
+type Routes struct {
+	public      *gin.RouterGroup
+	client      *gin.RouterGroup
+	admin       *gin.RouterGroup
+	device      *gin.RouterGroup
+	authService interfaces.AuthService
+	idempotency interfaces.IdempotencyService
+}
+
+// NewRoutes builds the route groups. A group is only rate limited if a limiter is registered under its name.
+func NewRoutes(
+	e *gin.Engine,
+	authService interfaces.AuthService,
+	rateLimiters map[string]interfaces.RateLimiter,
+	idempotency interfaces.IdempotencyService) *Routes {
+	return &Routes{
+		public:      e.Group("", withRateLimiter(rateLimiters[PublicRoutes])...),
+		client:      e.Group("/client", withRateLimiter(rateLimiters[ClientRoutes], authService.AuthenticateClient)...),
+		admin:       e.Group("/admin", withRateLimiter(rateLimiters[AdminRoutes], authService.AuthenticateAdmin)...),
+		device:      e.Group("/device", withRateLimiter(rateLimiters[DeviceRoutes], authService.AuthenticateDevice)...),
+		authService: authService,
+		idempotency: idempotency,
+	}
+}
+
+func (r *Routes) Public() gin.IRoutes {
+	return r.public
+}
+
+func (r *Routes) Client() gin.IRoutes {
+	return r.client
+}
+
+// IdempotentClient returns the client group honoring the Idempotency-Key header, meant for endpoints creating resources.
+func (r *Routes) IdempotentClient() gin.IRoutes {
+	return r.client.Group("", r.idempotency.Handle)
+}
+
+// Admin returns the admin group guarded by the given permission, it should be asked for once per route.
+func (r *Routes) Admin(permission enums.Permission) gin.IRoutes {
+	return r.admin.Group("", r.authService.RequirePermission(permission))
+}
+
+// Device returns the group for requests made by the scooters themselves.
+func (r *Routes) Device() gin.IRoutes {
+	return r.device
+}
+
+// withRateLimiter appends the limiter after authentication, so that it knows who the caller is.
+func withRateLimiter(rateLimiter interfaces.RateLimiter, handlers ...gin.HandlerFunc) []gin.HandlerFunc {
+	if rateLimiter == nil {
+		return handlers
+	}
+
+	return append(handlers, rateLimiter.Limit)
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+// scooterTransitions lists the statuses a scooter may move to from every status.
+// Retired scooters never come back, so they are not listed.
+var scooterTransitions = map[enums.ScooterStatus][]enums.ScooterStatus{
+	enums.AvailableScooter: {
+		enums.InTripScooter, enums.ReservedScooter, enums.MaintenanceScooter, enums.ChargingScooter, enums.RetiredScooter,
+	},
+	enums.ReservedScooter:    {enums.AvailableScooter, enums.InTripScooter},
+	enums.InTripScooter:      {enums.AvailableScooter, enums.ChargingScooter},
+	enums.MaintenanceScooter: {enums.AvailableScooter, enums.ChargingScooter, enums.RetiredScooter},
+	enums.ChargingScooter:    {enums.AvailableScooter, enums.MaintenanceScooter, enums.RetiredScooter},
+}
+
+func IsValidScooterStatus(status enums.ScooterStatus) bool {
+	_, ok := scooterTransitions[status]
+	return ok || status == enums.RetiredScooter
+}
+
+// ValidateScooterTransition accepts staying in the same status, so that repeated requests are harmless.
+func ValidateScooterTransition(from enums.ScooterStatus, to enums.ScooterStatus) error {
+	if from == to || slices.Contains(scooterTransitions[from], to) {
+		return nil
+	}
+
+	return fmt.Errorf("scooter can not go from %s to %s", from, to)
+}
+
+// IdleScooterStatus is the status a scooter is left in once nothing holds it anymore.
+// A scooter with a low battery is taken out of service until it is charged.
+func IdleScooterStatus(batteryLevel int, lowBatteryThreshold int) enums.ScooterStatus {
+	if batteryLevel < lowBatteryThreshold {
+		return enums.ChargingScooter
+	}
+
+	return enums.AvailableScooter
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestScooterStatus(t *testing.T) {
+	t.Run("When validating transition while it is allowed returns nil", func(t *testing.T) {
+		if err := ValidateScooterTransition(enums.AvailableScooter, enums.MaintenanceScooter); err != nil {
+			t.Errorf("expected result to be nil, got %s", err.Error())
+		}
+	})
+
+	t.Run("When validating transition while status does not change returns nil", func(t *testing.T) {
+		if err := ValidateScooterTransition(enums.MaintenanceScooter, enums.MaintenanceScooter); err != nil {
+			t.Errorf("expected result to be nil, got %s", err.Error())
+		}
+	})
+
+	t.Run("When validating transition while scooter is in a trip returns error", func(t *testing.T) {
+		err := ValidateScooterTransition(enums.InTripScooter, enums.MaintenanceScooter)
+		if err == nil || err.Error() != "scooter can not go from in_trip to maintenance" {
+			t.Errorf("expected result to be: scooter can not go from in_trip to maintenance, got %v", err)
+		}
+	})
+
+	t.Run("When validating transition while scooter is retired returns error", func(t *testing.T) {
+		if err := ValidateScooterTransition(enums.RetiredScooter, enums.AvailableScooter); err == nil {
+			t.Errorf("expected retired scooter not to come back")
+		}
+	})
+
+	t.Run("When validating status while status is unknown returns false", func(t *testing.T) {
+		if IsValidScooterStatus("broken") {
+			t.Errorf("expected status broken to be invalid")
+		}
+
+		if !IsValidScooterStatus(enums.RetiredScooter) {
+			t.Errorf("expected status retired to be valid")
+		}
+	})
+
+	t.Run("When choosing idle status while battery is low returns charging", func(t *testing.T) {
+		if status := IdleScooterStatus(10, 15); status != enums.ChargingScooter {
+			t.Errorf("expected status to be charging, got %s", status)
+		}
+
+		if status := IdleScooterStatus(15, 15); status != enums.AvailableScooter {
+			t.Errorf("expected status to be available, got %s", status)
+		}
+	})
+}
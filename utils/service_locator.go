@@ -7,8 +7,11 @@ import (
 )
 
 type ServiceLocator struct {
-	EndpointHandlers map[string]interfaces.EndpointHandler
-	AuthMiddlewares  map[string]interfaces.AuthService
+	EndpointHandlers       map[string]interfaces.EndpointHandler
+	AuthMiddlewares        map[string]interfaces.AuthService
+	RateLimiters           map[string]interfaces.RateLimiter
+	IdempotencyMiddlewares map[string]interfaces.IdempotencyService
+	BackgroundJobs         map[string]interfaces.BackgroundJob
 }
 
 func (sl *ServiceLocator) GetEndpointHandler(name string) (interfaces.EndpointHandler, error) {
@@ -27,6 +30,30 @@ func (sl *ServiceLocator) GetAuthMiddleware(name string) (interfaces.AuthService
 	return service, nil
 }
 
+func (sl *ServiceLocator) GetRateLimiter(name string) (interfaces.RateLimiter, error) {
+	service, ok := sl.RateLimiters[name]
+	if !ok {
+		return nil, fmt.Errorf("service %s not found", name)
+	}
+	return service, nil
+}
+
+func (sl *ServiceLocator) GetIdempotencyMiddleware(name string) (interfaces.IdempotencyService, error) {
+	service, ok := sl.IdempotencyMiddlewares[name]
+	if !ok {
+		return nil, fmt.Errorf("service %s not found", name)
+	}
+	return service, nil
+}
+
+func (sl *ServiceLocator) GetBackgroundJob(name string) (interfaces.BackgroundJob, error) {
+	service, ok := sl.BackgroundJobs[name]
+	if !ok {
+		return nil, fmt.Errorf("service %s not found", name)
+	}
+	return service, nil
+}
+
 func (sl *ServiceLocator) RegisterEndpointHandler(name string, handler interfaces.EndpointHandler) {
 	sl.EndpointHandlers[name] = handler
 }
@@ -34,3 +61,15 @@ func (sl *ServiceLocator) RegisterEndpointHandler(name string, handler interface
 func (sl *ServiceLocator) RegisterAuthMiddleware(name string, authMiddleware interfaces.AuthService) {
 	sl.AuthMiddlewares[name] = authMiddleware
 }
+
+func (sl *ServiceLocator) RegisterRateLimiter(name string, rateLimiter interfaces.RateLimiter) {
+	sl.RateLimiters[name] = rateLimiter
+}
+
+func (sl *ServiceLocator) RegisterIdempotencyMiddleware(name string, idempotency interfaces.IdempotencyService) {
+	sl.IdempotencyMiddlewares[name] = idempotency
+}
+
+func (sl *ServiceLocator) RegisterBackgroundJob(name string, job interfaces.BackgroundJob) {
+	sl.BackgroundJobs[name] = job
+}
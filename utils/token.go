@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// tokenHeader is the same for every token, since only HS256 is ever issued or accepted.
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type tokenClaims struct {
+	Subject   uuid.UUID  `json:"sub"`
+	Audience  string     `json:"aud"`
+	Role      enums.Role `json:"role,omitempty"`
+	IssuedAt  int64      `json:"iat"`
+	ExpiresAt int64      `json:"exp"`
+	AuthTime  int64      `json:"auth_time,omitempty"`
+}
+
+// TokenService issues and verifies HMAC signed JWTs for a single audience.
+type TokenService struct {
+	secret   []byte
+	ttl      time.Duration
+	audience string
+	now      func() time.Time
+}
+
+func NewTokenService(secret string, ttl time.Duration, audience string) *TokenService {
+	return &TokenService{secret: []byte(secret), ttl: ttl, audience: audience, now: time.Now}
+}
+
+// IssueToken keeps the time the subject authenticated at across refreshed tokens, a new session starts when it is not given.
+func (s *TokenService) IssueToken(claims types.TokenClaims) (*types.AuthToken, error) {
+	issuedAt := s.now()
+	expiresAt := issuedAt.Add(s.ttl)
+	authenticatedAt := claims.AuthenticatedAt
+	if authenticatedAt.IsZero() {
+		authenticatedAt = issuedAt
+	}
+
+	marshalledClaims, err := json.Marshal(tokenClaims{
+		Subject:   claims.Subject,
+		Audience:  s.audience,
+		Role:      claims.Role,
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		AuthTime:  authenticatedAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedToken := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(marshalledClaims)
+	return &types.AuthToken{
+		Token:     unsignedToken + "." + s.sign(unsignedToken),
+		ExpiresAt: time.Unix(expiresAt.Unix(), 0).UTC(),
+	}, nil
+}
+
+func (s *TokenService) ParseToken(token string) (*types.TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return nil, ErrInvalidToken
+	}
+
+	expectedSignature := s.sign(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(expectedSignature)) {
+		return nil, ErrInvalidToken
+	}
+
+	marshalledClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(marshalledClaims, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Audience != s.audience || claims.Subject == uuid.Nil {
+		return nil, ErrInvalidToken
+	}
+
+	if s.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	// Tokens issued before sessions were tracked started their session when they were issued
+	authTime := claims.AuthTime
+	if authTime == 0 {
+		authTime = claims.IssuedAt
+	}
+
+	return &types.TokenClaims{Subject: claims.Subject, Role: claims.Role, AuthenticatedAt: time.Unix(authTime, 0).UTC()}, nil
+}
+
+func (s *TokenService) sign(unsignedToken string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(unsignedToken))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nerijusro/scootinAboot/types"
+	"github.com/nerijusro/scootinAboot/types/enums"
+)
+
+func TestTokenService(t *testing.T) {
+	subject := uuid.MustParse("bec6a2fb-896f-473e-1111-a4208d033498")
+
+	t.Run("When parsing issued token returns its subject", func(t *testing.T) {
+		tokens := NewTokenService("secret", time.Hour, "client")
+
+		token, err := tokens.IssueToken(types.TokenClaims{Subject: subject})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		claims, err := tokens.ParseToken(token.Token)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if claims.Subject != subject {
+			t.Errorf("expected subject to be %s, got %s", subject, claims.Subject)
+		}
+	})
+
+	t.Run("When parsing issued token while role is given returns its role", func(t *testing.T) {
+		tokens := NewTokenService("secret", time.Hour, "admin")
+
+		token, _ := tokens.IssueToken(types.TokenClaims{Subject: subject, Role: enums.SupportRole})
+
+		claims, err := tokens.ParseToken(token.Token)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if claims.Role != enums.SupportRole {
+			t.Errorf("expected role to be %s, got %s", enums.SupportRole, claims.Role)
+		}
+	})
+
+	t.Run("When parsing token signed with another secret returns invalid token", func(t *testing.T) {
+		token, _ := NewTokenService("other-secret", time.Hour, "client").IssueToken(types.TokenClaims{Subject: subject})
+
+		_, err := NewTokenService("secret", time.Hour, "client").ParseToken(token.Token)
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected invalid token error, got %v", err)
+		}
+	})
+
+	t.Run("When parsing token issued for another audience returns invalid token", func(t *testing.T) {
+		token, _ := NewTokenService("secret", time.Hour, "admin").IssueToken(types.TokenClaims{Subject: subject})
+
+		_, err := NewTokenService("secret", time.Hour, "client").ParseToken(token.Token)
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected invalid token error, got %v", err)
+		}
+	})
+
+	t.Run("When parsing refreshed token returns the time its session started at", func(t *testing.T) {
+		tokens := NewTokenService("secret", time.Hour, "client")
+		authenticatedAt := time.Now().Add(-48 * time.Hour).Truncate(time.Second).UTC()
+
+		token, _ := tokens.IssueToken(types.TokenClaims{Subject: subject, AuthenticatedAt: authenticatedAt})
+
+		claims, err := tokens.ParseToken(token.Token)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if !claims.AuthenticatedAt.Equal(authenticatedAt) {
+			t.Errorf("expected authentication time to be %s, got %s", authenticatedAt, claims.AuthenticatedAt)
+		}
+	})
+
+	t.Run("When parsing expired token returns expired token", func(t *testing.T) {
+		tokens := NewTokenService("secret", time.Hour, "client")
+		token, _ := tokens.IssueToken(types.TokenClaims{Subject: subject})
+
+		tokens.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+		_, err := tokens.ParseToken(token.Token)
+		if !errors.Is(err, ErrExpiredToken) {
+			t.Errorf("expected expired token error, got %v", err)
+		}
+	})
+
+	t.Run("When parsing malformed token returns invalid token", func(t *testing.T) {
+		_, err := NewTokenService("secret", time.Hour, "client").ParseToken("not.a.token")
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected invalid token error, got %v", err)
+		}
+	})
+}